@@ -13,8 +13,12 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	_ "google.golang.org/protobuf/types/gofeaturespb"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -26,6 +30,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// A way of contacting a publisher.
+type ContactChannel int32
+
+const (
+	ContactChannel_CONTACT_CHANNEL_UNSPECIFIED ContactChannel = 0
+	ContactChannel_CONTACT_CHANNEL_EMAIL       ContactChannel = 1
+	// Call the publisher's office.
+	ContactChannel_CONTACT_CHANNEL_PHONE ContactChannel = 2
+	ContactChannel_CONTACT_CHANNEL_POST  ContactChannel = 3
+)
+
+// Enum value maps for ContactChannel.
+var (
+	ContactChannel_name = map[int32]string{
+		0: "CONTACT_CHANNEL_UNSPECIFIED",
+		1: "CONTACT_CHANNEL_EMAIL",
+		2: "CONTACT_CHANNEL_PHONE",
+		3: "CONTACT_CHANNEL_POST",
+	}
+	ContactChannel_value = map[string]int32{
+		"CONTACT_CHANNEL_UNSPECIFIED": 0,
+		"CONTACT_CHANNEL_EMAIL":       1,
+		"CONTACT_CHANNEL_PHONE":       2,
+		"CONTACT_CHANNEL_POST":        3,
+	}
+)
+
+func (x ContactChannel) Enum() *ContactChannel {
+	p := new(ContactChannel)
+	*p = x
+	return p
+}
+
+func (x ContactChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContactChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_bookstore_v1_bookstore_proto_enumTypes[0].Descriptor()
+}
+
+func (ContactChannel) Type() protoreflect.EnumType {
+	return &file_bookstore_v1_bookstore_proto_enumTypes[0]
+}
+
+func (x ContactChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type Author_Gender int32
 
 const (
@@ -59,11 +112,11 @@ func (x Author_Gender) String() string {
 }
 
 func (Author_Gender) Descriptor() protoreflect.EnumDescriptor {
-	return file_bookstore_v1_bookstore_proto_enumTypes[0].Descriptor()
+	return file_bookstore_v1_bookstore_proto_enumTypes[1].Descriptor()
 }
 
 func (Author_Gender) Type() protoreflect.EnumType {
-	return &file_bookstore_v1_bookstore_proto_enumTypes[0]
+	return &file_bookstore_v1_bookstore_proto_enumTypes[1]
 }
 
 func (x Author_Gender) Number() protoreflect.EnumNumber {
@@ -1174,6 +1227,110 @@ func (*getAuthorResponse_Fiction) isGetAuthorResponse_Genre() {}
 
 func (*getAuthorResponse_Nonfiction) isGetAuthorResponse_Genre() {}
 
+// The author featured on a shelf, if any.
+type FeaturedAuthor struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShelfId     *string                `protobuf:"bytes,1,opt,name=shelf_id,json=shelfId"`
+	xxx_hidden_Author      *GetAuthorResponse     `protobuf:"bytes,2,opt,name=author"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FeaturedAuthor) Reset() {
+	*x = FeaturedAuthor{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeaturedAuthor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeaturedAuthor) ProtoMessage() {}
+
+func (x *FeaturedAuthor) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FeaturedAuthor) GetShelfId() string {
+	if x != nil {
+		if x.xxx_hidden_ShelfId != nil {
+			return *x.xxx_hidden_ShelfId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FeaturedAuthor) GetAuthor() *GetAuthorResponse {
+	if x != nil {
+		return x.xxx_hidden_Author
+	}
+	return nil
+}
+
+func (x *FeaturedAuthor) SetShelfId(v string) {
+	x.xxx_hidden_ShelfId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *FeaturedAuthor) SetAuthor(v *GetAuthorResponse) {
+	x.xxx_hidden_Author = v
+}
+
+func (x *FeaturedAuthor) HasShelfId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FeaturedAuthor) HasAuthor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Author != nil
+}
+
+func (x *FeaturedAuthor) ClearShelfId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ShelfId = nil
+}
+
+func (x *FeaturedAuthor) ClearAuthor() {
+	x.xxx_hidden_Author = nil
+}
+
+type FeaturedAuthor_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShelfId *string
+	Author  *GetAuthorResponse
+}
+
+func (b0 FeaturedAuthor_builder) Build() *FeaturedAuthor {
+	m0 := &FeaturedAuthor{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ShelfId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_ShelfId = b.ShelfId
+	}
+	x.xxx_hidden_Author = b.Author
+	return m0
+}
+
 // A shelf resource.
 type Shelf struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
@@ -1189,7 +1346,7 @@ type Shelf struct {
 
 func (x *Shelf) Reset() {
 	*x = Shelf{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[16]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1201,7 +1358,7 @@ func (x *Shelf) String() string {
 func (*Shelf) ProtoMessage() {}
 
 func (x *Shelf) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[16]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1369,7 +1526,7 @@ type Genre struct {
 
 func (x *Genre) Reset() {
 	*x = Genre{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[17]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1381,7 +1538,7 @@ func (x *Genre) String() string {
 func (*Genre) ProtoMessage() {}
 
 func (x *Genre) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[17]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1475,6 +1632,7 @@ type Book struct {
 	xxx_hidden_Title       *string                `protobuf:"bytes,3,opt,name=title"`
 	xxx_hidden_Quotes      []string               `protobuf:"bytes,4,rep,name=quotes"`
 	xxx_hidden_ShelfId     *string                `protobuf:"bytes,5,opt,name=shelf_id,json=shelfId"`
+	xxx_hidden_CreatedBy   *string                `protobuf:"bytes,6,opt,name=created_by,json=createdBy"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -1483,7 +1641,7 @@ type Book struct {
 
 func (x *Book) Reset() {
 	*x = Book{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[18]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1495,7 +1653,7 @@ func (x *Book) String() string {
 func (*Book) ProtoMessage() {}
 
 func (x *Book) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[18]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1553,19 +1711,29 @@ func (x *Book) GetShelfId() string {
 	return ""
 }
 
+func (x *Book) GetCreatedBy() string {
+	if x != nil {
+		if x.xxx_hidden_CreatedBy != nil {
+			return *x.xxx_hidden_CreatedBy
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *Book) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
 }
 
 func (x *Book) SetAuthor(v string) {
 	x.xxx_hidden_Author = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
 }
 
 func (x *Book) SetTitle(v string) {
 	x.xxx_hidden_Title = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
 }
 
 func (x *Book) SetQuotes(v []string) {
@@ -1574,7 +1742,12 @@ func (x *Book) SetQuotes(v []string) {
 
 func (x *Book) SetShelfId(v string) {
 	x.xxx_hidden_ShelfId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *Book) SetCreatedBy(v string) {
+	x.xxx_hidden_CreatedBy = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
 }
 
 func (x *Book) HasId() bool {
@@ -1605,6 +1778,13 @@ func (x *Book) HasShelfId() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
+func (x *Book) HasCreatedBy() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
 func (x *Book) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = nil
@@ -1625,6 +1805,11 @@ func (x *Book) ClearShelfId() {
 	x.xxx_hidden_ShelfId = nil
 }
 
+func (x *Book) ClearCreatedBy() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_CreatedBy = nil
+}
+
 type Book_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1637,6 +1822,8 @@ type Book_builder struct {
 	// Quotes from the book.
 	Quotes  []string
 	ShelfId *string
+	// The user who added the book.
+	CreatedBy *string
 }
 
 func (b0 Book_builder) Build() *Book {
@@ -1644,22 +1831,26 @@ func (b0 Book_builder) Build() *Book {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
 		x.xxx_hidden_Id = b.Id
 	}
 	if b.Author != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
 		x.xxx_hidden_Author = b.Author
 	}
 	if b.Title != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
 		x.xxx_hidden_Title = b.Title
 	}
 	x.xxx_hidden_Quotes = b.Quotes
 	if b.ShelfId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
 		x.xxx_hidden_ShelfId = b.ShelfId
 	}
+	if b.CreatedBy != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_CreatedBy = b.CreatedBy
+	}
 	return m0
 }
 
@@ -1681,7 +1872,7 @@ type Author struct {
 
 func (x *Author) Reset() {
 	*x = Author{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[19]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1693,7 +1884,7 @@ func (x *Author) String() string {
 func (*Author) ProtoMessage() {}
 
 func (x *Author) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[19]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1923,7 +2114,7 @@ type ListShelvesResponse struct {
 
 func (x *ListShelvesResponse) Reset() {
 	*x = ListShelvesResponse{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[20]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1935,7 +2126,7 @@ func (x *ListShelvesResponse) String() string {
 func (*ListShelvesResponse) ProtoMessage() {}
 
 func (x *ListShelvesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[20]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2008,7 +2199,7 @@ type CreateShelfRequest struct {
 
 func (x *CreateShelfRequest) Reset() {
 	*x = CreateShelfRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[21]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2020,7 +2211,7 @@ func (x *CreateShelfRequest) String() string {
 func (*CreateShelfRequest) ProtoMessage() {}
 
 func (x *CreateShelfRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[21]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2080,7 +2271,7 @@ type GetShelfRequest struct {
 
 func (x *GetShelfRequest) Reset() {
 	*x = GetShelfRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[22]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2092,7 +2283,7 @@ func (x *GetShelfRequest) String() string {
 func (*GetShelfRequest) ProtoMessage() {}
 
 func (x *GetShelfRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[22]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2160,7 +2351,7 @@ type DeleteShelfRequest struct {
 
 func (x *DeleteShelfRequest) Reset() {
 	*x = DeleteShelfRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[23]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2172,7 +2363,7 @@ func (x *DeleteShelfRequest) String() string {
 func (*DeleteShelfRequest) ProtoMessage() {}
 
 func (x *DeleteShelfRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[23]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2240,7 +2431,7 @@ type ListBooksRequest struct {
 
 func (x *ListBooksRequest) Reset() {
 	*x = ListBooksRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[24]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2252,7 +2443,7 @@ func (x *ListBooksRequest) String() string {
 func (*ListBooksRequest) ProtoMessage() {}
 
 func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[24]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2308,32 +2499,31 @@ func (b0 ListBooksRequest_builder) Build() *ListBooksRequest {
 	return m0
 }
 
-// Request message for CreateBook method.
-type CreateBookRequest struct {
+// Request message for ExportBooks method.
+type ExportBooksRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Shelf       *string                `protobuf:"bytes,1,opt,name=shelf"`
-	xxx_hidden_Book        *Book                  `protobuf:"bytes,2,opt,name=book"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *CreateBookRequest) Reset() {
-	*x = CreateBookRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[25]
+func (x *ExportBooksRequest) Reset() {
+	*x = ExportBooksRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBookRequest) String() string {
+func (x *ExportBooksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBookRequest) ProtoMessage() {}
+func (*ExportBooksRequest) ProtoMessage() {}
 
-func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[25]
+func (x *ExportBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2344,7 +2534,7 @@ func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *CreateBookRequest) GetShelf() string {
+func (x *ExportBooksRequest) GetShelf() string {
 	if x != nil {
 		if x.xxx_hidden_Shelf != nil {
 			return *x.xxx_hidden_Shelf
@@ -2354,95 +2544,67 @@ func (x *CreateBookRequest) GetShelf() string {
 	return ""
 }
 
-func (x *CreateBookRequest) GetBook() *Book {
-	if x != nil {
-		return x.xxx_hidden_Book
-	}
-	return nil
-}
-
-func (x *CreateBookRequest) SetShelf(v string) {
+func (x *ExportBooksRequest) SetShelf(v string) {
 	x.xxx_hidden_Shelf = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
-}
-
-func (x *CreateBookRequest) SetBook(v *Book) {
-	x.xxx_hidden_Book = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (x *CreateBookRequest) HasShelf() bool {
+func (x *ExportBooksRequest) HasShelf() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *CreateBookRequest) HasBook() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Book != nil
-}
-
-func (x *CreateBookRequest) ClearShelf() {
+func (x *ExportBooksRequest) ClearShelf() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Shelf = nil
 }
 
-func (x *CreateBookRequest) ClearBook() {
-	x.xxx_hidden_Book = nil
-}
-
-type CreateBookRequest_builder struct {
+type ExportBooksRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The ID of the shelf on which to create a book.
+	// ID of the shelf which books to export.
 	Shelf *string
-	// A book resource to create on the shelf.
-	Book *Book
 }
 
-func (b0 CreateBookRequest_builder) Build() *CreateBookRequest {
-	m0 := &CreateBookRequest{}
+func (b0 ExportBooksRequest_builder) Build() *ExportBooksRequest {
+	m0 := &ExportBooksRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Shelf != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
 		x.xxx_hidden_Shelf = b.Shelf
 	}
-	x.xxx_hidden_Book = b.Book
 	return m0
 }
 
-// Request message for GetBook method.
-type GetBookRequest struct {
-	state                    protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Shelf         *string                `protobuf:"bytes,1,opt,name=shelf"`
-	xxx_hidden_Book          int64                  `protobuf:"varint,2,opt,name=book"`
-	xxx_hidden_IncludeAuthor bool                   `protobuf:"varint,3,opt,name=include_author,json=includeAuthor"`
-	xxx_hidden_PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize"`
-	xxx_hidden_PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken"`
-	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
-	XXX_presence             [1]uint32
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+// Request message for ImportBooks method, sent once per book.
+type ImportBooksRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Shelf       *string                `protobuf:"bytes,1,opt,name=shelf"`
+	xxx_hidden_Book        *Book                  `protobuf:"bytes,2,opt,name=book"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *GetBookRequest) Reset() {
-	*x = GetBookRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[26]
+func (x *ImportBooksRequest) Reset() {
+	*x = ImportBooksRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBookRequest) String() string {
+func (x *ImportBooksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBookRequest) ProtoMessage() {}
+func (*ImportBooksRequest) ProtoMessage() {}
 
-func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[26]
+func (x *ImportBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2453,7 +2615,7 @@ func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *GetBookRequest) GetShelf() string {
+func (x *ImportBooksRequest) GetShelf() string {
 	if x != nil {
 		if x.xxx_hidden_Shelf != nil {
 			return *x.xxx_hidden_Shelf
@@ -2463,18 +2625,267 @@ func (x *GetBookRequest) GetShelf() string {
 	return ""
 }
 
-func (x *GetBookRequest) GetBook() int64 {
+func (x *ImportBooksRequest) GetBook() *Book {
 	if x != nil {
 		return x.xxx_hidden_Book
 	}
-	return 0
+	return nil
 }
 
-func (x *GetBookRequest) GetIncludeAuthor() bool {
-	if x != nil {
-		return x.xxx_hidden_IncludeAuthor
-	}
-	return false
+func (x *ImportBooksRequest) SetShelf(v string) {
+	x.xxx_hidden_Shelf = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ImportBooksRequest) SetBook(v *Book) {
+	x.xxx_hidden_Book = v
+}
+
+func (x *ImportBooksRequest) HasShelf() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ImportBooksRequest) HasBook() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Book != nil
+}
+
+func (x *ImportBooksRequest) ClearShelf() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Shelf = nil
+}
+
+func (x *ImportBooksRequest) ClearBook() {
+	x.xxx_hidden_Book = nil
+}
+
+type ImportBooksRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The ID of the shelf on which to add the book.
+	Shelf *string
+	// The book to add.
+	Book *Book
+}
+
+func (b0 ImportBooksRequest_builder) Build() *ImportBooksRequest {
+	m0 := &ImportBooksRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Shelf != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Shelf = b.Shelf
+	}
+	x.xxx_hidden_Book = b.Book
+	return m0
+}
+
+// Request message for CreateBook method.
+type CreateBookRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Shelf       *string                `protobuf:"bytes,1,opt,name=shelf"`
+	xxx_hidden_Book        *Book                  `protobuf:"bytes,2,opt,name=book"`
+	xxx_hidden_TenantId    *string                `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateBookRequest) Reset() {
+	*x = CreateBookRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookRequest) ProtoMessage() {}
+
+func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateBookRequest) GetShelf() string {
+	if x != nil {
+		if x.xxx_hidden_Shelf != nil {
+			return *x.xxx_hidden_Shelf
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetBook() *Book {
+	if x != nil {
+		return x.xxx_hidden_Book
+	}
+	return nil
+}
+
+func (x *CreateBookRequest) GetTenantId() string {
+	if x != nil {
+		if x.xxx_hidden_TenantId != nil {
+			return *x.xxx_hidden_TenantId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) SetShelf(v string) {
+	x.xxx_hidden_Shelf = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *CreateBookRequest) SetBook(v *Book) {
+	x.xxx_hidden_Book = v
+}
+
+func (x *CreateBookRequest) SetTenantId(v string) {
+	x.xxx_hidden_TenantId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *CreateBookRequest) HasShelf() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateBookRequest) HasBook() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Book != nil
+}
+
+func (x *CreateBookRequest) HasTenantId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CreateBookRequest) ClearShelf() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Shelf = nil
+}
+
+func (x *CreateBookRequest) ClearBook() {
+	x.xxx_hidden_Book = nil
+}
+
+func (x *CreateBookRequest) ClearTenantId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_TenantId = nil
+}
+
+type CreateBookRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The ID of the shelf on which to create a book.
+	Shelf *string
+	// A book resource to create on the shelf.
+	Book *Book
+	// The tenant of the caller.
+	TenantId *string
+}
+
+func (b0 CreateBookRequest_builder) Build() *CreateBookRequest {
+	m0 := &CreateBookRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Shelf != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Shelf = b.Shelf
+	}
+	x.xxx_hidden_Book = b.Book
+	if b.TenantId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_TenantId = b.TenantId
+	}
+	return m0
+}
+
+// Request message for GetBook method.
+type GetBookRequest struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Shelf         *string                `protobuf:"bytes,1,opt,name=shelf"`
+	xxx_hidden_Book          int64                  `protobuf:"varint,2,opt,name=book"`
+	xxx_hidden_IncludeAuthor bool                   `protobuf:"varint,3,opt,name=include_author,json=includeAuthor"`
+	xxx_hidden_PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize"`
+	xxx_hidden_PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetBookRequest) GetShelf() string {
+	if x != nil {
+		if x.xxx_hidden_Shelf != nil {
+			return *x.xxx_hidden_Shelf
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetBookRequest) GetBook() int64 {
+	if x != nil {
+		return x.xxx_hidden_Book
+	}
+	return 0
+}
+
+func (x *GetBookRequest) GetIncludeAuthor() bool {
+	if x != nil {
+		return x.xxx_hidden_IncludeAuthor
+	}
+	return false
 }
 
 func (x *GetBookRequest) GetPageSize() int32 {
@@ -2631,7 +3042,7 @@ type UpdateBookRequest struct {
 
 func (x *UpdateBookRequest) Reset() {
 	*x = UpdateBookRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[27]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2643,7 +3054,7 @@ func (x *UpdateBookRequest) String() string {
 func (*UpdateBookRequest) ProtoMessage() {}
 
 func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[27]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2734,7 +3145,7 @@ type DeleteBookRequest struct {
 
 func (x *DeleteBookRequest) Reset() {
 	*x = DeleteBookRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[28]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2746,7 +3157,7 @@ func (x *DeleteBookRequest) String() string {
 func (*DeleteBookRequest) ProtoMessage() {}
 
 func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[28]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2806,7 +3217,7 @@ type GetAuthorRequest struct {
 
 func (x *GetAuthorRequest) Reset() {
 	*x = GetAuthorRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[29]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2818,7 +3229,7 @@ func (x *GetAuthorRequest) String() string {
 func (*GetAuthorRequest) ProtoMessage() {}
 
 func (x *GetAuthorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[29]
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2871,31 +3282,40 @@ func (b0 GetAuthorRequest_builder) Build() *GetAuthorRequest {
 	return m0
 }
 
-// A recursive comment for the recursive request
-type RecursiveBookRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_BookId      *string                `protobuf:"bytes,1,opt,name=book_id,json=bookId"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
-}
-
-func (x *RecursiveBookRequest) Reset() {
-	*x = RecursiveBookRequest{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[30]
+// Request message for a search of books, to test buf.validate CEL rules.
+type SearchBooksRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Query          *string                `protobuf:"bytes,1,opt,name=query"`
+	xxx_hidden_Isbn           *string                `protobuf:"bytes,2,opt,name=isbn"`
+	xxx_hidden_Title          *string                `protobuf:"bytes,3,opt,name=title"`
+	xxx_hidden_Author         *string                `protobuf:"bytes,4,opt,name=author"`
+	xxx_hidden_FirstPage      int32                  `protobuf:"varint,5,opt,name=first_page,json=firstPage"`
+	xxx_hidden_LastPage       int32                  `protobuf:"varint,6,opt,name=last_page,json=lastPage"`
+	xxx_hidden_Tag            *string                `protobuf:"bytes,7,opt,name=tag"`
+	xxx_hidden_Shelves        []string               `protobuf:"bytes,8,rep,name=shelves"`
+	xxx_hidden_PublishedAfter *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=published_after,json=publishedAfter"`
+	xxx_hidden_MaxPriceCents  int64                  `protobuf:"varint,10,opt,name=max_price_cents,json=maxPriceCents"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *SearchBooksRequest) Reset() {
+	*x = SearchBooksRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecursiveBookRequest) String() string {
+func (x *SearchBooksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecursiveBookRequest) ProtoMessage() {}
+func (*SearchBooksRequest) ProtoMessage() {}
 
-func (x *RecursiveBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[30]
+func (x *SearchBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2906,77 +3326,2869 @@ func (x *RecursiveBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *RecursiveBookRequest) GetBookId() string {
+func (x *SearchBooksRequest) GetQuery() string {
 	if x != nil {
-		if x.xxx_hidden_BookId != nil {
-			return *x.xxx_hidden_BookId
+		if x.xxx_hidden_Query != nil {
+			return *x.xxx_hidden_Query
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *RecursiveBookRequest) SetBookId(v string) {
-	x.xxx_hidden_BookId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+func (x *SearchBooksRequest) GetIsbn() string {
+	if x != nil {
+		if x.xxx_hidden_Isbn != nil {
+			return *x.xxx_hidden_Isbn
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchBooksRequest) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchBooksRequest) GetAuthor() string {
+	if x != nil {
+		if x.xxx_hidden_Author != nil {
+			return *x.xxx_hidden_Author
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchBooksRequest) GetFirstPage() int32 {
+	if x != nil {
+		return x.xxx_hidden_FirstPage
+	}
+	return 0
+}
+
+func (x *SearchBooksRequest) GetLastPage() int32 {
+	if x != nil {
+		return x.xxx_hidden_LastPage
+	}
+	return 0
+}
+
+func (x *SearchBooksRequest) GetTag() string {
+	if x != nil {
+		if x.xxx_hidden_Tag != nil {
+			return *x.xxx_hidden_Tag
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchBooksRequest) GetShelves() []string {
+	if x != nil {
+		return x.xxx_hidden_Shelves
+	}
+	return nil
+}
+
+func (x *SearchBooksRequest) GetPublishedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_PublishedAfter
+	}
+	return nil
+}
+
+func (x *SearchBooksRequest) GetMaxPriceCents() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxPriceCents
+	}
+	return 0
+}
+
+func (x *SearchBooksRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *SearchBooksRequest) SetIsbn(v string) {
+	x.xxx_hidden_Isbn = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *SearchBooksRequest) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *SearchBooksRequest) SetAuthor(v string) {
+	x.xxx_hidden_Author = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *SearchBooksRequest) SetFirstPage(v int32) {
+	x.xxx_hidden_FirstPage = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *SearchBooksRequest) SetLastPage(v int32) {
+	x.xxx_hidden_LastPage = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *SearchBooksRequest) SetTag(v string) {
+	x.xxx_hidden_Tag = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *SearchBooksRequest) SetShelves(v []string) {
+	x.xxx_hidden_Shelves = v
+}
+
+func (x *SearchBooksRequest) SetPublishedAfter(v *timestamppb.Timestamp) {
+	x.xxx_hidden_PublishedAfter = v
+}
+
+func (x *SearchBooksRequest) SetMaxPriceCents(v int64) {
+	x.xxx_hidden_MaxPriceCents = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+}
+
+func (x *SearchBooksRequest) HasQuery() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SearchBooksRequest) HasIsbn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SearchBooksRequest) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SearchBooksRequest) HasAuthor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *SearchBooksRequest) HasFirstPage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SearchBooksRequest) HasLastPage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SearchBooksRequest) HasTag() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *SearchBooksRequest) HasPublishedAfter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PublishedAfter != nil
+}
+
+func (x *SearchBooksRequest) HasMaxPriceCents() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *SearchBooksRequest) ClearQuery() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Query = nil
+}
+
+func (x *SearchBooksRequest) ClearIsbn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Isbn = nil
+}
+
+func (x *SearchBooksRequest) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *SearchBooksRequest) ClearAuthor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Author = nil
+}
+
+func (x *SearchBooksRequest) ClearFirstPage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_FirstPage = 0
+}
+
+func (x *SearchBooksRequest) ClearLastPage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_LastPage = 0
+}
+
+func (x *SearchBooksRequest) ClearTag() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Tag = nil
+}
+
+func (x *SearchBooksRequest) ClearPublishedAfter() {
+	x.xxx_hidden_PublishedAfter = nil
+}
+
+func (x *SearchBooksRequest) ClearMaxPriceCents() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_MaxPriceCents = 0
+}
+
+type SearchBooksRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Words to search for.
+	Query *string
+	// The ISBN-13 of the book.
+	Isbn *string
+	// The title of the book.
+	Title *string
+	// The author of the book.
+	Author    *string
+	FirstPage *int32
+	LastPage  *int32
+	// A tag of the book.
+	Tag            *string
+	Shelves        []string
+	PublishedAfter *timestamppb.Timestamp
+	// The highest price, in cents.
+	MaxPriceCents *int64
+}
+
+func (b0 SearchBooksRequest_builder) Build() *SearchBooksRequest {
+	m0 := &SearchBooksRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Query != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Query = b.Query
+	}
+	if b.Isbn != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Isbn = b.Isbn
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Author != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_Author = b.Author
+	}
+	if b.FirstPage != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_FirstPage = *b.FirstPage
+	}
+	if b.LastPage != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_LastPage = *b.LastPage
+	}
+	if b.Tag != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_Tag = b.Tag
+	}
+	x.xxx_hidden_Shelves = b.Shelves
+	x.xxx_hidden_PublishedAfter = b.PublishedAfter
+	if b.MaxPriceCents != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_MaxPriceCents = *b.MaxPriceCents
+	}
+	return m0
+}
+
+// How to reach the publisher of a book.
+type PublisherContact struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Email           *string                `protobuf:"bytes,1,opt,name=email"`
+	xxx_hidden_Website         *string                `protobuf:"bytes,2,opt,name=website"`
+	xxx_hidden_Host            *string                `protobuf:"bytes,3,opt,name=host"`
+	xxx_hidden_Ip              *string                `protobuf:"bytes,4,opt,name=ip"`
+	xxx_hidden_Network         *string                `protobuf:"bytes,5,opt,name=network"`
+	xxx_hidden_Endpoint        *string                `protobuf:"bytes,6,opt,name=endpoint"`
+	xxx_hidden_RequestId       *string                `protobuf:"bytes,7,opt,name=request_id,json=requestId"`
+	xxx_hidden_TraceId         *string                `protobuf:"bytes,8,opt,name=trace_id,json=traceId"`
+	xxx_hidden_Header          *string                `protobuf:"bytes,9,opt,name=header"`
+	xxx_hidden_Code            *string                `protobuf:"bytes,10,opt,name=code"`
+	xxx_hidden_Region          *string                `protobuf:"bytes,11,opt,name=region"`
+	xxx_hidden_KeyId           []byte                 `protobuf:"bytes,12,opt,name=key_id,json=keyId"`
+	xxx_hidden_Priority        int32                  `protobuf:"varint,13,opt,name=priority"`
+	xxx_hidden_Channel         ContactChannel         `protobuf:"varint,14,opt,name=channel,enum=bookstore.v1.ContactChannel"`
+	xxx_hidden_FallbackChannel ContactChannel         `protobuf:"varint,15,opt,name=fallback_channel,json=fallbackChannel,enum=bookstore.v1.ContactChannel"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *PublisherContact) Reset() {
+	*x = PublisherContact{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublisherContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublisherContact) ProtoMessage() {}
+
+func (x *PublisherContact) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PublisherContact) GetEmail() string {
+	if x != nil {
+		if x.xxx_hidden_Email != nil {
+			return *x.xxx_hidden_Email
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetWebsite() string {
+	if x != nil {
+		if x.xxx_hidden_Website != nil {
+			return *x.xxx_hidden_Website
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetHost() string {
+	if x != nil {
+		if x.xxx_hidden_Host != nil {
+			return *x.xxx_hidden_Host
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetIp() string {
+	if x != nil {
+		if x.xxx_hidden_Ip != nil {
+			return *x.xxx_hidden_Ip
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetNetwork() string {
+	if x != nil {
+		if x.xxx_hidden_Network != nil {
+			return *x.xxx_hidden_Network
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetEndpoint() string {
+	if x != nil {
+		if x.xxx_hidden_Endpoint != nil {
+			return *x.xxx_hidden_Endpoint
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetRequestId() string {
+	if x != nil {
+		if x.xxx_hidden_RequestId != nil {
+			return *x.xxx_hidden_RequestId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetTraceId() string {
+	if x != nil {
+		if x.xxx_hidden_TraceId != nil {
+			return *x.xxx_hidden_TraceId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetHeader() string {
+	if x != nil {
+		if x.xxx_hidden_Header != nil {
+			return *x.xxx_hidden_Header
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetCode() string {
+	if x != nil {
+		if x.xxx_hidden_Code != nil {
+			return *x.xxx_hidden_Code
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetRegion() string {
+	if x != nil {
+		if x.xxx_hidden_Region != nil {
+			return *x.xxx_hidden_Region
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PublisherContact) GetKeyId() []byte {
+	if x != nil {
+		return x.xxx_hidden_KeyId
+	}
+	return nil
+}
+
+func (x *PublisherContact) GetPriority() int32 {
+	if x != nil {
+		return x.xxx_hidden_Priority
+	}
+	return 0
+}
+
+func (x *PublisherContact) GetChannel() ContactChannel {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 13) {
+			return x.xxx_hidden_Channel
+		}
+	}
+	return ContactChannel_CONTACT_CHANNEL_UNSPECIFIED
+}
+
+func (x *PublisherContact) GetFallbackChannel() ContactChannel {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 14) {
+			return x.xxx_hidden_FallbackChannel
+		}
+	}
+	return ContactChannel_CONTACT_CHANNEL_UNSPECIFIED
+}
+
+func (x *PublisherContact) SetEmail(v string) {
+	x.xxx_hidden_Email = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 15)
+}
+
+func (x *PublisherContact) SetWebsite(v string) {
+	x.xxx_hidden_Website = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 15)
+}
+
+func (x *PublisherContact) SetHost(v string) {
+	x.xxx_hidden_Host = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 15)
+}
+
+func (x *PublisherContact) SetIp(v string) {
+	x.xxx_hidden_Ip = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 15)
+}
+
+func (x *PublisherContact) SetNetwork(v string) {
+	x.xxx_hidden_Network = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 15)
+}
+
+func (x *PublisherContact) SetEndpoint(v string) {
+	x.xxx_hidden_Endpoint = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 15)
+}
+
+func (x *PublisherContact) SetRequestId(v string) {
+	x.xxx_hidden_RequestId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 15)
+}
+
+func (x *PublisherContact) SetTraceId(v string) {
+	x.xxx_hidden_TraceId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 15)
+}
+
+func (x *PublisherContact) SetHeader(v string) {
+	x.xxx_hidden_Header = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 15)
+}
+
+func (x *PublisherContact) SetCode(v string) {
+	x.xxx_hidden_Code = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 15)
+}
+
+func (x *PublisherContact) SetRegion(v string) {
+	x.xxx_hidden_Region = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 15)
+}
+
+func (x *PublisherContact) SetKeyId(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_KeyId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 15)
+}
+
+func (x *PublisherContact) SetPriority(v int32) {
+	x.xxx_hidden_Priority = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 15)
+}
+
+func (x *PublisherContact) SetChannel(v ContactChannel) {
+	x.xxx_hidden_Channel = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 15)
+}
+
+func (x *PublisherContact) SetFallbackChannel(v ContactChannel) {
+	x.xxx_hidden_FallbackChannel = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 15)
+}
+
+func (x *PublisherContact) HasEmail() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *PublisherContact) HasWebsite() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *PublisherContact) HasHost() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *PublisherContact) HasIp() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *PublisherContact) HasNetwork() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *PublisherContact) HasEndpoint() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *PublisherContact) HasRequestId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *PublisherContact) HasTraceId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *PublisherContact) HasHeader() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *PublisherContact) HasCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *PublisherContact) HasRegion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *PublisherContact) HasKeyId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *PublisherContact) HasPriority() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *PublisherContact) HasChannel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 13)
+}
+
+func (x *PublisherContact) HasFallbackChannel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 14)
+}
+
+func (x *PublisherContact) ClearEmail() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Email = nil
+}
+
+func (x *PublisherContact) ClearWebsite() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Website = nil
+}
+
+func (x *PublisherContact) ClearHost() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Host = nil
+}
+
+func (x *PublisherContact) ClearIp() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Ip = nil
+}
+
+func (x *PublisherContact) ClearNetwork() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Network = nil
+}
+
+func (x *PublisherContact) ClearEndpoint() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Endpoint = nil
+}
+
+func (x *PublisherContact) ClearRequestId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_RequestId = nil
+}
+
+func (x *PublisherContact) ClearTraceId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_TraceId = nil
+}
+
+func (x *PublisherContact) ClearHeader() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Header = nil
+}
+
+func (x *PublisherContact) ClearCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Code = nil
+}
+
+func (x *PublisherContact) ClearRegion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_Region = nil
+}
+
+func (x *PublisherContact) ClearKeyId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_KeyId = nil
+}
+
+func (x *PublisherContact) ClearPriority() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_Priority = 0
+}
+
+func (x *PublisherContact) ClearChannel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 13)
+	x.xxx_hidden_Channel = ContactChannel_CONTACT_CHANNEL_UNSPECIFIED
+}
+
+func (x *PublisherContact) ClearFallbackChannel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 14)
+	x.xxx_hidden_FallbackChannel = ContactChannel_CONTACT_CHANNEL_UNSPECIFIED
+}
+
+type PublisherContact_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Email     *string
+	Website   *string
+	Host      *string
+	Ip        *string
+	Network   *string
+	Endpoint  *string
+	RequestId *string
+	TraceId   *string
+	Header    *string
+	// A catalog code, such as BK-1234-X.
+	Code     *string
+	Region   *string
+	KeyId    []byte
+	Priority *int32
+	// How the publisher prefers to be contacted.
+	Channel         *ContactChannel
+	FallbackChannel *ContactChannel
+}
+
+func (b0 PublisherContact_builder) Build() *PublisherContact {
+	m0 := &PublisherContact{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Email != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 15)
+		x.xxx_hidden_Email = b.Email
+	}
+	if b.Website != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 15)
+		x.xxx_hidden_Website = b.Website
+	}
+	if b.Host != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 15)
+		x.xxx_hidden_Host = b.Host
+	}
+	if b.Ip != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 15)
+		x.xxx_hidden_Ip = b.Ip
+	}
+	if b.Network != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 15)
+		x.xxx_hidden_Network = b.Network
+	}
+	if b.Endpoint != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 15)
+		x.xxx_hidden_Endpoint = b.Endpoint
+	}
+	if b.RequestId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 15)
+		x.xxx_hidden_RequestId = b.RequestId
+	}
+	if b.TraceId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 15)
+		x.xxx_hidden_TraceId = b.TraceId
+	}
+	if b.Header != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 15)
+		x.xxx_hidden_Header = b.Header
+	}
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 15)
+		x.xxx_hidden_Code = b.Code
+	}
+	if b.Region != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 15)
+		x.xxx_hidden_Region = b.Region
+	}
+	if b.KeyId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 15)
+		x.xxx_hidden_KeyId = b.KeyId
+	}
+	if b.Priority != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 15)
+		x.xxx_hidden_Priority = *b.Priority
+	}
+	if b.Channel != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 15)
+		x.xxx_hidden_Channel = *b.Channel
+	}
+	if b.FallbackChannel != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 15)
+		x.xxx_hidden_FallbackChannel = *b.FallbackChannel
+	}
+	return m0
+}
+
+// The books of a store.
+type ShelfInventory struct {
+	state                   protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_BooksByShelf map[int64]int32           `protobuf:"bytes,1,rep,name=books_by_shelf,json=booksByShelf" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_Available    map[string]bool           `protobuf:"bytes,2,rep,name=available" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_Notes        map[bool]string           `protobuf:"bytes,3,rep,name=notes" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Channels     map[uint32]ContactChannel `protobuf:"bytes,4,rep,name=channels" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=bookstore.v1.ContactChannel"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ShelfInventory) Reset() {
+	*x = ShelfInventory{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShelfInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShelfInventory) ProtoMessage() {}
+
+func (x *ShelfInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShelfInventory) GetBooksByShelf() map[int64]int32 {
+	if x != nil {
+		return x.xxx_hidden_BooksByShelf
+	}
+	return nil
+}
+
+func (x *ShelfInventory) GetAvailable() map[string]bool {
+	if x != nil {
+		return x.xxx_hidden_Available
+	}
+	return nil
+}
+
+func (x *ShelfInventory) GetNotes() map[bool]string {
+	if x != nil {
+		return x.xxx_hidden_Notes
+	}
+	return nil
+}
+
+func (x *ShelfInventory) GetChannels() map[uint32]ContactChannel {
+	if x != nil {
+		return x.xxx_hidden_Channels
+	}
+	return nil
+}
+
+func (x *ShelfInventory) SetBooksByShelf(v map[int64]int32) {
+	x.xxx_hidden_BooksByShelf = v
+}
+
+func (x *ShelfInventory) SetAvailable(v map[string]bool) {
+	x.xxx_hidden_Available = v
+}
+
+func (x *ShelfInventory) SetNotes(v map[bool]string) {
+	x.xxx_hidden_Notes = v
+}
+
+func (x *ShelfInventory) SetChannels(v map[uint32]ContactChannel) {
+	x.xxx_hidden_Channels = v
+}
+
+type ShelfInventory_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The number of books by shelf number.
+	BooksByShelf map[int64]int32
+	// Whether a book is available, by ISBN.
+	Available map[string]bool
+	Notes     map[bool]string
+	Channels  map[uint32]ContactChannel
+}
+
+func (b0 ShelfInventory_builder) Build() *ShelfInventory {
+	m0 := &ShelfInventory{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_BooksByShelf = b.BooksByShelf
+	x.xxx_hidden_Available = b.Available
+	x.xxx_hidden_Notes = b.Notes
+	x.xxx_hidden_Channels = b.Channels
+	return m0
+}
+
+// Repeated fields of every scalar kind, with rules on the array and its items.
+type RepeatedRules struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Strings   []string               `protobuf:"bytes,1,rep,name=strings"`
+	xxx_hidden_Blobs     [][]byte               `protobuf:"bytes,2,rep,name=blobs"`
+	xxx_hidden_Flags     []bool                 `protobuf:"varint,3,rep,packed,name=flags"`
+	xxx_hidden_Int32S    []int32                `protobuf:"varint,4,rep,packed,name=int32s"`
+	xxx_hidden_Sint32S   []int32                `protobuf:"zigzag32,5,rep,packed,name=sint32s"`
+	xxx_hidden_Sfixed32S []int32                `protobuf:"fixed32,6,rep,packed,name=sfixed32s"`
+	xxx_hidden_Int64S    []int64                `protobuf:"varint,7,rep,packed,name=int64s"`
+	xxx_hidden_Sint64S   []int64                `protobuf:"zigzag64,8,rep,packed,name=sint64s"`
+	xxx_hidden_Sfixed64S []int64                `protobuf:"fixed64,9,rep,packed,name=sfixed64s"`
+	xxx_hidden_Uint32S   []uint32               `protobuf:"varint,10,rep,packed,name=uint32s"`
+	xxx_hidden_Fixed32S  []uint32               `protobuf:"fixed32,11,rep,packed,name=fixed32s"`
+	xxx_hidden_Uint64S   []uint64               `protobuf:"varint,12,rep,packed,name=uint64s"`
+	xxx_hidden_Fixed64S  []uint64               `protobuf:"fixed64,13,rep,packed,name=fixed64s"`
+	xxx_hidden_Floats    []float32              `protobuf:"fixed32,14,rep,packed,name=floats"`
+	xxx_hidden_Doubles   []float64              `protobuf:"fixed64,15,rep,packed,name=doubles"`
+	xxx_hidden_Channels  []ContactChannel       `protobuf:"varint,16,rep,packed,name=channels,enum=bookstore.v1.ContactChannel"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RepeatedRules) Reset() {
+	*x = RepeatedRules{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepeatedRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedRules) ProtoMessage() {}
+
+func (x *RepeatedRules) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RepeatedRules) GetStrings() []string {
+	if x != nil {
+		return x.xxx_hidden_Strings
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetBlobs() [][]byte {
+	if x != nil {
+		return x.xxx_hidden_Blobs
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetFlags() []bool {
+	if x != nil {
+		return x.xxx_hidden_Flags
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetInt32S() []int32 {
+	if x != nil {
+		return x.xxx_hidden_Int32S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetSint32S() []int32 {
+	if x != nil {
+		return x.xxx_hidden_Sint32S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetSfixed32S() []int32 {
+	if x != nil {
+		return x.xxx_hidden_Sfixed32S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetInt64S() []int64 {
+	if x != nil {
+		return x.xxx_hidden_Int64S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetSint64S() []int64 {
+	if x != nil {
+		return x.xxx_hidden_Sint64S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetSfixed64S() []int64 {
+	if x != nil {
+		return x.xxx_hidden_Sfixed64S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetUint32S() []uint32 {
+	if x != nil {
+		return x.xxx_hidden_Uint32S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetFixed32S() []uint32 {
+	if x != nil {
+		return x.xxx_hidden_Fixed32S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetUint64S() []uint64 {
+	if x != nil {
+		return x.xxx_hidden_Uint64S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetFixed64S() []uint64 {
+	if x != nil {
+		return x.xxx_hidden_Fixed64S
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetFloats() []float32 {
+	if x != nil {
+		return x.xxx_hidden_Floats
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetDoubles() []float64 {
+	if x != nil {
+		return x.xxx_hidden_Doubles
+	}
+	return nil
+}
+
+func (x *RepeatedRules) GetChannels() []ContactChannel {
+	if x != nil {
+		return x.xxx_hidden_Channels
+	}
+	return nil
+}
+
+func (x *RepeatedRules) SetStrings(v []string) {
+	x.xxx_hidden_Strings = v
+}
+
+func (x *RepeatedRules) SetBlobs(v [][]byte) {
+	x.xxx_hidden_Blobs = v
+}
+
+func (x *RepeatedRules) SetFlags(v []bool) {
+	x.xxx_hidden_Flags = v
+}
+
+func (x *RepeatedRules) SetInt32S(v []int32) {
+	x.xxx_hidden_Int32S = v
+}
+
+func (x *RepeatedRules) SetSint32S(v []int32) {
+	x.xxx_hidden_Sint32S = v
+}
+
+func (x *RepeatedRules) SetSfixed32S(v []int32) {
+	x.xxx_hidden_Sfixed32S = v
+}
+
+func (x *RepeatedRules) SetInt64S(v []int64) {
+	x.xxx_hidden_Int64S = v
+}
+
+func (x *RepeatedRules) SetSint64S(v []int64) {
+	x.xxx_hidden_Sint64S = v
+}
+
+func (x *RepeatedRules) SetSfixed64S(v []int64) {
+	x.xxx_hidden_Sfixed64S = v
+}
+
+func (x *RepeatedRules) SetUint32S(v []uint32) {
+	x.xxx_hidden_Uint32S = v
+}
+
+func (x *RepeatedRules) SetFixed32S(v []uint32) {
+	x.xxx_hidden_Fixed32S = v
+}
+
+func (x *RepeatedRules) SetUint64S(v []uint64) {
+	x.xxx_hidden_Uint64S = v
+}
+
+func (x *RepeatedRules) SetFixed64S(v []uint64) {
+	x.xxx_hidden_Fixed64S = v
+}
+
+func (x *RepeatedRules) SetFloats(v []float32) {
+	x.xxx_hidden_Floats = v
+}
+
+func (x *RepeatedRules) SetDoubles(v []float64) {
+	x.xxx_hidden_Doubles = v
+}
+
+func (x *RepeatedRules) SetChannels(v []ContactChannel) {
+	x.xxx_hidden_Channels = v
+}
+
+type RepeatedRules_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Strings   []string
+	Blobs     [][]byte
+	Flags     []bool
+	Int32S    []int32
+	Sint32S   []int32
+	Sfixed32S []int32
+	Int64S    []int64
+	Sint64S   []int64
+	Sfixed64S []int64
+	Uint32S   []uint32
+	Fixed32S  []uint32
+	Uint64S   []uint64
+	Fixed64S  []uint64
+	Floats    []float32
+	Doubles   []float64
+	Channels  []ContactChannel
+}
+
+func (b0 RepeatedRules_builder) Build() *RepeatedRules {
+	m0 := &RepeatedRules{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Strings = b.Strings
+	x.xxx_hidden_Blobs = b.Blobs
+	x.xxx_hidden_Flags = b.Flags
+	x.xxx_hidden_Int32S = b.Int32S
+	x.xxx_hidden_Sint32S = b.Sint32S
+	x.xxx_hidden_Sfixed32S = b.Sfixed32S
+	x.xxx_hidden_Int64S = b.Int64S
+	x.xxx_hidden_Sint64S = b.Sint64S
+	x.xxx_hidden_Sfixed64S = b.Sfixed64S
+	x.xxx_hidden_Uint32S = b.Uint32S
+	x.xxx_hidden_Fixed32S = b.Fixed32S
+	x.xxx_hidden_Uint64S = b.Uint64S
+	x.xxx_hidden_Fixed64S = b.Fixed64S
+	x.xxx_hidden_Floats = b.Floats
+	x.xxx_hidden_Doubles = b.Doubles
+	x.xxx_hidden_Channels = b.Channels
+	return m0
+}
+
+// A book offered for sale.
+type BookListing struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id            *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_PriceCents    int64                  `protobuf:"varint,2,opt,name=price_cents,json=priceCents"`
+	xxx_hidden_ListedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=listed_at,json=listedAt"`
+	xxx_hidden_PrintedOn     *string                `protobuf:"bytes,4,opt,name=printed_on,json=printedOn"`
+	xxx_hidden_Tags          []string               `protobuf:"bytes,5,rep,name=tags"`
+	xxx_hidden_Stock         map[string]int32       `protobuf:"bytes,6,rep,name=stock" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_PayoutAccount *string                `protobuf:"bytes,7,opt,name=payout_account,json=payoutAccount"`
+	xxx_hidden_Isbn10        *string                `protobuf:"bytes,8,opt,name=isbn10"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BookListing) Reset() {
+	*x = BookListing{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookListing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookListing) ProtoMessage() {}
+
+func (x *BookListing) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BookListing) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BookListing) GetPriceCents() int64 {
+	if x != nil {
+		return x.xxx_hidden_PriceCents
+	}
+	return 0
+}
+
+func (x *BookListing) GetListedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ListedAt
+	}
+	return nil
+}
+
+func (x *BookListing) GetPrintedOn() string {
+	if x != nil {
+		if x.xxx_hidden_PrintedOn != nil {
+			return *x.xxx_hidden_PrintedOn
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BookListing) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *BookListing) GetStock() map[string]int32 {
+	if x != nil {
+		return x.xxx_hidden_Stock
+	}
+	return nil
+}
+
+func (x *BookListing) GetPayoutAccount() string {
+	if x != nil {
+		if x.xxx_hidden_PayoutAccount != nil {
+			return *x.xxx_hidden_PayoutAccount
+		}
+		return ""
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in bookstore/v1/bookstore.proto.
+func (x *BookListing) GetIsbn10() string {
+	if x != nil {
+		if x.xxx_hidden_Isbn10 != nil {
+			return *x.xxx_hidden_Isbn10
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BookListing) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *BookListing) SetPriceCents(v int64) {
+	x.xxx_hidden_PriceCents = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *BookListing) SetListedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ListedAt = v
+}
+
+func (x *BookListing) SetPrintedOn(v string) {
+	x.xxx_hidden_PrintedOn = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *BookListing) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *BookListing) SetStock(v map[string]int32) {
+	x.xxx_hidden_Stock = v
+}
+
+func (x *BookListing) SetPayoutAccount(v string) {
+	x.xxx_hidden_PayoutAccount = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+// Deprecated: Marked as deprecated in bookstore/v1/bookstore.proto.
+func (x *BookListing) SetIsbn10(v string) {
+	x.xxx_hidden_Isbn10 = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *BookListing) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BookListing) HasPriceCents() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BookListing) HasListedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ListedAt != nil
+}
+
+func (x *BookListing) HasPrintedOn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BookListing) HasPayoutAccount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+// Deprecated: Marked as deprecated in bookstore/v1/bookstore.proto.
+func (x *BookListing) HasIsbn10() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *BookListing) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *BookListing) ClearPriceCents() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_PriceCents = 0
+}
+
+func (x *BookListing) ClearListedAt() {
+	x.xxx_hidden_ListedAt = nil
+}
+
+func (x *BookListing) ClearPrintedOn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_PrintedOn = nil
+}
+
+func (x *BookListing) ClearPayoutAccount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_PayoutAccount = nil
+}
+
+// Deprecated: Marked as deprecated in bookstore/v1/bookstore.proto.
+func (x *BookListing) ClearIsbn10() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Isbn10 = nil
+}
+
+type BookListing_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The id of the listing.
+	Id *string
+	// The asking price, in cents.
+	PriceCents *int64
+	// When the book was listed.
+	ListedAt *timestamppb.Timestamp
+	// The day the book was printed.
+	PrintedOn *string
+	// Tags of the listing.
+	Tags []string
+	// Copies in stock, by store.
+	Stock map[string]int32
+	// The seller's payout account, never returned.
+	PayoutAccount *string
+	// The 10 digit ISBN, replaced by id.
+	//
+	// Deprecated: Marked as deprecated in bookstore/v1/bookstore.proto.
+	Isbn10 *string
+}
+
+func (b0 BookListing_builder) Build() *BookListing {
+	m0 := &BookListing{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.PriceCents != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_PriceCents = *b.PriceCents
+	}
+	x.xxx_hidden_ListedAt = b.ListedAt
+	if b.PrintedOn != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_PrintedOn = b.PrintedOn
+	}
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Stock = b.Stock
+	if b.PayoutAccount != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_PayoutAccount = b.PayoutAccount
+	}
+	if b.Isbn10 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Isbn10 = b.Isbn10
+	}
+	return m0
+}
+
+// Holds every well known type, as the arguments of a tool may.
+type KnownTypes struct {
+	state                  protoimpl.MessageState     `protogen:"opaque.v1"`
+	xxx_hidden_Timestamp   *timestamppb.Timestamp     `protobuf:"bytes,1,opt,name=timestamp"`
+	xxx_hidden_Duration    *durationpb.Duration       `protobuf:"bytes,2,opt,name=duration"`
+	xxx_hidden_FieldMask   *fieldmaskpb.FieldMask     `protobuf:"bytes,3,opt,name=field_mask,json=fieldMask"`
+	xxx_hidden_Any         *anypb.Any                 `protobuf:"bytes,4,opt,name=any"`
+	xxx_hidden_Empty       *emptypb.Empty             `protobuf:"bytes,5,opt,name=empty"`
+	xxx_hidden_Struct      *structpb.Struct           `protobuf:"bytes,6,opt,name=struct"`
+	xxx_hidden_Value       *structpb.Value            `protobuf:"bytes,7,opt,name=value"`
+	xxx_hidden_ListValue   *structpb.ListValue        `protobuf:"bytes,8,opt,name=list_value,json=listValue"`
+	xxx_hidden_NullValue   structpb.NullValue         `protobuf:"varint,9,opt,name=null_value,json=nullValue,enum=google.protobuf.NullValue"`
+	xxx_hidden_BoolValue   *wrapperspb.BoolValue      `protobuf:"bytes,10,opt,name=bool_value,json=boolValue"`
+	xxx_hidden_Int32Value  *wrapperspb.Int32Value     `protobuf:"bytes,11,opt,name=int32_value,json=int32Value"`
+	xxx_hidden_Int64Value  *wrapperspb.Int64Value     `protobuf:"bytes,12,opt,name=int64_value,json=int64Value"`
+	xxx_hidden_Uint32Value *wrapperspb.UInt32Value    `protobuf:"bytes,13,opt,name=uint32_value,json=uint32Value"`
+	xxx_hidden_Uint64Value *wrapperspb.UInt64Value    `protobuf:"bytes,14,opt,name=uint64_value,json=uint64Value"`
+	xxx_hidden_FloatValue  *wrapperspb.FloatValue     `protobuf:"bytes,15,opt,name=float_value,json=floatValue"`
+	xxx_hidden_DoubleValue *wrapperspb.DoubleValue    `protobuf:"bytes,16,opt,name=double_value,json=doubleValue"`
+	xxx_hidden_StringValue *wrapperspb.StringValue    `protobuf:"bytes,17,opt,name=string_value,json=stringValue"`
+	xxx_hidden_BytesValue  *wrapperspb.BytesValue     `protobuf:"bytes,18,opt,name=bytes_value,json=bytesValue"`
+	xxx_hidden_Values      *[]*structpb.Value         `protobuf:"bytes,19,rep,name=values"`
+	xxx_hidden_Labels      map[string]*structpb.Value `protobuf:"bytes,20,rep,name=labels" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Counts      *[]*wrapperspb.Int64Value  `protobuf:"bytes,21,rep,name=counts"`
+	xxx_hidden_Nulls       []structpb.NullValue       `protobuf:"varint,22,rep,packed,name=nulls,enum=google.protobuf.NullValue"`
+	xxx_hidden_Note        isKnownTypes_Note          `protobuf_oneof:"note"`
+	xxx_hidden_Ratio       float32                    `protobuf:"fixed32,25,opt,name=ratio"`
+	xxx_hidden_Offset      int64                      `protobuf:"zigzag64,26,opt,name=offset"`
+	xxx_hidden_Gender      Author_Gender              `protobuf:"varint,27,opt,name=gender,enum=bookstore.v1.Author_Gender"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *KnownTypes) Reset() {
+	*x = KnownTypes{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownTypes) ProtoMessage() {}
+
+func (x *KnownTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *KnownTypes) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Timestamp
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Duration
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetFieldMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.xxx_hidden_FieldMask
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetAny() *anypb.Any {
+	if x != nil {
+		return x.xxx_hidden_Any
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetEmpty() *emptypb.Empty {
+	if x != nil {
+		return x.xxx_hidden_Empty
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetStruct() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Struct
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetValue() *structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_Value
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetListValue() *structpb.ListValue {
+	if x != nil {
+		return x.xxx_hidden_ListValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetNullValue() structpb.NullValue {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 8) {
+			return x.xxx_hidden_NullValue
+		}
+	}
+	return structpb.NullValue(0)
+}
+
+func (x *KnownTypes) GetBoolValue() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.xxx_hidden_BoolValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetInt32Value() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.xxx_hidden_Int32Value
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetInt64Value() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.xxx_hidden_Int64Value
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetUint32Value() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.xxx_hidden_Uint32Value
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetUint64Value() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.xxx_hidden_Uint64Value
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetFloatValue() *wrapperspb.FloatValue {
+	if x != nil {
+		return x.xxx_hidden_FloatValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetDoubleValue() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.xxx_hidden_DoubleValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetStringValue() *wrapperspb.StringValue {
+	if x != nil {
+		return x.xxx_hidden_StringValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetBytesValue() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.xxx_hidden_BytesValue
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetValues() []*structpb.Value {
+	if x != nil {
+		if x.xxx_hidden_Values != nil {
+			return *x.xxx_hidden_Values
+		}
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetLabels() map[string]*structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetCounts() []*wrapperspb.Int64Value {
+	if x != nil {
+		if x.xxx_hidden_Counts != nil {
+			return *x.xxx_hidden_Counts
+		}
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetNulls() []structpb.NullValue {
+	if x != nil {
+		return x.xxx_hidden_Nulls
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetNoteValue() *structpb.Value {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Note.(*knownTypes_NoteValue); ok {
+			return x.NoteValue
+		}
+	}
+	return nil
+}
+
+func (x *KnownTypes) GetNoteText() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Note.(*knownTypes_NoteText); ok {
+			return x.NoteText
+		}
+	}
+	return ""
+}
+
+func (x *KnownTypes) GetRatio() float32 {
+	if x != nil {
+		return x.xxx_hidden_Ratio
+	}
+	return 0
+}
+
+func (x *KnownTypes) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *KnownTypes) GetGender() Author_Gender {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 25) {
+			return x.xxx_hidden_Gender
+		}
+	}
+	return Author_GENDER_UNSPECIFIED
+}
+
+func (x *KnownTypes) SetTimestamp(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Timestamp = v
+}
+
+func (x *KnownTypes) SetDuration(v *durationpb.Duration) {
+	x.xxx_hidden_Duration = v
+}
+
+func (x *KnownTypes) SetFieldMask(v *fieldmaskpb.FieldMask) {
+	x.xxx_hidden_FieldMask = v
+}
+
+func (x *KnownTypes) SetAny(v *anypb.Any) {
+	x.xxx_hidden_Any = v
+}
+
+func (x *KnownTypes) SetEmpty(v *emptypb.Empty) {
+	x.xxx_hidden_Empty = v
+}
+
+func (x *KnownTypes) SetStruct(v *structpb.Struct) {
+	x.xxx_hidden_Struct = v
+}
+
+func (x *KnownTypes) SetValue(v *structpb.Value) {
+	x.xxx_hidden_Value = v
+}
+
+func (x *KnownTypes) SetListValue(v *structpb.ListValue) {
+	x.xxx_hidden_ListValue = v
+}
+
+func (x *KnownTypes) SetNullValue(v structpb.NullValue) {
+	x.xxx_hidden_NullValue = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 26)
+}
+
+func (x *KnownTypes) SetBoolValue(v *wrapperspb.BoolValue) {
+	x.xxx_hidden_BoolValue = v
+}
+
+func (x *KnownTypes) SetInt32Value(v *wrapperspb.Int32Value) {
+	x.xxx_hidden_Int32Value = v
+}
+
+func (x *KnownTypes) SetInt64Value(v *wrapperspb.Int64Value) {
+	x.xxx_hidden_Int64Value = v
+}
+
+func (x *KnownTypes) SetUint32Value(v *wrapperspb.UInt32Value) {
+	x.xxx_hidden_Uint32Value = v
+}
+
+func (x *KnownTypes) SetUint64Value(v *wrapperspb.UInt64Value) {
+	x.xxx_hidden_Uint64Value = v
+}
+
+func (x *KnownTypes) SetFloatValue(v *wrapperspb.FloatValue) {
+	x.xxx_hidden_FloatValue = v
+}
+
+func (x *KnownTypes) SetDoubleValue(v *wrapperspb.DoubleValue) {
+	x.xxx_hidden_DoubleValue = v
+}
+
+func (x *KnownTypes) SetStringValue(v *wrapperspb.StringValue) {
+	x.xxx_hidden_StringValue = v
+}
+
+func (x *KnownTypes) SetBytesValue(v *wrapperspb.BytesValue) {
+	x.xxx_hidden_BytesValue = v
+}
+
+func (x *KnownTypes) SetValues(v []*structpb.Value) {
+	x.xxx_hidden_Values = &v
+}
+
+func (x *KnownTypes) SetLabels(v map[string]*structpb.Value) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *KnownTypes) SetCounts(v []*wrapperspb.Int64Value) {
+	x.xxx_hidden_Counts = &v
+}
+
+func (x *KnownTypes) SetNulls(v []structpb.NullValue) {
+	x.xxx_hidden_Nulls = v
+}
+
+func (x *KnownTypes) SetNoteValue(v *structpb.Value) {
+	if v == nil {
+		x.xxx_hidden_Note = nil
+		return
+	}
+	x.xxx_hidden_Note = &knownTypes_NoteValue{v}
+}
+
+func (x *KnownTypes) SetNoteText(v string) {
+	x.xxx_hidden_Note = &knownTypes_NoteText{v}
+}
+
+func (x *KnownTypes) SetRatio(v float32) {
+	x.xxx_hidden_Ratio = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 23, 26)
+}
+
+func (x *KnownTypes) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 24, 26)
+}
+
+func (x *KnownTypes) SetGender(v Author_Gender) {
+	x.xxx_hidden_Gender = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 25, 26)
+}
+
+func (x *KnownTypes) HasTimestamp() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timestamp != nil
+}
+
+func (x *KnownTypes) HasDuration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Duration != nil
+}
+
+func (x *KnownTypes) HasFieldMask() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_FieldMask != nil
+}
+
+func (x *KnownTypes) HasAny() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Any != nil
+}
+
+func (x *KnownTypes) HasEmpty() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Empty != nil
+}
+
+func (x *KnownTypes) HasStruct() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Struct != nil
+}
+
+func (x *KnownTypes) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Value != nil
+}
+
+func (x *KnownTypes) HasListValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ListValue != nil
+}
+
+func (x *KnownTypes) HasNullValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *KnownTypes) HasBoolValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_BoolValue != nil
+}
+
+func (x *KnownTypes) HasInt32Value() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Int32Value != nil
+}
+
+func (x *KnownTypes) HasInt64Value() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Int64Value != nil
+}
+
+func (x *KnownTypes) HasUint32Value() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Uint32Value != nil
+}
+
+func (x *KnownTypes) HasUint64Value() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Uint64Value != nil
+}
+
+func (x *KnownTypes) HasFloatValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_FloatValue != nil
+}
+
+func (x *KnownTypes) HasDoubleValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DoubleValue != nil
+}
+
+func (x *KnownTypes) HasStringValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_StringValue != nil
+}
+
+func (x *KnownTypes) HasBytesValue() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_BytesValue != nil
+}
+
+func (x *KnownTypes) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Note != nil
+}
+
+func (x *KnownTypes) HasNoteValue() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Note.(*knownTypes_NoteValue)
+	return ok
+}
+
+func (x *KnownTypes) HasNoteText() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Note.(*knownTypes_NoteText)
+	return ok
+}
+
+func (x *KnownTypes) HasRatio() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 23)
+}
+
+func (x *KnownTypes) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 24)
+}
+
+func (x *KnownTypes) HasGender() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 25)
+}
+
+func (x *KnownTypes) ClearTimestamp() {
+	x.xxx_hidden_Timestamp = nil
+}
+
+func (x *KnownTypes) ClearDuration() {
+	x.xxx_hidden_Duration = nil
+}
+
+func (x *KnownTypes) ClearFieldMask() {
+	x.xxx_hidden_FieldMask = nil
+}
+
+func (x *KnownTypes) ClearAny() {
+	x.xxx_hidden_Any = nil
+}
+
+func (x *KnownTypes) ClearEmpty() {
+	x.xxx_hidden_Empty = nil
+}
+
+func (x *KnownTypes) ClearStruct() {
+	x.xxx_hidden_Struct = nil
+}
+
+func (x *KnownTypes) ClearValue() {
+	x.xxx_hidden_Value = nil
+}
+
+func (x *KnownTypes) ClearListValue() {
+	x.xxx_hidden_ListValue = nil
+}
+
+func (x *KnownTypes) ClearNullValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_NullValue = structpb.NullValue_NULL_VALUE
+}
+
+func (x *KnownTypes) ClearBoolValue() {
+	x.xxx_hidden_BoolValue = nil
+}
+
+func (x *KnownTypes) ClearInt32Value() {
+	x.xxx_hidden_Int32Value = nil
+}
+
+func (x *KnownTypes) ClearInt64Value() {
+	x.xxx_hidden_Int64Value = nil
+}
+
+func (x *KnownTypes) ClearUint32Value() {
+	x.xxx_hidden_Uint32Value = nil
+}
+
+func (x *KnownTypes) ClearUint64Value() {
+	x.xxx_hidden_Uint64Value = nil
+}
+
+func (x *KnownTypes) ClearFloatValue() {
+	x.xxx_hidden_FloatValue = nil
+}
+
+func (x *KnownTypes) ClearDoubleValue() {
+	x.xxx_hidden_DoubleValue = nil
+}
+
+func (x *KnownTypes) ClearStringValue() {
+	x.xxx_hidden_StringValue = nil
+}
+
+func (x *KnownTypes) ClearBytesValue() {
+	x.xxx_hidden_BytesValue = nil
+}
+
+func (x *KnownTypes) ClearNote() {
+	x.xxx_hidden_Note = nil
+}
+
+func (x *KnownTypes) ClearNoteValue() {
+	if _, ok := x.xxx_hidden_Note.(*knownTypes_NoteValue); ok {
+		x.xxx_hidden_Note = nil
+	}
+}
+
+func (x *KnownTypes) ClearNoteText() {
+	if _, ok := x.xxx_hidden_Note.(*knownTypes_NoteText); ok {
+		x.xxx_hidden_Note = nil
+	}
+}
+
+func (x *KnownTypes) ClearRatio() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 23)
+	x.xxx_hidden_Ratio = 0
+}
+
+func (x *KnownTypes) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 24)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *KnownTypes) ClearGender() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 25)
+	x.xxx_hidden_Gender = Author_GENDER_UNSPECIFIED
+}
+
+const KnownTypes_Note_not_set_case case_KnownTypes_Note = 0
+const KnownTypes_NoteValue_case case_KnownTypes_Note = 23
+const KnownTypes_NoteText_case case_KnownTypes_Note = 24
+
+func (x *KnownTypes) WhichNote() case_KnownTypes_Note {
+	if x == nil {
+		return KnownTypes_Note_not_set_case
+	}
+	switch x.xxx_hidden_Note.(type) {
+	case *knownTypes_NoteValue:
+		return KnownTypes_NoteValue_case
+	case *knownTypes_NoteText:
+		return KnownTypes_NoteText_case
+	default:
+		return KnownTypes_Note_not_set_case
+	}
+}
+
+type KnownTypes_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Timestamp   *timestamppb.Timestamp
+	Duration    *durationpb.Duration
+	FieldMask   *fieldmaskpb.FieldMask
+	Any         *anypb.Any
+	Empty       *emptypb.Empty
+	Struct      *structpb.Struct
+	Value       *structpb.Value
+	ListValue   *structpb.ListValue
+	NullValue   *structpb.NullValue
+	BoolValue   *wrapperspb.BoolValue
+	Int32Value  *wrapperspb.Int32Value
+	Int64Value  *wrapperspb.Int64Value
+	Uint32Value *wrapperspb.UInt32Value
+	Uint64Value *wrapperspb.UInt64Value
+	FloatValue  *wrapperspb.FloatValue
+	DoubleValue *wrapperspb.DoubleValue
+	StringValue *wrapperspb.StringValue
+	BytesValue  *wrapperspb.BytesValue
+	Values      []*structpb.Value
+	Labels      map[string]*structpb.Value
+	Counts      []*wrapperspb.Int64Value
+	Nulls       []structpb.NullValue
+	// Fields of oneof xxx_hidden_Note:
+	NoteValue *structpb.Value
+	NoteText  *string
+	// -- end of xxx_hidden_Note
+	Ratio  *float32
+	Offset *int64
+	Gender *Author_Gender
+}
+
+func (b0 KnownTypes_builder) Build() *KnownTypes {
+	m0 := &KnownTypes{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Timestamp = b.Timestamp
+	x.xxx_hidden_Duration = b.Duration
+	x.xxx_hidden_FieldMask = b.FieldMask
+	x.xxx_hidden_Any = b.Any
+	x.xxx_hidden_Empty = b.Empty
+	x.xxx_hidden_Struct = b.Struct
+	x.xxx_hidden_Value = b.Value
+	x.xxx_hidden_ListValue = b.ListValue
+	if b.NullValue != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 26)
+		x.xxx_hidden_NullValue = *b.NullValue
+	}
+	x.xxx_hidden_BoolValue = b.BoolValue
+	x.xxx_hidden_Int32Value = b.Int32Value
+	x.xxx_hidden_Int64Value = b.Int64Value
+	x.xxx_hidden_Uint32Value = b.Uint32Value
+	x.xxx_hidden_Uint64Value = b.Uint64Value
+	x.xxx_hidden_FloatValue = b.FloatValue
+	x.xxx_hidden_DoubleValue = b.DoubleValue
+	x.xxx_hidden_StringValue = b.StringValue
+	x.xxx_hidden_BytesValue = b.BytesValue
+	x.xxx_hidden_Values = &b.Values
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_Counts = &b.Counts
+	x.xxx_hidden_Nulls = b.Nulls
+	if b.NoteValue != nil {
+		x.xxx_hidden_Note = &knownTypes_NoteValue{b.NoteValue}
+	}
+	if b.NoteText != nil {
+		x.xxx_hidden_Note = &knownTypes_NoteText{*b.NoteText}
+	}
+	if b.Ratio != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 23, 26)
+		x.xxx_hidden_Ratio = *b.Ratio
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 24, 26)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Gender != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 25, 26)
+		x.xxx_hidden_Gender = *b.Gender
+	}
+	return m0
+}
+
+type case_KnownTypes_Note protoreflect.FieldNumber
+
+func (x case_KnownTypes_Note) String() string {
+	md := file_bookstore_v1_bookstore_proto_msgTypes[38].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isKnownTypes_Note interface {
+	isKnownTypes_Note()
+}
+
+type knownTypes_NoteValue struct {
+	NoteValue *structpb.Value `protobuf:"bytes,23,opt,name=note_value,json=noteValue,oneof"`
+}
+
+type knownTypes_NoteText struct {
+	NoteText string `protobuf:"bytes,24,opt,name=note_text,json=noteText,oneof"`
+}
+
+func (*knownTypes_NoteValue) isKnownTypes_Note() {}
+
+func (*knownTypes_NoteText) isKnownTypes_Note() {}
+
+// A recursive comment for the recursive request
+type RecursiveBookRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_BookId      *string                `protobuf:"bytes,1,opt,name=book_id,json=bookId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RecursiveBookRequest) Reset() {
+	*x = RecursiveBookRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecursiveBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecursiveBookRequest) ProtoMessage() {}
+
+func (x *RecursiveBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RecursiveBookRequest) GetBookId() string {
+	if x != nil {
+		if x.xxx_hidden_BookId != nil {
+			return *x.xxx_hidden_BookId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RecursiveBookRequest) SetBookId(v string) {
+	x.xxx_hidden_BookId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RecursiveBookRequest) HasBookId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RecursiveBookRequest) ClearBookId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_BookId = nil
+}
+
+type RecursiveBookRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// A book ID!
+	BookId *string
+}
+
+func (b0 RecursiveBookRequest_builder) Build() *RecursiveBookRequest {
+	m0 := &RecursiveBookRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.BookId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_BookId = b.BookId
+	}
+	return m0
+}
+
+// A recursive comment for the recursive response
+type RecursiveBookResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Page        *RecursivePage         `protobuf:"bytes,1,opt,name=page"`
+	xxx_hidden_AnotherProp *string                `protobuf:"bytes,2,opt,name=another_prop,json=anotherProp"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RecursiveBookResponse) Reset() {
+	*x = RecursiveBookResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecursiveBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecursiveBookResponse) ProtoMessage() {}
+
+func (x *RecursiveBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RecursiveBookResponse) GetPage() *RecursivePage {
+	if x != nil {
+		return x.xxx_hidden_Page
+	}
+	return nil
+}
+
+func (x *RecursiveBookResponse) GetAnotherProp() string {
+	if x != nil {
+		if x.xxx_hidden_AnotherProp != nil {
+			return *x.xxx_hidden_AnotherProp
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RecursiveBookResponse) SetPage(v *RecursivePage) {
+	x.xxx_hidden_Page = v
+}
+
+func (x *RecursiveBookResponse) SetAnotherProp(v string) {
+	x.xxx_hidden_AnotherProp = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RecursiveBookResponse) HasPage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Page != nil
+}
+
+func (x *RecursiveBookResponse) HasAnotherProp() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RecursiveBookResponse) ClearPage() {
+	x.xxx_hidden_Page = nil
+}
+
+func (x *RecursiveBookResponse) ClearAnotherProp() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_AnotherProp = nil
+}
+
+type RecursiveBookResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// This is a recursive page
+	Page *RecursivePage
+	// This is a non recursive secondary prop
+	AnotherProp *string
+}
+
+func (b0 RecursiveBookResponse_builder) Build() *RecursiveBookResponse {
+	m0 := &RecursiveBookResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Page = b.Page
+	if b.AnotherProp != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_AnotherProp = b.AnotherProp
+	}
+	return m0
+}
+
+// A recursive page for the recursive response
+type RecursivePage struct {
+	state                  protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_Books       *RecursiveBookResponse    `protobuf:"bytes,1,opt,name=books"`
+	xxx_hidden_Pages       *[]*RecursiveBookResponse `protobuf:"bytes,2,rep,name=pages"`
+	xxx_hidden_ExtraPages  *[]*RecursivePage         `protobuf:"bytes,3,rep,name=extra_pages,json=extraPages"`
+	xxx_hidden_Prop        *string                   `protobuf:"bytes,4,opt,name=prop"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RecursivePage) Reset() {
+	*x = RecursivePage{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecursivePage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecursivePage) ProtoMessage() {}
+
+func (x *RecursivePage) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RecursivePage) GetBooks() *RecursiveBookResponse {
+	if x != nil {
+		return x.xxx_hidden_Books
+	}
+	return nil
+}
+
+func (x *RecursivePage) GetPages() []*RecursiveBookResponse {
+	if x != nil {
+		if x.xxx_hidden_Pages != nil {
+			return *x.xxx_hidden_Pages
+		}
+	}
+	return nil
+}
+
+func (x *RecursivePage) GetExtraPages() []*RecursivePage {
+	if x != nil {
+		if x.xxx_hidden_ExtraPages != nil {
+			return *x.xxx_hidden_ExtraPages
+		}
+	}
+	return nil
+}
+
+func (x *RecursivePage) GetProp() string {
+	if x != nil {
+		if x.xxx_hidden_Prop != nil {
+			return *x.xxx_hidden_Prop
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RecursivePage) SetBooks(v *RecursiveBookResponse) {
+	x.xxx_hidden_Books = v
+}
+
+func (x *RecursivePage) SetPages(v []*RecursiveBookResponse) {
+	x.xxx_hidden_Pages = &v
+}
+
+func (x *RecursivePage) SetExtraPages(v []*RecursivePage) {
+	x.xxx_hidden_ExtraPages = &v
+}
+
+func (x *RecursivePage) SetProp(v string) {
+	x.xxx_hidden_Prop = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *RecursivePage) HasBooks() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Books != nil
+}
+
+func (x *RecursivePage) HasProp() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RecursivePage) ClearBooks() {
+	x.xxx_hidden_Books = nil
+}
+
+func (x *RecursivePage) ClearProp() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Prop = nil
+}
+
+type RecursivePage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// This is a recursive book
+	Books *RecursiveBookResponse
+	// This is a list of recursive books
+	Pages []*RecursiveBookResponse
+	// This is a list of recursive pages
+	ExtraPages []*RecursivePage
+	// This is a non recursive prop
+	Prop *string
+}
+
+func (b0 RecursivePage_builder) Build() *RecursivePage {
+	m0 := &RecursivePage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Books = b.Books
+	x.xxx_hidden_Pages = &b.Pages
+	x.xxx_hidden_ExtraPages = &b.ExtraPages
+	if b.Prop != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Prop = b.Prop
+	}
+	return m0
+}
+
+type ListBooksResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Books *[]*Book               `protobuf:"bytes,1,rep,name=books"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		if x.xxx_hidden_Books != nil {
+			return *x.xxx_hidden_Books
+		}
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) SetBooks(v []*Book) {
+	x.xxx_hidden_Books = &v
+}
+
+type ListBooksResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Books []*Book
+}
+
+func (b0 ListBooksResponse_builder) Build() *ListBooksResponse {
+	m0 := &ListBooksResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Books = &b.Books
+	return m0
+}
+
+// Response message for ExportBooks method, sent once per book.
+type ExportBooksResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Book *Book                  `protobuf:"bytes,1,opt,name=book"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportBooksResponse) Reset() {
+	*x = ExportBooksResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBooksResponse) ProtoMessage() {}
+
+func (x *ExportBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportBooksResponse) GetBook() *Book {
+	if x != nil {
+		return x.xxx_hidden_Book
+	}
+	return nil
+}
+
+func (x *ExportBooksResponse) SetBook(v *Book) {
+	x.xxx_hidden_Book = v
+}
+
+func (x *ExportBooksResponse) HasBook() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Book != nil
+}
+
+func (x *ExportBooksResponse) ClearBook() {
+	x.xxx_hidden_Book = nil
+}
+
+type ExportBooksResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Book *Book
+}
+
+func (b0 ExportBooksResponse_builder) Build() *ExportBooksResponse {
+	m0 := &ExportBooksResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Book = b.Book
+	return m0
+}
+
+// Response message for ImportBooks method.
+type ImportBooksResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Count       int32                  `protobuf:"varint,1,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ImportBooksResponse) Reset() {
+	*x = ImportBooksResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBooksResponse) ProtoMessage() {}
+
+func (x *ImportBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ImportBooksResponse) GetCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *ImportBooksResponse) SetCount(v int32) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ImportBooksResponse) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ImportBooksResponse) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Count = 0
+}
+
+type ImportBooksResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The number of books imported.
+	Count *int32
+}
+
+func (b0 ImportBooksResponse_builder) Build() *ImportBooksResponse {
+	m0 := &ImportBooksResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *RecursiveBookRequest) HasBookId() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
-}
+func (*GetStatsRequest) ProtoMessage() {}
 
-func (x *RecursiveBookRequest) ClearBookId() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_BookId = nil
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type RecursiveBookRequest_builder struct {
+type GetStatsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// A book ID!
-	BookId *string
 }
 
-func (b0 RecursiveBookRequest_builder) Build() *RecursiveBookRequest {
-	m0 := &RecursiveBookRequest{}
+func (b0 GetStatsRequest_builder) Build() *GetStatsRequest {
+	m0 := &GetStatsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.BookId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_BookId = b.BookId
-	}
 	return m0
 }
 
-// A recursive comment for the recursive response
-type RecursiveBookResponse struct {
+type GetStatsResponse struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Page        *RecursivePage         `protobuf:"bytes,1,opt,name=page"`
-	xxx_hidden_AnotherProp *string                `protobuf:"bytes,2,opt,name=another_prop,json=anotherProp"`
+	xxx_hidden_Shelves     int64                  `protobuf:"varint,1,opt,name=shelves"`
+	xxx_hidden_Books       int64                  `protobuf:"varint,2,opt,name=books"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *RecursiveBookResponse) Reset() {
-	*x = RecursiveBookResponse{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[31]
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecursiveBookResponse) String() string {
+func (x *GetStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecursiveBookResponse) ProtoMessage() {}
+func (*GetStatsResponse) ProtoMessage() {}
 
-func (x *RecursiveBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[31]
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2987,104 +6199,99 @@ func (x *RecursiveBookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *RecursiveBookResponse) GetPage() *RecursivePage {
+func (x *GetStatsResponse) GetShelves() int64 {
 	if x != nil {
-		return x.xxx_hidden_Page
+		return x.xxx_hidden_Shelves
 	}
-	return nil
+	return 0
 }
 
-func (x *RecursiveBookResponse) GetAnotherProp() string {
+func (x *GetStatsResponse) GetBooks() int64 {
 	if x != nil {
-		if x.xxx_hidden_AnotherProp != nil {
-			return *x.xxx_hidden_AnotherProp
-		}
-		return ""
+		return x.xxx_hidden_Books
 	}
-	return ""
+	return 0
 }
 
-func (x *RecursiveBookResponse) SetPage(v *RecursivePage) {
-	x.xxx_hidden_Page = v
+func (x *GetStatsResponse) SetShelves(v int64) {
+	x.xxx_hidden_Shelves = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *RecursiveBookResponse) SetAnotherProp(v string) {
-	x.xxx_hidden_AnotherProp = &v
+func (x *GetStatsResponse) SetBooks(v int64) {
+	x.xxx_hidden_Books = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *RecursiveBookResponse) HasPage() bool {
+func (x *GetStatsResponse) HasShelves() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Page != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *RecursiveBookResponse) HasAnotherProp() bool {
+func (x *GetStatsResponse) HasBooks() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *RecursiveBookResponse) ClearPage() {
-	x.xxx_hidden_Page = nil
+func (x *GetStatsResponse) ClearShelves() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Shelves = 0
 }
 
-func (x *RecursiveBookResponse) ClearAnotherProp() {
+func (x *GetStatsResponse) ClearBooks() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_AnotherProp = nil
+	x.xxx_hidden_Books = 0
 }
 
-type RecursiveBookResponse_builder struct {
+type GetStatsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// This is a recursive page
-	Page *RecursivePage
-	// This is a non recursive secondary prop
-	AnotherProp *string
+	// The number of shelves.
+	Shelves *int64
+	// The number of books.
+	Books *int64
 }
 
-func (b0 RecursiveBookResponse_builder) Build() *RecursiveBookResponse {
-	m0 := &RecursiveBookResponse{}
+func (b0 GetStatsResponse_builder) Build() *GetStatsResponse {
+	m0 := &GetStatsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Page = b.Page
-	if b.AnotherProp != nil {
+	if b.Shelves != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Shelves = *b.Shelves
+	}
+	if b.Books != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_AnotherProp = b.AnotherProp
+		x.xxx_hidden_Books = *b.Books
 	}
 	return m0
 }
 
-// A recursive page for the recursive response
-type RecursivePage struct {
-	state                  protoimpl.MessageState    `protogen:"opaque.v1"`
-	xxx_hidden_Books       *RecursiveBookResponse    `protobuf:"bytes,1,opt,name=books"`
-	xxx_hidden_Pages       *[]*RecursiveBookResponse `protobuf:"bytes,2,rep,name=pages"`
-	xxx_hidden_ExtraPages  *[]*RecursivePage         `protobuf:"bytes,3,rep,name=extra_pages,json=extraPages"`
-	xxx_hidden_Prop        *string                   `protobuf:"bytes,4,opt,name=prop"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+type PurgeCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecursivePage) Reset() {
-	*x = RecursivePage{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[32]
+func (x *PurgeCacheRequest) Reset() {
+	*x = PurgeCacheRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecursivePage) String() string {
+func (x *PurgeCacheRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecursivePage) ProtoMessage() {}
+func (*PurgeCacheRequest) ProtoMessage() {}
 
-func (x *RecursivePage) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[32]
+func (x *PurgeCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3095,130 +6302,125 @@ func (x *RecursivePage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *RecursivePage) GetBooks() *RecursiveBookResponse {
-	if x != nil {
-		return x.xxx_hidden_Books
-	}
-	return nil
+type PurgeCacheRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
 }
 
-func (x *RecursivePage) GetPages() []*RecursiveBookResponse {
-	if x != nil {
-		if x.xxx_hidden_Pages != nil {
-			return *x.xxx_hidden_Pages
-		}
-	}
-	return nil
+func (b0 PurgeCacheRequest_builder) Build() *PurgeCacheRequest {
+	m0 := &PurgeCacheRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
 }
 
-func (x *RecursivePage) GetExtraPages() []*RecursivePage {
-	if x != nil {
-		if x.xxx_hidden_ExtraPages != nil {
-			return *x.xxx_hidden_ExtraPages
-		}
-	}
-	return nil
+type PurgeCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecursivePage) GetProp() string {
+func (x *PurgeCacheResponse) Reset() {
+	*x = PurgeCacheResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeCacheResponse) ProtoMessage() {}
+
+func (x *PurgeCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[48]
 	if x != nil {
-		if x.xxx_hidden_Prop != nil {
-			return *x.xxx_hidden_Prop
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RecursivePage) SetBooks(v *RecursiveBookResponse) {
-	x.xxx_hidden_Books = v
-}
+type PurgeCacheResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-func (x *RecursivePage) SetPages(v []*RecursiveBookResponse) {
-	x.xxx_hidden_Pages = &v
 }
 
-func (x *RecursivePage) SetExtraPages(v []*RecursivePage) {
-	x.xxx_hidden_ExtraPages = &v
+func (b0 PurgeCacheResponse_builder) Build() *PurgeCacheResponse {
+	m0 := &PurgeCacheResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
 }
 
-func (x *RecursivePage) SetProp(v string) {
-	x.xxx_hidden_Prop = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+type RebuildIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecursivePage) HasBooks() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Books != nil
+func (x *RebuildIndexRequest) Reset() {
+	*x = RebuildIndexRequest{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecursivePage) HasProp() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+func (x *RebuildIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *RecursivePage) ClearBooks() {
-	x.xxx_hidden_Books = nil
-}
+func (*RebuildIndexRequest) ProtoMessage() {}
 
-func (x *RecursivePage) ClearProp() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Prop = nil
+func (x *RebuildIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type RecursivePage_builder struct {
+type RebuildIndexRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// This is a recursive book
-	Books *RecursiveBookResponse
-	// This is a list of recursive books
-	Pages []*RecursiveBookResponse
-	// This is a list of recursive pages
-	ExtraPages []*RecursivePage
-	// This is a non recursive prop
-	Prop *string
 }
 
-func (b0 RecursivePage_builder) Build() *RecursivePage {
-	m0 := &RecursivePage{}
+func (b0 RebuildIndexRequest_builder) Build() *RebuildIndexRequest {
+	m0 := &RebuildIndexRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Books = b.Books
-	x.xxx_hidden_Pages = &b.Pages
-	x.xxx_hidden_ExtraPages = &b.ExtraPages
-	if b.Prop != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
-		x.xxx_hidden_Prop = b.Prop
-	}
 	return m0
 }
 
-type ListBooksResponse struct {
-	state            protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Books *[]*Book               `protobuf:"bytes,1,rep,name=books"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type RebuildIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBooksResponse) Reset() {
-	*x = ListBooksResponse{}
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[33]
+func (x *RebuildIndexResponse) Reset() {
+	*x = RebuildIndexResponse{}
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBooksResponse) String() string {
+func (x *RebuildIndexResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBooksResponse) ProtoMessage() {}
+func (*RebuildIndexResponse) ProtoMessage() {}
 
-func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bookstore_v1_bookstore_proto_msgTypes[33]
+func (x *RebuildIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bookstore_v1_bookstore_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3229,30 +6431,15 @@ func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ListBooksResponse) GetBooks() []*Book {
-	if x != nil {
-		if x.xxx_hidden_Books != nil {
-			return *x.xxx_hidden_Books
-		}
-	}
-	return nil
-}
-
-func (x *ListBooksResponse) SetBooks(v []*Book) {
-	x.xxx_hidden_Books = &v
-}
-
-type ListBooksResponse_builder struct {
+type RebuildIndexResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Books []*Book
 }
 
-func (b0 ListBooksResponse_builder) Build() *ListBooksResponse {
-	m0 := &ListBooksResponse{}
+func (b0 RebuildIndexResponse_builder) Build() *RebuildIndexResponse {
+	m0 := &RebuildIndexResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Books = &b.Books
 	return m0
 }
 
@@ -3260,7 +6447,7 @@ var File_bookstore_v1_bookstore_proto protoreflect.FileDescriptor
 
 const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\n" +
-	"\x1cbookstore/v1/bookstore.proto\x12\fbookstore.v1\x1a\x1bbuf/validate/validate.proto\x1a\x19google/protobuf/any.proto\x1a google/protobuf/field_mask.proto\x1a!google/protobuf/go_features.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14mcpgw/v1/mcpgw.proto\"N\n" +
+	"\x1cbookstore/v1/bookstore.proto\x12\fbookstore.v1\x1a\x1bbuf/validate/validate.proto\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a!google/protobuf/go_features.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x14mcpgw/v1/mcpgw.proto\"N\n" +
 	"\x12CreateGenreRequest\x128\n" +
 	"\x04name\x18\x01 \x01(\tB$\xbaH\x06r\x04\x10\x01\x182\xe2\x9c\x04\x17\n" +
 	"\x15The name of the genreR\x04name\"@\n" +
@@ -3293,7 +6480,10 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\n" +
 	"nonfiction\x18\x03 \x01(\bH\x00R\n" +
 	"nonfictionB\a\n" +
-	"\x05genre\"\x83\x01\n" +
+	"\x05genre\"d\n" +
+	"\x0eFeaturedAuthor\x12\x19\n" +
+	"\bshelf_id\x18\x01 \x01(\tR\ashelfId\x127\n" +
+	"\x06author\x18\x02 \x01(\v2\x1f.bookstore.v1.GetAuthorResponseR\x06author\"\x83\x01\n" +
 	"\x05Shelf\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05theme\x18\x02 \x01(\tR\x05theme\x12'\n" +
@@ -3301,13 +6491,15 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\x0esearch_encoded\x18\x04 \x01(\tR\x13search%5Bencoded%5D\"+\n" +
 	"\x05Genre\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"w\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x9e\x01\n" +
 	"\x04Book\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06author\x18\x02 \x01(\tR\x06author\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
 	"\x06quotes\x18\x04 \x03(\tR\x06quotes\x12\x19\n" +
-	"\bshelf_id\x18\x05 \x01(\tR\ashelfId\"\xcf\x02\n" +
+	"\bshelf_id\x18\x05 \x01(\tR\ashelfId\x12%\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tB\x06\xe2\x9c\x04\x02@\x01R\tcreatedBy\"\xcf\x02\n" +
 	"\x06Author\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x123\n" +
 	"\x06gender\x18\x02 \x01(\x0e2\x1b.bookstore.v1.Author.GenderR\x06gender\x12\x1d\n" +
@@ -3332,10 +6524,16 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\x12DeleteShelfRequest\x12\x14\n" +
 	"\x05shelf\x18\x01 \x01(\tR\x05shelf\"(\n" +
 	"\x10ListBooksRequest\x12\x14\n" +
-	"\x05shelf\x18\x01 \x01(\tR\x05shelf\"Q\n" +
+	"\x05shelf\x18\x01 \x01(\tR\x05shelf\"*\n" +
+	"\x12ExportBooksRequest\x12\x14\n" +
+	"\x05shelf\x18\x01 \x01(\tR\x05shelf\"R\n" +
+	"\x12ImportBooksRequest\x12\x14\n" +
+	"\x05shelf\x18\x01 \x01(\tR\x05shelf\x12&\n" +
+	"\x04book\x18\x02 \x01(\v2\x12.bookstore.v1.BookR\x04book\"v\n" +
 	"\x11CreateBookRequest\x12\x14\n" +
 	"\x05shelf\x18\x01 \x01(\tR\x05shelf\x12&\n" +
-	"\x04book\x18\x02 \x01(\v2\x12.bookstore.v1.BookR\x04book\"\x9d\x01\n" +
+	"\x04book\x18\x02 \x01(\v2\x12.bookstore.v1.BookR\x04book\x12#\n" +
+	"\ttenant_id\x18\x03 \x01(\tB\x06\xe2\x9c\x04\x02@\x01R\btenantId\"\x9d\x01\n" +
 	"\x0eGetBookRequest\x12\x14\n" +
 	"\x05shelf\x18\x01 \x01(\tR\x05shelf\x12\x12\n" +
 	"\x04book\x18\x02 \x01(\x03R\x04book\x12%\n" +
@@ -3349,7 +6547,150 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\x11DeleteBookRequest\x12&\n" +
 	"\x04book\x18\x01 \x01(\v2\x12.bookstore.v1.BookR\x04book\"*\n" +
 	"\x10GetAuthorRequest\x12\x16\n" +
-	"\x06author\x18\x01 \x01(\x03R\x06author\"/\n" +
+	"\x06author\x18\x01 \x01(\x03R\x06author\"\xba\x06\n" +
+	"\x12SearchBooksRequest\x12T\n" +
+	"\x05query\x18\x01 \x01(\tB>\xbaH;\xba\x018\n" +
+	"\x0fquery_not_empty\x1a%this.size() > 0 && this.size() <= 100R\x05query\x12D\n" +
+	"\x04isbn\x18\x02 \x01(\tB0\xbaH-\xba\x01*\n" +
+	"\visbn_digits\x1a\x1bthis.matches('^[0-9]{13}$')R\x04isbn\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12E\n" +
+	"\n" +
+	"first_page\x18\x05 \x01(\x05B&\xbaH#\xba\x01 \n" +
+	"\x13first_page_positive\x1a\tthis >= 1R\tfirstPage\x12\x1b\n" +
+	"\tlast_page\x18\x06 \x01(\x05R\blastPage\x12U\n" +
+	"\x03tag\x18\a \x01(\tBC\xbaH@\xba\x01=\n" +
+	"\ttag_lower\x12\x15tag must be lowercase\x1a\x19this == this.lowerAscii()R\x03tag\x12D\n" +
+	"\ashelves\x18\b \x03(\tB*\xbaH'\xba\x01$\n" +
+	"\x11shelves_not_empty\x1a\x0fsize(this) != 0R\ashelves\x12C\n" +
+	"\x0fpublished_after\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0epublishedAfter\x12\\\n" +
+	"\x0fmax_price_cents\x18\n" +
+	" \x01(\x03B4\xbaH1\xba\x01.\n" +
+	"\x0fmax_price_range\x1a\x1bthis >= 0 && this < 1000000R\rmaxPriceCents:\xb5\x01\xbaH\xb1\x01\x1a/\n" +
+	"\n" +
+	"page_range\x1a!this.first_page <= this.last_page\x1ak\n" +
+	"\x06recent\x12/published_after must be within the last century\x1a0this.published_after > now - duration('876000h')\"\x11\n" +
+	"\x05title\n" +
+	"\x06author\x10\x01\"\xb6\x05\n" +
+	"\x10PublisherContact\x12\x1d\n" +
+	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\x12\"\n" +
+	"\awebsite\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x90\x01\x01R\awebsite\x12\x1c\n" +
+	"\x04host\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xa8\x01\x01R\x04host\x12\x17\n" +
+	"\x02ip\x18\x04 \x01(\tB\a\xbaH\x04r\x02p\x01R\x02ip\x12\"\n" +
+	"\anetwork\x18\x05 \x01(\tB\b\xbaH\x05r\x03\xf0\x01\x01R\anetwork\x12$\n" +
+	"\bendpoint\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x80\x02\x01R\bendpoint\x12'\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\trequestId\x12#\n" +
+	"\btrace_id\x18\b \x01(\tB\b\xbaH\x05r\x03\x88\x02\x01R\atraceId\x12 \n" +
+	"\x06header\x18\t \x01(\tB\b\xbaH\x05r\x03\xc0\x01\x01R\x06header\x12R\n" +
+	"\x04code\x18\n" +
+	" \x01(\tB>\xbaH;r92\x19^[A-Z]{2}-[0-9]{4}-[A-Z]$:\x03BK-B\x02-XZ\tBK-0000-X\x98\x01\t\xba\x01\x049999R\x04code\x12(\n" +
+	"\x06region\x18\v \x01(\tB\x10\xbaH\rr\v(\x10Z\aunknownR\x06region\x120\n" +
+	"\x06key_id\x18\f \x01(\fB\x19\xbaH\x16z\x14J\x10AAAAAAAAAAAAAAAAh\x10R\x05keyId\x12'\n" +
+	"\bpriority\x18\r \x01(\x05B\v\xbaH\b\x1a\x068\x03\x18\x05(\x01R\bpriority\x12@\n" +
+	"\achannel\x18\x0e \x01(\x0e2\x1c.bookstore.v1.ContactChannelB\b\xbaH\x05\x82\x01\x02\x10\x01R\achannel\x12S\n" +
+	"\x10fallback_channel\x18\x0f \x01(\x0e2\x1c.bookstore.v1.ContactChannelB\n" +
+	"\xbaH\a\x82\x01\x04 \x00 \x03R\x0ffallbackChannel\"\x89\x05\n" +
+	"\x0eShelfInventory\x12i\n" +
+	"\x0ebooks_by_shelf\x18\x01 \x03(\v2..bookstore.v1.ShelfInventory.BooksByShelfEntryB\x13\xbaH\x10\x9a\x01\r\"\x04\"\x02(\x01*\x05\x1a\x03\x18\xe8\aR\fbooksByShelf\x12b\n" +
+	"\tavailable\x18\x02 \x03(\v2+.bookstore.v1.ShelfInventory.AvailableEntryB\x17\xbaH\x14\x9a\x01\x11\"\x0fr\r2\v^[0-9]{13}$R\tavailable\x12=\n" +
+	"\x05notes\x18\x03 \x03(\v2'.bookstore.v1.ShelfInventory.NotesEntryR\x05notes\x12U\n" +
+	"\bchannels\x18\x04 \x03(\v2*.bookstore.v1.ShelfInventory.ChannelsEntryB\r\xbaH\n" +
+	"\x9a\x01\a*\x05\x82\x01\x02 \x00R\bchannels\x1a?\n" +
+	"\x11BooksByShelfEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a<\n" +
+	"\x0eAvailableEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"NotesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\bR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aY\n" +
+	"\rChannelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x1c.bookstore.v1.ContactChannelR\x05value:\x028\x01\"\xd0\x06\n" +
+	"\rRepeatedRules\x123\n" +
+	"\astrings\x18\x01 \x03(\tB\x19\xbaH\x16\x92\x01\x13\b\x01\x10\x05\x18\x01\"\vr\t\x10\x01\x18\x14:\x03bk-R\astrings\x12(\n" +
+	"\x05blobs\x18\x02 \x03(\fB\x12\xbaH\x0f\x92\x01\f\b\x01\x10\x05\x18\x01\"\x04z\x02\x18@R\x05blobs\x12&\n" +
+	"\x05flags\x18\x03 \x03(\bB\x10\xbaH\r\x92\x01\n" +
+	"\b\x01\x10\x05\"\x04j\x02\b\x01R\x05flags\x12,\n" +
+	"\x06int32s\x18\x04 \x03(\x05B\x14\xbaH\x11\x92\x01\x0e\b\x01\x10\x05\x18\x01\"\x06\x1a\x04\x18\n" +
+	"(\x01R\x06int32s\x12.\n" +
+	"\asint32s\x18\x05 \x03(\x11B\x14\xbaH\x11\x92\x01\x0e\b\x01\x10\x05\x18\x01\"\x06:\x04\x10\n" +
+	" \tR\asint32s\x12=\n" +
+	"\tsfixed32s\x18\x06 \x03(\x0fB\x1f\xbaH\x1c\x92\x01\x19\b\x01\x10\x05\x18\x01\"\x11Z\x0f5\x01\x00\x00\x005\x02\x00\x00\x005\x03\x00\x00\x00R\tsfixed32s\x12*\n" +
+	"\x06int64s\x18\a \x03(\x03B\x12\xbaH\x0f\x92\x01\f\b\x01\x10\x05\x18\x01\"\x04\"\x02(\x01R\x06int64s\x12-\n" +
+	"\asint64s\x18\b \x03(\x12B\x13\xbaH\x10\x92\x01\r\b\x01\x10\x05\x18\x01\"\x05B\x03\x10\xc8\x01R\asint64s\x127\n" +
+	"\tsfixed64s\x18\t \x03(\x10B\x19\xbaH\x16\x92\x01\x13\b\x01\x10\x05\x18\x01\"\vb\t9\x00\x00\x00\x00\x00\x00\x00\x00R\tsfixed64s\x12,\n" +
+	"\auint32s\x18\n" +
+	" \x03(\rB\x12\xbaH\x0f\x92\x01\f\b\x01\x10\x05\x18\x01\"\x04*\x02\x18cR\auint32s\x121\n" +
+	"\bfixed32s\x18\v \x03(\aB\x15\xbaH\x12\x92\x01\x0f\b\x01\x10\x05\x18\x01\"\aJ\x05%\x01\x00\x00\x00R\bfixed32s\x12,\n" +
+	"\auint64s\x18\f \x03(\x04B\x12\xbaH\x0f\x92\x01\f\b\x01\x10\x05\x18\x01\"\x042\x02(\x05R\auint64s\x125\n" +
+	"\bfixed64s\x18\r \x03(\x06B\x19\xbaH\x16\x92\x01\x13\b\x01\x10\x05\x18\x01\"\vR\t\t\a\x00\x00\x00\x00\x00\x00\x00R\bfixed64s\x122\n" +
+	"\x06floats\x18\x0e \x03(\x02B\x1a\xbaH\x17\x92\x01\x14\b\x01\x10\x05\x18\x01\"\f\n" +
+	"\n" +
+	"\x1d\x00\x00\xc0?-\x00\x00\x00?R\x06floats\x12<\n" +
+	"\adoubles\x18\x0f \x03(\x01B\"\xbaH\x1f\x92\x01\x1c\b\x01\x10\x05\x18\x01\"\x14\x12\x12\x11\x00\x00\x00\x00\x00\x00\x00@!\x00\x00\x00\x00\x00\x00\xf0?R\adoubles\x12O\n" +
+	"\bchannels\x18\x10 \x03(\x0e2\x1c.bookstore.v1.ContactChannelB\x15\xbaH\x12\x92\x01\x0f\b\x01\x10\x05\x18\x01\"\a\x82\x01\x04 \x00 \x03R\bchannels\"\x8c\x04\n" +
+	"\vBookListing\x12\x1f\n" +
+	"\x02id\x18\x01 \x01(\tB\x0f\xe2\x9c\x04\v\x12\a\"lst-1\"0\x01R\x02id\x12A\n" +
+	"\vprice_cents\x18\x02 \x01(\x03B \xe2\x9c\x04\x1c\x12\x06\"1999\"\x12\x06\"2500\"\x1a\x03\"0\"*\x05PriceR\n" +
+	"priceCents\x12U\n" +
+	"\tlisted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x1c\xe2\x9c\x04\x18\x12\x16\"2024-05-01T12:00:00Z\"R\blistedAt\x127\n" +
+	"\n" +
+	"printed_on\x18\x04 \x01(\tB\x18\xe2\x9c\x04\x14\x12\f\"1965-08-01\"\"\x04dateR\tprintedOn\x125\n" +
+	"\x04tags\x18\x05 \x03(\tB!\xe2\x9c\x04\x1d\x12\x1b[\"signed\", \"first-edition\"]R\x04tags\x12M\n" +
+	"\x05stock\x18\x06 \x03(\v2$.bookstore.v1.BookListing.StockEntryB\x11\xe2\x9c\x04\r\x12\v{\"main\": 3}R\x05stock\x12-\n" +
+	"\x0epayout_account\x18\a \x01(\tB\x06\xe2\x9c\x04\x028\x01R\rpayoutAccount\x12\x1a\n" +
+	"\x06isbn10\x18\b \x01(\tB\x02\x18\x01R\x06isbn10\x1a8\n" +
+	"\n" +
+	"StockEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x85\f\n" +
+	"\n" +
+	"KnownTypes\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x129\n" +
+	"\n" +
+	"field_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\tfieldMask\x12&\n" +
+	"\x03any\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x03any\x12,\n" +
+	"\x05empty\x18\x05 \x01(\v2\x16.google.protobuf.EmptyR\x05empty\x12/\n" +
+	"\x06struct\x18\x06 \x01(\v2\x17.google.protobuf.StructR\x06struct\x12,\n" +
+	"\x05value\x18\a \x01(\v2\x16.google.protobuf.ValueR\x05value\x129\n" +
+	"\n" +
+	"list_value\x18\b \x01(\v2\x1a.google.protobuf.ListValueR\tlistValue\x129\n" +
+	"\n" +
+	"null_value\x18\t \x01(\x0e2\x1a.google.protobuf.NullValueR\tnullValue\x129\n" +
+	"\n" +
+	"bool_value\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.BoolValueR\tboolValue\x12<\n" +
+	"\vint32_value\x18\v \x01(\v2\x1b.google.protobuf.Int32ValueR\n" +
+	"int32Value\x12<\n" +
+	"\vint64_value\x18\f \x01(\v2\x1b.google.protobuf.Int64ValueR\n" +
+	"int64Value\x12?\n" +
+	"\fuint32_value\x18\r \x01(\v2\x1c.google.protobuf.UInt32ValueR\vuint32Value\x12?\n" +
+	"\fuint64_value\x18\x0e \x01(\v2\x1c.google.protobuf.UInt64ValueR\vuint64Value\x12<\n" +
+	"\vfloat_value\x18\x0f \x01(\v2\x1b.google.protobuf.FloatValueR\n" +
+	"floatValue\x12?\n" +
+	"\fdouble_value\x18\x10 \x01(\v2\x1c.google.protobuf.DoubleValueR\vdoubleValue\x12?\n" +
+	"\fstring_value\x18\x11 \x01(\v2\x1c.google.protobuf.StringValueR\vstringValue\x12<\n" +
+	"\vbytes_value\x18\x12 \x01(\v2\x1b.google.protobuf.BytesValueR\n" +
+	"bytesValue\x12.\n" +
+	"\x06values\x18\x13 \x03(\v2\x16.google.protobuf.ValueR\x06values\x12<\n" +
+	"\x06labels\x18\x14 \x03(\v2$.bookstore.v1.KnownTypes.LabelsEntryR\x06labels\x123\n" +
+	"\x06counts\x18\x15 \x03(\v2\x1b.google.protobuf.Int64ValueR\x06counts\x120\n" +
+	"\x05nulls\x18\x16 \x03(\x0e2\x1a.google.protobuf.NullValueR\x05nulls\x127\n" +
+	"\n" +
+	"note_value\x18\x17 \x01(\v2\x16.google.protobuf.ValueH\x00R\tnoteValue\x12\x1d\n" +
+	"\tnote_text\x18\x18 \x01(\tH\x00R\bnoteText\x12\x14\n" +
+	"\x05ratio\x18\x19 \x01(\x02R\x05ratio\x12\x16\n" +
+	"\x06offset\x18\x1a \x01(\x12R\x06offset\x123\n" +
+	"\x06gender\x18\x1b \x01(\x0e2\x1b.bookstore.v1.Author.GenderR\x06gender\x1aQ\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01B\x06\n" +
+	"\x04note\"/\n" +
 	"\x14RecursiveBookRequest\x12\x17\n" +
 	"\abook_id\x18\x01 \x01(\tR\x06bookId\"k\n" +
 	"\x15RecursiveBookResponse\x12/\n" +
@@ -3362,7 +6703,25 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"extraPages\x12\x12\n" +
 	"\x04prop\x18\x04 \x01(\tR\x04prop\"=\n" +
 	"\x11ListBooksResponse\x12(\n" +
-	"\x05books\x18\x01 \x03(\v2\x12.bookstore.v1.BookR\x05books2\x85\r\n" +
+	"\x05books\x18\x01 \x03(\v2\x12.bookstore.v1.BookR\x05books\"=\n" +
+	"\x13ExportBooksResponse\x12&\n" +
+	"\x04book\x18\x01 \x01(\v2\x12.bookstore.v1.BookR\x04book\"+\n" +
+	"\x13ImportBooksResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"\x11\n" +
+	"\x0fGetStatsRequest\"B\n" +
+	"\x10GetStatsResponse\x12\x18\n" +
+	"\ashelves\x18\x01 \x01(\x03R\ashelves\x12\x14\n" +
+	"\x05books\x18\x02 \x01(\x03R\x05books\"\x13\n" +
+	"\x11PurgeCacheRequest\"\x14\n" +
+	"\x12PurgeCacheResponse\"\x15\n" +
+	"\x13RebuildIndexRequest\"\x16\n" +
+	"\x14RebuildIndexResponse*\xa4\x01\n" +
+	"\x0eContactChannel\x12\x1f\n" +
+	"\x1bCONTACT_CHANNEL_UNSPECIFIED\x10\x00\x12<\n" +
+	"\x15CONTACT_CHANNEL_EMAIL\x10\x01\x1a!\xf2\x9c\x04\x1d\n" +
+	"\x1bWrite to the email address.\x12\x19\n" +
+	"\x15CONTACT_CHANNEL_PHONE\x10\x02\x12\x18\n" +
+	"\x14CONTACT_CHANNEL_POST\x10\x032\xb6\x0f\n" +
 	"\x10BookstoreService\x12\x8d\x01\n" +
 	"\vListShelves\x12 .bookstore.v1.ListShelvesRequest\x1a!.bookstore.v1.ListShelvesResponse\"9ڜ\x045\n" +
 	"\fList Shelves\x12!List all shelves in the bookstore\x18\x01(\x01\x12\x8b\x01\n" +
@@ -3381,114 +6740,213 @@ const file_bookstore_v1_bookstore_proto_rawDesc = "" +
 	"\fDelete Genre\x12\x1fDelete a genre in the bookstore \x01\x12\x8c\x01\n" +
 	"\n" +
 	"CreateBook\x12\x1f.bookstore.v1.CreateBookRequest\x1a .bookstore.v1.CreateBookResponse\";ڜ\x047\n" +
-	"\vCreate Book\x12\"Create a new book in the bookstore \x01(\x010\x01\x12y\n" +
-	"\aGetBook\x12\x1c.bookstore.v1.GetBookRequest\x1a\x1d.bookstore.v1.GetBookResponse\"1ڜ\x04-\n" +
-	"\bGet Book\x12\x1bGet a book in the bookstore\x18\x01(\x010\x01\x12\x85\x01\n" +
+	"\vCreate Book\x12\"Create a new book in the bookstore \x01(\x010\x01\x12{\n" +
+	"\aGetBook\x12\x1c.bookstore.v1.GetBookRequest\x1a\x1d.bookstore.v1.GetBookResponse\"3ڜ\x04/\n" +
+	"\bGet Book\x12\x1bGet a book in the bookstore\x18\x01(\x010\x01X\x02\x12\x85\x01\n" +
 	"\tListBooks\x12\x1e.bookstore.v1.ListBooksRequest\x1a\x1f.bookstore.v1.ListBooksResponse\"7ڜ\x043\n" +
 	"\n" +
-	"List Books\x12\x1fList all books in the bookstore\x18\x01(\x010\x01\x12\x84\x01\n" +
+	"List Books\x12\x1fList all books in the bookstore\x18\x01(\x010\x01\x12\x98\x01\n" +
+	"\vExportBooks\x12 .bookstore.v1.ExportBooksRequest\x1a!.bookstore.v1.ExportBooksResponse\"Bڜ\x04>\n" +
+	"\fExport Books\x12*Export all books on a shelf, one at a time\x18\x01(\x010\x01\x12\x91\x01\n" +
+	"\vImportBooks\x12 .bookstore.v1.ImportBooksRequest\x1a!.bookstore.v1.ImportBooksResponse\";ڜ\x047\n" +
+	"\fImport Books\x12%Import books, each onto its own shelf@\x01(\x01\x12\x84\x01\n" +
 	"\n" +
 	"DeleteBook\x12\x1f.bookstore.v1.DeleteBookRequest\x1a .bookstore.v1.DeleteBookResponse\"3ڜ\x04/\n" +
 	"\vDelete Book\x12\x1eDelete a book in the bookstore \x01\x12\x88\x01\n" +
 	"\n" +
 	"UpdateBook\x12\x1f.bookstore.v1.UpdateBookRequest\x1a .bookstore.v1.UpdateBookResponse\"7ڜ\x043\n" +
-	"\vUpdate Book\x12\x1eUpdate a book in the bookstore \x01(\x010\x01\x1a\x06Ҝ\x04\x02\b\x01B\xb5\x01\n" +
+	"\vUpdate Book\x12\x1eUpdate a book in the bookstore \x01(\x010\x01\x1a\x06Ҝ\x04\x02\b\x012\xfd\x02\n" +
+	"\fAdminService\x12\x99\x01\n" +
+	"\bGetStats\x12\x1d.bookstore.v1.GetStatsRequest\x1a\x1e.bookstore.v1.GetStatsResponse\"Nڜ\x04J\n" +
+	"\tGet Stats\x124Get counts of the shelves and books in the bookstore\x18\x01R\x05stats\x12O\n" +
+	"\n" +
+	"PurgeCache\x12\x1f.bookstore.v1.PurgeCacheRequest\x1a .bookstore.v1.PurgeCacheResponse\x12l\n" +
+	"\fRebuildIndex\x12!.bookstore.v1.RebuildIndexRequest\x1a\".bookstore.v1.RebuildIndexResponse\"\x15ڜ\x04\x11\n" +
+	"\rRebuild IndexH\x01\x1a\x12Ҝ\x04\x0e\b\x01\x10\x02\x1a\x06admin_ \x02B\xb5\x01\n" +
 	"\x10com.bookstore.v1B\x0eBookstoreProtoP\x01Z8github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1\xa2\x02\x03BXX\xaa\x02\fBookstore.V1\xca\x02\fBookstore\\V1\xe2\x02\x18Bookstore\\V1\\GPBMetadata\xea\x02\rBookstore::V1\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
-var file_bookstore_v1_bookstore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bookstore_v1_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_bookstore_v1_bookstore_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_bookstore_v1_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_bookstore_v1_bookstore_proto_goTypes = []any{
-	(Author_Gender)(0),            // 0: bookstore.v1.Author.Gender
-	(*CreateGenreRequest)(nil),    // 1: bookstore.v1.CreateGenreRequest
-	(*CreateGenreResponse)(nil),   // 2: bookstore.v1.CreateGenreResponse
-	(*GetGenreRequest)(nil),       // 3: bookstore.v1.GetGenreRequest
-	(*GetGenreResponse)(nil),      // 4: bookstore.v1.GetGenreResponse
-	(*DeleteGenreRequest)(nil),    // 5: bookstore.v1.DeleteGenreRequest
-	(*DeleteGenreResponse)(nil),   // 6: bookstore.v1.DeleteGenreResponse
-	(*ListGenresRequest)(nil),     // 7: bookstore.v1.ListGenresRequest
-	(*ListGenresResponse)(nil),    // 8: bookstore.v1.ListGenresResponse
-	(*DeleteShelfResponse)(nil),   // 9: bookstore.v1.DeleteShelfResponse
-	(*ListShelvesRequest)(nil),    // 10: bookstore.v1.ListShelvesRequest
-	(*DeleteBookResponse)(nil),    // 11: bookstore.v1.DeleteBookResponse
-	(*CreateShelfResponse)(nil),   // 12: bookstore.v1.CreateShelfResponse
-	(*CreateBookResponse)(nil),    // 13: bookstore.v1.CreateBookResponse
-	(*GetBookResponse)(nil),       // 14: bookstore.v1.GetBookResponse
-	(*UpdateBookResponse)(nil),    // 15: bookstore.v1.UpdateBookResponse
-	(*GetAuthorResponse)(nil),     // 16: bookstore.v1.GetAuthorResponse
-	(*Shelf)(nil),                 // 17: bookstore.v1.Shelf
-	(*Genre)(nil),                 // 18: bookstore.v1.Genre
-	(*Book)(nil),                  // 19: bookstore.v1.Book
-	(*Author)(nil),                // 20: bookstore.v1.Author
-	(*ListShelvesResponse)(nil),   // 21: bookstore.v1.ListShelvesResponse
-	(*CreateShelfRequest)(nil),    // 22: bookstore.v1.CreateShelfRequest
-	(*GetShelfRequest)(nil),       // 23: bookstore.v1.GetShelfRequest
-	(*DeleteShelfRequest)(nil),    // 24: bookstore.v1.DeleteShelfRequest
-	(*ListBooksRequest)(nil),      // 25: bookstore.v1.ListBooksRequest
-	(*CreateBookRequest)(nil),     // 26: bookstore.v1.CreateBookRequest
-	(*GetBookRequest)(nil),        // 27: bookstore.v1.GetBookRequest
-	(*UpdateBookRequest)(nil),     // 28: bookstore.v1.UpdateBookRequest
-	(*DeleteBookRequest)(nil),     // 29: bookstore.v1.DeleteBookRequest
-	(*GetAuthorRequest)(nil),      // 30: bookstore.v1.GetAuthorRequest
-	(*RecursiveBookRequest)(nil),  // 31: bookstore.v1.RecursiveBookRequest
-	(*RecursiveBookResponse)(nil), // 32: bookstore.v1.RecursiveBookResponse
-	(*RecursivePage)(nil),         // 33: bookstore.v1.RecursivePage
-	(*ListBooksResponse)(nil),     // 34: bookstore.v1.ListBooksResponse
-	(*timestamppb.Timestamp)(nil), // 35: google.protobuf.Timestamp
-	(*anypb.Any)(nil),             // 36: google.protobuf.Any
-	(*fieldmaskpb.FieldMask)(nil), // 37: google.protobuf.FieldMask
+	(ContactChannel)(0),            // 0: bookstore.v1.ContactChannel
+	(Author_Gender)(0),             // 1: bookstore.v1.Author.Gender
+	(*CreateGenreRequest)(nil),     // 2: bookstore.v1.CreateGenreRequest
+	(*CreateGenreResponse)(nil),    // 3: bookstore.v1.CreateGenreResponse
+	(*GetGenreRequest)(nil),        // 4: bookstore.v1.GetGenreRequest
+	(*GetGenreResponse)(nil),       // 5: bookstore.v1.GetGenreResponse
+	(*DeleteGenreRequest)(nil),     // 6: bookstore.v1.DeleteGenreRequest
+	(*DeleteGenreResponse)(nil),    // 7: bookstore.v1.DeleteGenreResponse
+	(*ListGenresRequest)(nil),      // 8: bookstore.v1.ListGenresRequest
+	(*ListGenresResponse)(nil),     // 9: bookstore.v1.ListGenresResponse
+	(*DeleteShelfResponse)(nil),    // 10: bookstore.v1.DeleteShelfResponse
+	(*ListShelvesRequest)(nil),     // 11: bookstore.v1.ListShelvesRequest
+	(*DeleteBookResponse)(nil),     // 12: bookstore.v1.DeleteBookResponse
+	(*CreateShelfResponse)(nil),    // 13: bookstore.v1.CreateShelfResponse
+	(*CreateBookResponse)(nil),     // 14: bookstore.v1.CreateBookResponse
+	(*GetBookResponse)(nil),        // 15: bookstore.v1.GetBookResponse
+	(*UpdateBookResponse)(nil),     // 16: bookstore.v1.UpdateBookResponse
+	(*GetAuthorResponse)(nil),      // 17: bookstore.v1.GetAuthorResponse
+	(*FeaturedAuthor)(nil),         // 18: bookstore.v1.FeaturedAuthor
+	(*Shelf)(nil),                  // 19: bookstore.v1.Shelf
+	(*Genre)(nil),                  // 20: bookstore.v1.Genre
+	(*Book)(nil),                   // 21: bookstore.v1.Book
+	(*Author)(nil),                 // 22: bookstore.v1.Author
+	(*ListShelvesResponse)(nil),    // 23: bookstore.v1.ListShelvesResponse
+	(*CreateShelfRequest)(nil),     // 24: bookstore.v1.CreateShelfRequest
+	(*GetShelfRequest)(nil),        // 25: bookstore.v1.GetShelfRequest
+	(*DeleteShelfRequest)(nil),     // 26: bookstore.v1.DeleteShelfRequest
+	(*ListBooksRequest)(nil),       // 27: bookstore.v1.ListBooksRequest
+	(*ExportBooksRequest)(nil),     // 28: bookstore.v1.ExportBooksRequest
+	(*ImportBooksRequest)(nil),     // 29: bookstore.v1.ImportBooksRequest
+	(*CreateBookRequest)(nil),      // 30: bookstore.v1.CreateBookRequest
+	(*GetBookRequest)(nil),         // 31: bookstore.v1.GetBookRequest
+	(*UpdateBookRequest)(nil),      // 32: bookstore.v1.UpdateBookRequest
+	(*DeleteBookRequest)(nil),      // 33: bookstore.v1.DeleteBookRequest
+	(*GetAuthorRequest)(nil),       // 34: bookstore.v1.GetAuthorRequest
+	(*SearchBooksRequest)(nil),     // 35: bookstore.v1.SearchBooksRequest
+	(*PublisherContact)(nil),       // 36: bookstore.v1.PublisherContact
+	(*ShelfInventory)(nil),         // 37: bookstore.v1.ShelfInventory
+	(*RepeatedRules)(nil),          // 38: bookstore.v1.RepeatedRules
+	(*BookListing)(nil),            // 39: bookstore.v1.BookListing
+	(*KnownTypes)(nil),             // 40: bookstore.v1.KnownTypes
+	(*RecursiveBookRequest)(nil),   // 41: bookstore.v1.RecursiveBookRequest
+	(*RecursiveBookResponse)(nil),  // 42: bookstore.v1.RecursiveBookResponse
+	(*RecursivePage)(nil),          // 43: bookstore.v1.RecursivePage
+	(*ListBooksResponse)(nil),      // 44: bookstore.v1.ListBooksResponse
+	(*ExportBooksResponse)(nil),    // 45: bookstore.v1.ExportBooksResponse
+	(*ImportBooksResponse)(nil),    // 46: bookstore.v1.ImportBooksResponse
+	(*GetStatsRequest)(nil),        // 47: bookstore.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),       // 48: bookstore.v1.GetStatsResponse
+	(*PurgeCacheRequest)(nil),      // 49: bookstore.v1.PurgeCacheRequest
+	(*PurgeCacheResponse)(nil),     // 50: bookstore.v1.PurgeCacheResponse
+	(*RebuildIndexRequest)(nil),    // 51: bookstore.v1.RebuildIndexRequest
+	(*RebuildIndexResponse)(nil),   // 52: bookstore.v1.RebuildIndexResponse
+	nil,                            // 53: bookstore.v1.ShelfInventory.BooksByShelfEntry
+	nil,                            // 54: bookstore.v1.ShelfInventory.AvailableEntry
+	nil,                            // 55: bookstore.v1.ShelfInventory.NotesEntry
+	nil,                            // 56: bookstore.v1.ShelfInventory.ChannelsEntry
+	nil,                            // 57: bookstore.v1.BookListing.StockEntry
+	nil,                            // 58: bookstore.v1.KnownTypes.LabelsEntry
+	(*timestamppb.Timestamp)(nil),  // 59: google.protobuf.Timestamp
+	(*anypb.Any)(nil),              // 60: google.protobuf.Any
+	(*fieldmaskpb.FieldMask)(nil),  // 61: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),    // 62: google.protobuf.Duration
+	(*emptypb.Empty)(nil),          // 63: google.protobuf.Empty
+	(*structpb.Struct)(nil),        // 64: google.protobuf.Struct
+	(*structpb.Value)(nil),         // 65: google.protobuf.Value
+	(*structpb.ListValue)(nil),     // 66: google.protobuf.ListValue
+	(structpb.NullValue)(0),        // 67: google.protobuf.NullValue
+	(*wrapperspb.BoolValue)(nil),   // 68: google.protobuf.BoolValue
+	(*wrapperspb.Int32Value)(nil),  // 69: google.protobuf.Int32Value
+	(*wrapperspb.Int64Value)(nil),  // 70: google.protobuf.Int64Value
+	(*wrapperspb.UInt32Value)(nil), // 71: google.protobuf.UInt32Value
+	(*wrapperspb.UInt64Value)(nil), // 72: google.protobuf.UInt64Value
+	(*wrapperspb.FloatValue)(nil),  // 73: google.protobuf.FloatValue
+	(*wrapperspb.DoubleValue)(nil), // 74: google.protobuf.DoubleValue
+	(*wrapperspb.StringValue)(nil), // 75: google.protobuf.StringValue
+	(*wrapperspb.BytesValue)(nil),  // 76: google.protobuf.BytesValue
 }
 var file_bookstore_v1_bookstore_proto_depIdxs = []int32{
-	18, // 0: bookstore.v1.CreateGenreResponse.genre:type_name -> bookstore.v1.Genre
-	18, // 1: bookstore.v1.GetGenreResponse.genre:type_name -> bookstore.v1.Genre
-	18, // 2: bookstore.v1.ListGenresResponse.genres:type_name -> bookstore.v1.Genre
-	17, // 3: bookstore.v1.CreateShelfResponse.shelf:type_name -> bookstore.v1.Shelf
-	19, // 4: bookstore.v1.CreateBookResponse.book:type_name -> bookstore.v1.Book
-	19, // 5: bookstore.v1.GetBookResponse.book:type_name -> bookstore.v1.Book
-	19, // 6: bookstore.v1.UpdateBookResponse.book:type_name -> bookstore.v1.Book
-	20, // 7: bookstore.v1.GetAuthorResponse.author:type_name -> bookstore.v1.Author
-	0,  // 8: bookstore.v1.Author.gender:type_name -> bookstore.v1.Author.Gender
-	35, // 9: bookstore.v1.Author.created_at:type_name -> google.protobuf.Timestamp
-	36, // 10: bookstore.v1.Author.books:type_name -> google.protobuf.Any
-	17, // 11: bookstore.v1.ListShelvesResponse.shelves:type_name -> bookstore.v1.Shelf
-	37, // 12: bookstore.v1.ListShelvesResponse.mask:type_name -> google.protobuf.FieldMask
-	17, // 13: bookstore.v1.CreateShelfRequest.shelf:type_name -> bookstore.v1.Shelf
-	19, // 14: bookstore.v1.CreateBookRequest.book:type_name -> bookstore.v1.Book
-	19, // 15: bookstore.v1.UpdateBookRequest.book:type_name -> bookstore.v1.Book
-	19, // 16: bookstore.v1.DeleteBookRequest.book:type_name -> bookstore.v1.Book
-	33, // 17: bookstore.v1.RecursiveBookResponse.page:type_name -> bookstore.v1.RecursivePage
-	32, // 18: bookstore.v1.RecursivePage.books:type_name -> bookstore.v1.RecursiveBookResponse
-	32, // 19: bookstore.v1.RecursivePage.pages:type_name -> bookstore.v1.RecursiveBookResponse
-	33, // 20: bookstore.v1.RecursivePage.extra_pages:type_name -> bookstore.v1.RecursivePage
-	19, // 21: bookstore.v1.ListBooksResponse.books:type_name -> bookstore.v1.Book
-	10, // 22: bookstore.v1.BookstoreService.ListShelves:input_type -> bookstore.v1.ListShelvesRequest
-	22, // 23: bookstore.v1.BookstoreService.CreateShelf:input_type -> bookstore.v1.CreateShelfRequest
-	24, // 24: bookstore.v1.BookstoreService.DeleteShelf:input_type -> bookstore.v1.DeleteShelfRequest
-	7,  // 25: bookstore.v1.BookstoreService.ListGenres:input_type -> bookstore.v1.ListGenresRequest
-	1,  // 26: bookstore.v1.BookstoreService.CreateGenre:input_type -> bookstore.v1.CreateGenreRequest
-	3,  // 27: bookstore.v1.BookstoreService.GetGenre:input_type -> bookstore.v1.GetGenreRequest
-	5,  // 28: bookstore.v1.BookstoreService.DeleteGenre:input_type -> bookstore.v1.DeleteGenreRequest
-	26, // 29: bookstore.v1.BookstoreService.CreateBook:input_type -> bookstore.v1.CreateBookRequest
-	27, // 30: bookstore.v1.BookstoreService.GetBook:input_type -> bookstore.v1.GetBookRequest
-	25, // 31: bookstore.v1.BookstoreService.ListBooks:input_type -> bookstore.v1.ListBooksRequest
-	29, // 32: bookstore.v1.BookstoreService.DeleteBook:input_type -> bookstore.v1.DeleteBookRequest
-	28, // 33: bookstore.v1.BookstoreService.UpdateBook:input_type -> bookstore.v1.UpdateBookRequest
-	21, // 34: bookstore.v1.BookstoreService.ListShelves:output_type -> bookstore.v1.ListShelvesResponse
-	12, // 35: bookstore.v1.BookstoreService.CreateShelf:output_type -> bookstore.v1.CreateShelfResponse
-	9,  // 36: bookstore.v1.BookstoreService.DeleteShelf:output_type -> bookstore.v1.DeleteShelfResponse
-	8,  // 37: bookstore.v1.BookstoreService.ListGenres:output_type -> bookstore.v1.ListGenresResponse
-	2,  // 38: bookstore.v1.BookstoreService.CreateGenre:output_type -> bookstore.v1.CreateGenreResponse
-	4,  // 39: bookstore.v1.BookstoreService.GetGenre:output_type -> bookstore.v1.GetGenreResponse
-	6,  // 40: bookstore.v1.BookstoreService.DeleteGenre:output_type -> bookstore.v1.DeleteGenreResponse
-	13, // 41: bookstore.v1.BookstoreService.CreateBook:output_type -> bookstore.v1.CreateBookResponse
-	14, // 42: bookstore.v1.BookstoreService.GetBook:output_type -> bookstore.v1.GetBookResponse
-	34, // 43: bookstore.v1.BookstoreService.ListBooks:output_type -> bookstore.v1.ListBooksResponse
-	11, // 44: bookstore.v1.BookstoreService.DeleteBook:output_type -> bookstore.v1.DeleteBookResponse
-	15, // 45: bookstore.v1.BookstoreService.UpdateBook:output_type -> bookstore.v1.UpdateBookResponse
-	34, // [34:46] is the sub-list for method output_type
-	22, // [22:34] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	20, // 0: bookstore.v1.CreateGenreResponse.genre:type_name -> bookstore.v1.Genre
+	20, // 1: bookstore.v1.GetGenreResponse.genre:type_name -> bookstore.v1.Genre
+	20, // 2: bookstore.v1.ListGenresResponse.genres:type_name -> bookstore.v1.Genre
+	19, // 3: bookstore.v1.CreateShelfResponse.shelf:type_name -> bookstore.v1.Shelf
+	21, // 4: bookstore.v1.CreateBookResponse.book:type_name -> bookstore.v1.Book
+	21, // 5: bookstore.v1.GetBookResponse.book:type_name -> bookstore.v1.Book
+	21, // 6: bookstore.v1.UpdateBookResponse.book:type_name -> bookstore.v1.Book
+	22, // 7: bookstore.v1.GetAuthorResponse.author:type_name -> bookstore.v1.Author
+	17, // 8: bookstore.v1.FeaturedAuthor.author:type_name -> bookstore.v1.GetAuthorResponse
+	1,  // 9: bookstore.v1.Author.gender:type_name -> bookstore.v1.Author.Gender
+	59, // 10: bookstore.v1.Author.created_at:type_name -> google.protobuf.Timestamp
+	60, // 11: bookstore.v1.Author.books:type_name -> google.protobuf.Any
+	19, // 12: bookstore.v1.ListShelvesResponse.shelves:type_name -> bookstore.v1.Shelf
+	61, // 13: bookstore.v1.ListShelvesResponse.mask:type_name -> google.protobuf.FieldMask
+	19, // 14: bookstore.v1.CreateShelfRequest.shelf:type_name -> bookstore.v1.Shelf
+	21, // 15: bookstore.v1.ImportBooksRequest.book:type_name -> bookstore.v1.Book
+	21, // 16: bookstore.v1.CreateBookRequest.book:type_name -> bookstore.v1.Book
+	21, // 17: bookstore.v1.UpdateBookRequest.book:type_name -> bookstore.v1.Book
+	21, // 18: bookstore.v1.DeleteBookRequest.book:type_name -> bookstore.v1.Book
+	59, // 19: bookstore.v1.SearchBooksRequest.published_after:type_name -> google.protobuf.Timestamp
+	0,  // 20: bookstore.v1.PublisherContact.channel:type_name -> bookstore.v1.ContactChannel
+	0,  // 21: bookstore.v1.PublisherContact.fallback_channel:type_name -> bookstore.v1.ContactChannel
+	53, // 22: bookstore.v1.ShelfInventory.books_by_shelf:type_name -> bookstore.v1.ShelfInventory.BooksByShelfEntry
+	54, // 23: bookstore.v1.ShelfInventory.available:type_name -> bookstore.v1.ShelfInventory.AvailableEntry
+	55, // 24: bookstore.v1.ShelfInventory.notes:type_name -> bookstore.v1.ShelfInventory.NotesEntry
+	56, // 25: bookstore.v1.ShelfInventory.channels:type_name -> bookstore.v1.ShelfInventory.ChannelsEntry
+	0,  // 26: bookstore.v1.RepeatedRules.channels:type_name -> bookstore.v1.ContactChannel
+	59, // 27: bookstore.v1.BookListing.listed_at:type_name -> google.protobuf.Timestamp
+	57, // 28: bookstore.v1.BookListing.stock:type_name -> bookstore.v1.BookListing.StockEntry
+	59, // 29: bookstore.v1.KnownTypes.timestamp:type_name -> google.protobuf.Timestamp
+	62, // 30: bookstore.v1.KnownTypes.duration:type_name -> google.protobuf.Duration
+	61, // 31: bookstore.v1.KnownTypes.field_mask:type_name -> google.protobuf.FieldMask
+	60, // 32: bookstore.v1.KnownTypes.any:type_name -> google.protobuf.Any
+	63, // 33: bookstore.v1.KnownTypes.empty:type_name -> google.protobuf.Empty
+	64, // 34: bookstore.v1.KnownTypes.struct:type_name -> google.protobuf.Struct
+	65, // 35: bookstore.v1.KnownTypes.value:type_name -> google.protobuf.Value
+	66, // 36: bookstore.v1.KnownTypes.list_value:type_name -> google.protobuf.ListValue
+	67, // 37: bookstore.v1.KnownTypes.null_value:type_name -> google.protobuf.NullValue
+	68, // 38: bookstore.v1.KnownTypes.bool_value:type_name -> google.protobuf.BoolValue
+	69, // 39: bookstore.v1.KnownTypes.int32_value:type_name -> google.protobuf.Int32Value
+	70, // 40: bookstore.v1.KnownTypes.int64_value:type_name -> google.protobuf.Int64Value
+	71, // 41: bookstore.v1.KnownTypes.uint32_value:type_name -> google.protobuf.UInt32Value
+	72, // 42: bookstore.v1.KnownTypes.uint64_value:type_name -> google.protobuf.UInt64Value
+	73, // 43: bookstore.v1.KnownTypes.float_value:type_name -> google.protobuf.FloatValue
+	74, // 44: bookstore.v1.KnownTypes.double_value:type_name -> google.protobuf.DoubleValue
+	75, // 45: bookstore.v1.KnownTypes.string_value:type_name -> google.protobuf.StringValue
+	76, // 46: bookstore.v1.KnownTypes.bytes_value:type_name -> google.protobuf.BytesValue
+	65, // 47: bookstore.v1.KnownTypes.values:type_name -> google.protobuf.Value
+	58, // 48: bookstore.v1.KnownTypes.labels:type_name -> bookstore.v1.KnownTypes.LabelsEntry
+	70, // 49: bookstore.v1.KnownTypes.counts:type_name -> google.protobuf.Int64Value
+	67, // 50: bookstore.v1.KnownTypes.nulls:type_name -> google.protobuf.NullValue
+	65, // 51: bookstore.v1.KnownTypes.note_value:type_name -> google.protobuf.Value
+	1,  // 52: bookstore.v1.KnownTypes.gender:type_name -> bookstore.v1.Author.Gender
+	43, // 53: bookstore.v1.RecursiveBookResponse.page:type_name -> bookstore.v1.RecursivePage
+	42, // 54: bookstore.v1.RecursivePage.books:type_name -> bookstore.v1.RecursiveBookResponse
+	42, // 55: bookstore.v1.RecursivePage.pages:type_name -> bookstore.v1.RecursiveBookResponse
+	43, // 56: bookstore.v1.RecursivePage.extra_pages:type_name -> bookstore.v1.RecursivePage
+	21, // 57: bookstore.v1.ListBooksResponse.books:type_name -> bookstore.v1.Book
+	21, // 58: bookstore.v1.ExportBooksResponse.book:type_name -> bookstore.v1.Book
+	0,  // 59: bookstore.v1.ShelfInventory.ChannelsEntry.value:type_name -> bookstore.v1.ContactChannel
+	65, // 60: bookstore.v1.KnownTypes.LabelsEntry.value:type_name -> google.protobuf.Value
+	11, // 61: bookstore.v1.BookstoreService.ListShelves:input_type -> bookstore.v1.ListShelvesRequest
+	24, // 62: bookstore.v1.BookstoreService.CreateShelf:input_type -> bookstore.v1.CreateShelfRequest
+	26, // 63: bookstore.v1.BookstoreService.DeleteShelf:input_type -> bookstore.v1.DeleteShelfRequest
+	8,  // 64: bookstore.v1.BookstoreService.ListGenres:input_type -> bookstore.v1.ListGenresRequest
+	2,  // 65: bookstore.v1.BookstoreService.CreateGenre:input_type -> bookstore.v1.CreateGenreRequest
+	4,  // 66: bookstore.v1.BookstoreService.GetGenre:input_type -> bookstore.v1.GetGenreRequest
+	6,  // 67: bookstore.v1.BookstoreService.DeleteGenre:input_type -> bookstore.v1.DeleteGenreRequest
+	30, // 68: bookstore.v1.BookstoreService.CreateBook:input_type -> bookstore.v1.CreateBookRequest
+	31, // 69: bookstore.v1.BookstoreService.GetBook:input_type -> bookstore.v1.GetBookRequest
+	27, // 70: bookstore.v1.BookstoreService.ListBooks:input_type -> bookstore.v1.ListBooksRequest
+	28, // 71: bookstore.v1.BookstoreService.ExportBooks:input_type -> bookstore.v1.ExportBooksRequest
+	29, // 72: bookstore.v1.BookstoreService.ImportBooks:input_type -> bookstore.v1.ImportBooksRequest
+	33, // 73: bookstore.v1.BookstoreService.DeleteBook:input_type -> bookstore.v1.DeleteBookRequest
+	32, // 74: bookstore.v1.BookstoreService.UpdateBook:input_type -> bookstore.v1.UpdateBookRequest
+	47, // 75: bookstore.v1.AdminService.GetStats:input_type -> bookstore.v1.GetStatsRequest
+	49, // 76: bookstore.v1.AdminService.PurgeCache:input_type -> bookstore.v1.PurgeCacheRequest
+	51, // 77: bookstore.v1.AdminService.RebuildIndex:input_type -> bookstore.v1.RebuildIndexRequest
+	23, // 78: bookstore.v1.BookstoreService.ListShelves:output_type -> bookstore.v1.ListShelvesResponse
+	13, // 79: bookstore.v1.BookstoreService.CreateShelf:output_type -> bookstore.v1.CreateShelfResponse
+	10, // 80: bookstore.v1.BookstoreService.DeleteShelf:output_type -> bookstore.v1.DeleteShelfResponse
+	9,  // 81: bookstore.v1.BookstoreService.ListGenres:output_type -> bookstore.v1.ListGenresResponse
+	3,  // 82: bookstore.v1.BookstoreService.CreateGenre:output_type -> bookstore.v1.CreateGenreResponse
+	5,  // 83: bookstore.v1.BookstoreService.GetGenre:output_type -> bookstore.v1.GetGenreResponse
+	7,  // 84: bookstore.v1.BookstoreService.DeleteGenre:output_type -> bookstore.v1.DeleteGenreResponse
+	14, // 85: bookstore.v1.BookstoreService.CreateBook:output_type -> bookstore.v1.CreateBookResponse
+	15, // 86: bookstore.v1.BookstoreService.GetBook:output_type -> bookstore.v1.GetBookResponse
+	44, // 87: bookstore.v1.BookstoreService.ListBooks:output_type -> bookstore.v1.ListBooksResponse
+	45, // 88: bookstore.v1.BookstoreService.ExportBooks:output_type -> bookstore.v1.ExportBooksResponse
+	46, // 89: bookstore.v1.BookstoreService.ImportBooks:output_type -> bookstore.v1.ImportBooksResponse
+	12, // 90: bookstore.v1.BookstoreService.DeleteBook:output_type -> bookstore.v1.DeleteBookResponse
+	16, // 91: bookstore.v1.BookstoreService.UpdateBook:output_type -> bookstore.v1.UpdateBookResponse
+	48, // 92: bookstore.v1.AdminService.GetStats:output_type -> bookstore.v1.GetStatsResponse
+	50, // 93: bookstore.v1.AdminService.PurgeCache:output_type -> bookstore.v1.PurgeCacheResponse
+	52, // 94: bookstore.v1.AdminService.RebuildIndex:output_type -> bookstore.v1.RebuildIndexResponse
+	78, // [78:95] is the sub-list for method output_type
+	61, // [61:78] is the sub-list for method input_type
+	61, // [61:61] is the sub-list for extension type_name
+	61, // [61:61] is the sub-list for extension extendee
+	0,  // [0:61] is the sub-list for field type_name
 }
 
 func init() { file_bookstore_v1_bookstore_proto_init() }
@@ -3500,15 +6958,19 @@ func file_bookstore_v1_bookstore_proto_init() {
 		(*getAuthorResponse_Fiction)(nil),
 		(*getAuthorResponse_Nonfiction)(nil),
 	}
+	file_bookstore_v1_bookstore_proto_msgTypes[38].OneofWrappers = []any{
+		(*knownTypes_NoteValue)(nil),
+		(*knownTypes_NoteText)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bookstore_v1_bookstore_proto_rawDesc), len(file_bookstore_v1_bookstore_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   34,
+			NumEnums:      2,
+			NumMessages:   57,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_bookstore_v1_bookstore_proto_goTypes,
 		DependencyIndexes: file_bookstore_v1_bookstore_proto_depIdxs,
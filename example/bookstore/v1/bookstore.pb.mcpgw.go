@@ -2,12 +2,11 @@
 package v1
 
 import (
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+
 	context "context"
 
-	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
-	mcpgw_schema "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1/schema"
 	grpc "google.golang.org/grpc"
-	protojson "google.golang.org/protobuf/encoding/protojson"
 	proto "google.golang.org/protobuf/proto"
 )
 
@@ -21,9 +20,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 	Methods: []*mcpgw_v1.MethodDesc{
 		{
 			Method:        BookstoreService_ListShelves_FullMethodName,
+			Name:          "BookstoreService_ListShelves",
 			Handler:       _BookstoreService_ListShelves_MCPGW_Handler,
 			Decoder:       _BookstoreService_ListShelves_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_ListShelves_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_ListShelves_MCPGW_OutputSchema,
 			Title:         "List Shelves",
 			Description:   "List all shelves in the bookstore",
 			ReadOnlyHint:  true,
@@ -33,9 +34,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_CreateShelf_FullMethodName,
+			Name:          "BookstoreService_CreateShelf",
 			Handler:       _BookstoreService_CreateShelf_MCPGW_Handler,
 			Decoder:       _BookstoreService_CreateShelf_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_CreateShelf_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_CreateShelf_MCPGW_OutputSchema,
 			Title:         "Create Shelf",
 			Description:   "Create a new shelf in the bookstore",
 			ReadOnlyHint:  false,
@@ -45,9 +48,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_DeleteShelf_FullMethodName,
+			Name:          "BookstoreService_DeleteShelf",
 			Handler:       _BookstoreService_DeleteShelf_MCPGW_Handler,
 			Decoder:       _BookstoreService_DeleteShelf_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_DeleteShelf_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_DeleteShelf_MCPGW_OutputSchema,
 			Title:         "Delete Shelf",
 			Description:   "Delete a shelf in the bookstore",
 			ReadOnlyHint:  false,
@@ -57,9 +62,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_ListGenres_FullMethodName,
+			Name:          "BookstoreService_ListGenres",
 			Handler:       _BookstoreService_ListGenres_MCPGW_Handler,
 			Decoder:       _BookstoreService_ListGenres_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_ListGenres_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_ListGenres_MCPGW_OutputSchema,
 			Title:         "List Genres",
 			Description:   "List all genres in the bookstore",
 			ReadOnlyHint:  true,
@@ -69,9 +76,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_CreateGenre_FullMethodName,
+			Name:          "BookstoreService_CreateGenre",
 			Handler:       _BookstoreService_CreateGenre_MCPGW_Handler,
 			Decoder:       _BookstoreService_CreateGenre_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_CreateGenre_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_CreateGenre_MCPGW_OutputSchema,
 			Title:         "Create Genre",
 			Description:   "Create a new genre in the bookstore",
 			ReadOnlyHint:  false,
@@ -81,9 +90,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_GetGenre_FullMethodName,
+			Name:          "BookstoreService_GetGenre",
 			Handler:       _BookstoreService_GetGenre_MCPGW_Handler,
 			Decoder:       _BookstoreService_GetGenre_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_GetGenre_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_GetGenre_MCPGW_OutputSchema,
 			Title:         "Get Genre",
 			Description:   "Get a genre in the bookstore",
 			ReadOnlyHint:  false,
@@ -93,9 +104,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_DeleteGenre_FullMethodName,
+			Name:          "BookstoreService_DeleteGenre",
 			Handler:       _BookstoreService_DeleteGenre_MCPGW_Handler,
 			Decoder:       _BookstoreService_DeleteGenre_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_DeleteGenre_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_DeleteGenre_MCPGW_OutputSchema,
 			Title:         "Delete Genre",
 			Description:   "Delete a genre in the bookstore",
 			ReadOnlyHint:  false,
@@ -105,9 +118,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_CreateBook_FullMethodName,
+			Name:          "BookstoreService_CreateBook",
 			Handler:       _BookstoreService_CreateBook_MCPGW_Handler,
 			Decoder:       _BookstoreService_CreateBook_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_CreateBook_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_CreateBook_MCPGW_OutputSchema,
 			Title:         "Create Book",
 			Description:   "Create a new book in the bookstore",
 			ReadOnlyHint:  false,
@@ -116,22 +131,27 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 			OpenWorldHint: true,
 		},
 		{
-			Method:        BookstoreService_GetBook_FullMethodName,
-			Handler:       _BookstoreService_GetBook_MCPGW_Handler,
-			Decoder:       _BookstoreService_GetBook_MCPGW_Decoder,
-			InputSchema:   _BookstoreService_GetBook_MCPGW_InputSchema,
-			Title:         "Get Book",
-			Description:   "Get a book in the bookstore",
-			ReadOnlyHint:  true,
-			Destructive:   false,
-			Idempotent:    true,
-			OpenWorldHint: true,
+			Method:           BookstoreService_GetBook_FullMethodName,
+			Name:             "BookstoreService_GetBook",
+			Handler:          _BookstoreService_GetBook_MCPGW_Handler,
+			Decoder:          _BookstoreService_GetBook_MCPGW_Decoder,
+			ArgumentDecoding: mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_LENIENT,
+			InputSchema:      _BookstoreService_GetBook_MCPGW_InputSchema,
+			OutputSchema:     _BookstoreService_GetBook_MCPGW_OutputSchema,
+			Title:            "Get Book",
+			Description:      "Get a book in the bookstore",
+			ReadOnlyHint:     true,
+			Destructive:      false,
+			Idempotent:       true,
+			OpenWorldHint:    true,
 		},
 		{
 			Method:        BookstoreService_ListBooks_FullMethodName,
+			Name:          "BookstoreService_ListBooks",
 			Handler:       _BookstoreService_ListBooks_MCPGW_Handler,
 			Decoder:       _BookstoreService_ListBooks_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_ListBooks_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_ListBooks_MCPGW_OutputSchema,
 			Title:         "List Books",
 			Description:   "List all books in the bookstore",
 			ReadOnlyHint:  true,
@@ -139,11 +159,47 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 			Idempotent:    true,
 			OpenWorldHint: true,
 		},
+		{
+			Method:        BookstoreService_ExportBooks_FullMethodName,
+			Name:          "BookstoreService_ExportBooks",
+			StreamHandler: _BookstoreService_ExportBooks_MCPGW_Handler,
+			ServerStreams: true,
+			ClientStreams: false,
+			StreamResult:  mcpgw_v1.StreamResult_STREAM_RESULT_ALL,
+			Decoder:       _BookstoreService_ExportBooks_MCPGW_Decoder,
+			InputSchema:   _BookstoreService_ExportBooks_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_ExportBooks_MCPGW_OutputSchema,
+			Title:         "Export Books",
+			Description:   "Export all books on a shelf, one at a time",
+			ReadOnlyHint:  true,
+			Destructive:   false,
+			Idempotent:    true,
+			OpenWorldHint: false,
+		},
+		{
+			Method:        BookstoreService_ImportBooks_FullMethodName,
+			Name:          "BookstoreService_ImportBooks",
+			StreamHandler: _BookstoreService_ImportBooks_MCPGW_Handler,
+			ServerStreams: false,
+			ClientStreams: true,
+			StreamResult:  mcpgw_v1.StreamResult_STREAM_RESULT_ALL,
+			Decoder:       _BookstoreService_ImportBooks_MCPGW_Decoder,
+			InputSchema:   _BookstoreService_ImportBooks_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_ImportBooks_MCPGW_OutputSchema,
+			Title:         "Import Books",
+			Description:   "Import books, each onto its own shelf",
+			ReadOnlyHint:  false,
+			Destructive:   false,
+			Idempotent:    false,
+			OpenWorldHint: false,
+		},
 		{
 			Method:        BookstoreService_DeleteBook_FullMethodName,
+			Name:          "BookstoreService_DeleteBook",
 			Handler:       _BookstoreService_DeleteBook_MCPGW_Handler,
 			Decoder:       _BookstoreService_DeleteBook_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_DeleteBook_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_DeleteBook_MCPGW_OutputSchema,
 			Title:         "Delete Book",
 			Description:   "Delete a book in the bookstore",
 			ReadOnlyHint:  false,
@@ -153,9 +209,11 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 		},
 		{
 			Method:        BookstoreService_UpdateBook_FullMethodName,
+			Name:          "BookstoreService_UpdateBook",
 			Handler:       _BookstoreService_UpdateBook_MCPGW_Handler,
 			Decoder:       _BookstoreService_UpdateBook_MCPGW_Decoder,
 			InputSchema:   _BookstoreService_UpdateBook_MCPGW_InputSchema,
+			OutputSchema:  _BookstoreService_UpdateBook_MCPGW_OutputSchema,
 			Title:         "Update Book",
 			Description:   "Update a book in the bookstore",
 			ReadOnlyHint:  false,
@@ -166,10 +224,71 @@ var mcpgw_desc_BookstoreServiceServer = mcpgw_v1.ServiceDesc{
 	},
 }
 
-func _BookstoreService_ListShelves_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*ListShelvesRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&ListShelvesRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_ListShelves_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "ListShelvesRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_ListShelves_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Response to ListShelves call.",
+	"properties": {
+		"mask": {
+			"pattern": "^([a-zA-Z0-9_.]+)(,[a-zA-Z0-9_.]+)*$",
+			"type": "string"
+		},
+		"shelves": {
+			"description": "Shelves in the bookstore.",
+			"items": {
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"additionalProperties": false,
+				"description": "A shelf resource.",
+				"properties": {
+					"id": {
+						"description": "A unique shelf id.",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"search%5Bencoded%5D": {
+						"description": "To test json name is percentage encoded",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"search[decoded]": {
+						"description": "To test json name is percentage decoded",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"theme": {
+						"description": "A theme of the shelf (fiction, poetry, etc).",
+						"type": [
+							"string",
+							"null"
+						]
+					}
+				},
+				"title": "Shelf",
+				"type": "object"
+			},
+			"type": [
+				"array",
+				"null"
+			]
+		}
+	},
+	"title": "ListShelvesResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_ListShelves_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(ListShelvesRequest)
@@ -195,20 +314,107 @@ func _BookstoreService_ListShelves_MCPGW_Handler(srv any, ctx context.Context, d
 }
 
 func _BookstoreService_ListShelves_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_CreateShelf_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*CreateShelfRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&CreateShelfRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_CreateShelf_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for CreateShelf method.",
+	"properties": {
+		"shelf": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "The shelf resource to create.",
+			"properties": {
+				"id": {
+					"description": "A unique shelf id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"search%5Bencoded%5D": {
+					"description": "To test json name is percentage encoded",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"search[decoded]": {
+					"description": "To test json name is percentage decoded",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"theme": {
+					"description": "A theme of the shelf (fiction, poetry, etc).",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Shelf",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "CreateShelfRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_CreateShelf_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"shelf": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A shelf resource.",
+			"properties": {
+				"id": {
+					"description": "A unique shelf id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"search%5Bencoded%5D": {
+					"description": "To test json name is percentage encoded",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"search[decoded]": {
+					"description": "To test json name is percentage decoded",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"theme": {
+					"description": "A theme of the shelf (fiction, poetry, etc).",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Shelf",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "CreateShelfResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_CreateShelf_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(CreateShelfRequest)
@@ -234,20 +440,33 @@ func _BookstoreService_CreateShelf_MCPGW_Handler(srv any, ctx context.Context, d
 }
 
 func _BookstoreService_CreateShelf_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_DeleteShelf_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*DeleteShelfRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&DeleteShelfRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_DeleteShelf_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for DeleteShelf method.",
+	"properties": {
+		"shelf": {
+			"description": "The ID of the shelf to delete.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "DeleteShelfRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_DeleteShelf_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "DeleteShelfResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_DeleteShelf_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(DeleteShelfRequest)
@@ -273,20 +492,55 @@ func _BookstoreService_DeleteShelf_MCPGW_Handler(srv any, ctx context.Context, d
 }
 
 func _BookstoreService_DeleteShelf_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_ListGenres_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*ListGenresRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&ListGenresRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_ListGenres_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "ListGenresRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_ListGenres_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"genres": {
+			"items": {
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"additionalProperties": false,
+				"description": "A book genre",
+				"properties": {
+					"id": {
+						"description": "A unique genre id.",
+						"pattern": "^-?[0-9]+$",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"name": {
+						"description": "A genre name.",
+						"type": [
+							"string",
+							"null"
+						]
+					}
+				},
+				"title": "Genre",
+				"type": "object"
+			},
+			"type": [
+				"array",
+				"null"
+			]
+		}
+	},
+	"title": "ListGenresResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_ListGenres_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(ListGenresRequest)
@@ -312,20 +566,62 @@ func _BookstoreService_ListGenres_MCPGW_Handler(srv any, ctx context.Context, de
 }
 
 func _BookstoreService_ListGenres_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_CreateGenre_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*CreateGenreRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&CreateGenreRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_CreateGenre_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"name": {
+			"description": "The name of the genre",
+			"maxLength": 50,
+			"minLength": 1,
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "CreateGenreRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_CreateGenre_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"genre": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book genre",
+			"properties": {
+				"id": {
+					"description": "A unique genre id.",
+					"pattern": "^-?[0-9]+$",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"name": {
+					"description": "A genre name.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Genre",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "CreateGenreResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_CreateGenre_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(CreateGenreRequest)
@@ -351,20 +647,59 @@ func _BookstoreService_CreateGenre_MCPGW_Handler(srv any, ctx context.Context, d
 }
 
 func _BookstoreService_CreateGenre_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_GetGenre_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*GetGenreRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&GetGenreRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_GetGenre_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"genreId": {
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "GetGenreRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_GetGenre_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"genre": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book genre",
+			"properties": {
+				"id": {
+					"description": "A unique genre id.",
+					"pattern": "^-?[0-9]+$",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"name": {
+					"description": "A genre name.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Genre",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "GetGenreResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_GetGenre_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(GetGenreRequest)
@@ -390,20 +725,31 @@ func _BookstoreService_GetGenre_MCPGW_Handler(srv any, ctx context.Context, dec
 }
 
 func _BookstoreService_GetGenre_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_DeleteGenre_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*DeleteGenreRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&DeleteGenreRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_DeleteGenre_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"genreId": {
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "DeleteGenreRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_DeleteGenre_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "DeleteGenreResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_DeleteGenre_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(DeleteGenreRequest)
@@ -429,20 +775,139 @@ func _BookstoreService_DeleteGenre_MCPGW_Handler(srv any, ctx context.Context, d
 }
 
 func _BookstoreService_DeleteGenre_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_CreateBook_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*CreateBookRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&CreateBookRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_CreateBook_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for CreateBook method.",
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book resource to create on the shelf.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		},
+		"shelf": {
+			"description": "The ID of the shelf on which to create a book.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "CreateBookRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_CreateBook_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book resource.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"createdBy": {
+					"description": "The user who added the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "CreateBookResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_CreateBook_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(CreateBookRequest)
@@ -468,20 +933,116 @@ func _BookstoreService_CreateBook_MCPGW_Handler(srv any, ctx context.Context, de
 }
 
 func _BookstoreService_CreateBook_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_GetBook_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*GetBookRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&GetBookRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_GetBook_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for GetBook method.",
+	"properties": {
+		"book": {
+			"description": "The ID of the book to retrieve.",
+			"pattern": "^-?[0-9]+$",
+			"type": [
+				"string",
+				"null"
+			]
+		},
+		"includeAuthor": {
+			"type": [
+				"boolean",
+				"null"
+			]
+		},
+		"pageSize": {
+			"type": [
+				"integer",
+				"null"
+			]
+		},
+		"pageToken": {
+			"type": [
+				"string",
+				"null"
+			]
+		},
+		"shelf": {
+			"description": "The ID of the shelf from which to retrieve a book.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "GetBookRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_GetBook_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book resource.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"createdBy": {
+					"description": "The user who added the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "GetBookResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_GetBook_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(GetBookRequest)
@@ -507,20 +1068,93 @@ func _BookstoreService_GetBook_MCPGW_Handler(srv any, ctx context.Context, dec f
 }
 
 func _BookstoreService_GetBook_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.DecodeLenient(ctx, input, out)
 }
 
-func _BookstoreService_ListBooks_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*ListBooksRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&ListBooksRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_ListBooks_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for ListBooks method.",
+	"properties": {
+		"shelf": {
+			"description": "ID of the shelf which books to list.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "ListBooksRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_ListBooks_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"books": {
+			"items": {
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"additionalProperties": false,
+				"description": "A book resource.",
+				"properties": {
+					"author": {
+						"description": "An author of the book.",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"createdBy": {
+						"description": "The user who added the book.",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"id": {
+						"description": "A unique book id.",
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"quotes": {
+						"description": "Quotes from the book.",
+						"items": {
+							"type": "string"
+						},
+						"type": [
+							"array",
+							"null"
+						]
+					},
+					"shelfId": {
+						"type": [
+							"string",
+							"null"
+						]
+					},
+					"title": {
+						"description": "A book title.",
+						"type": [
+							"string",
+							"null"
+						]
+					}
+				},
+				"title": "Book",
+				"type": "object"
+			},
+			"type": [
+				"array",
+				"null"
+			]
+		}
+	},
+	"title": "ListBooksResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_ListBooks_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(ListBooksRequest)
@@ -546,21 +1180,286 @@ func _BookstoreService_ListBooks_MCPGW_Handler(srv any, ctx context.Context, dec
 }
 
 func _BookstoreService_ListBooks_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
+	return mcpgw_v1.Decode(ctx, input, out)
+}
 
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
+var _BookstoreService_ExportBooks_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for ExportBooks method.",
+	"properties": {
+		"shelf": {
+			"description": "ID of the shelf which books to export.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "ExportBooksRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_ExportBooks_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"messages": {
+			"items": {
+				"additionalProperties": false,
+				"description": "Response message for ExportBooks method, sent once per book.",
+				"properties": {
+					"book": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"additionalProperties": false,
+						"description": "A book resource.",
+						"properties": {
+							"author": {
+								"description": "An author of the book.",
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"createdBy": {
+								"description": "The user who added the book.",
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"id": {
+								"description": "A unique book id.",
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"quotes": {
+								"description": "Quotes from the book.",
+								"items": {
+									"type": "string"
+								},
+								"type": [
+									"array",
+									"null"
+								]
+							},
+							"shelfId": {
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"title": {
+								"description": "A book title.",
+								"type": [
+									"string",
+									"null"
+								]
+							}
+						},
+						"title": "Book",
+						"type": [
+							"object",
+							"null"
+						]
+					}
+				},
+				"title": "ExportBooksResponse",
+				"type": "object"
+			},
+			"type": "array"
+		}
+	},
+	"required": [
+		"messages"
+	],
+	"type": "object"
+}`)
+
+func _BookstoreService_ExportBooks_MCPGW_Handler(srv any, stream grpc.ServerStream) error {
+	in := new(ExportBooksRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(BookstoreServiceServer).ExportBooks(in, &grpc.GenericServerStream[ExportBooksRequest, ExportBooksResponse]{ServerStream: stream})
+}
 
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+func _BookstoreService_ExportBooks_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_DeleteBook_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*DeleteBookRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&DeleteBookRequest{}).ProtoReflect().Descriptor())
+var _BookstoreService_ImportBooks_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"messages": {
+			"items": {
+				"additionalProperties": false,
+				"description": "Request message for ImportBooks method, sent once per book.",
+				"properties": {
+					"book": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"additionalProperties": false,
+						"description": "The book to add.",
+						"properties": {
+							"author": {
+								"description": "An author of the book.",
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"id": {
+								"description": "A unique book id.",
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"quotes": {
+								"description": "Quotes from the book.",
+								"items": {
+									"type": "string"
+								},
+								"type": [
+									"array",
+									"null"
+								]
+							},
+							"shelfId": {
+								"type": [
+									"string",
+									"null"
+								]
+							},
+							"title": {
+								"description": "A book title.",
+								"type": [
+									"string",
+									"null"
+								]
+							}
+						},
+						"title": "Book",
+						"type": [
+							"object",
+							"null"
+						]
+					},
+					"shelf": {
+						"description": "The ID of the shelf on which to add the book.",
+						"type": [
+							"string",
+							"null"
+						]
+					}
+				},
+				"title": "ImportBooksRequest",
+				"type": "object"
+			},
+			"type": "array"
+		}
+	},
+	"required": [
+		"messages"
+	],
+	"type": "object"
+}`)
+
+var _BookstoreService_ImportBooks_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Response message for ImportBooks method.",
+	"properties": {
+		"count": {
+			"description": "The number of books imported.",
+			"type": [
+				"integer",
+				"null"
+			]
+		}
+	},
+	"title": "ImportBooksResponse",
+	"type": "object"
+}`)
+
+func _BookstoreService_ImportBooks_MCPGW_Handler(srv any, stream grpc.ServerStream) error {
+	return srv.(BookstoreServiceServer).ImportBooks(&grpc.GenericServerStream[ImportBooksRequest, ImportBooksResponse]{ServerStream: stream})
 }
 
+func _BookstoreService_ImportBooks_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
+	return mcpgw_v1.Decode(ctx, input, out)
+}
+
+var _BookstoreService_DeleteBook_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for DeleteBook method.",
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "The book resource to delete.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "DeleteBookRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_DeleteBook_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "DeleteBookResponse",
+	"type": "object"
+}`)
+
 func _BookstoreService_DeleteBook_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(DeleteBookRequest)
 	if err := dec(in); err != nil {
@@ -585,20 +1484,139 @@ func _BookstoreService_DeleteBook_MCPGW_Handler(srv any, ctx context.Context, de
 }
 
 func _BookstoreService_DeleteBook_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
-
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
-	}
-
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	return mcpgw_v1.Decode(ctx, input, out)
 }
 
-func _BookstoreService_UpdateBook_MCPGW_InputSchema() map[string]any {
-	return mcpgw_schema.MustGenerateSchema(((*UpdateBookRequest)(nil)).ProtoReflect().Descriptor())
-	// return mcpgw_schema.MustGenerateSchema((&UpdateBookRequest{}).ProtoReflect().Descriptor())
-}
+var _BookstoreService_UpdateBook_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"description": "Request message for UpdateBook method",
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book resource to update on the shelf.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		},
+		"shelf": {
+			"description": "The ID of the shelf from which to retrieve a book.",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "UpdateBookRequest",
+	"type": "object"
+}`)
+
+var _BookstoreService_UpdateBook_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"book": {
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"additionalProperties": false,
+			"description": "A book resource.",
+			"properties": {
+				"author": {
+					"description": "An author of the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"createdBy": {
+					"description": "The user who added the book.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"id": {
+					"description": "A unique book id.",
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"quotes": {
+					"description": "Quotes from the book.",
+					"items": {
+						"type": "string"
+					},
+					"type": [
+						"array",
+						"null"
+					]
+				},
+				"shelfId": {
+					"type": [
+						"string",
+						"null"
+					]
+				},
+				"title": {
+					"description": "A book title.",
+					"type": [
+						"string",
+						"null"
+					]
+				}
+			},
+			"title": "Book",
+			"type": [
+				"object",
+				"null"
+			]
+		}
+	},
+	"title": "UpdateBookResponse",
+	"type": "object"
+}`)
 
 func _BookstoreService_UpdateBook_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
 	in := new(UpdateBookRequest)
@@ -624,12 +1642,91 @@ func _BookstoreService_UpdateBook_MCPGW_Handler(srv any, ctx context.Context, de
 }
 
 func _BookstoreService_UpdateBook_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
-	var err error
-	_ = err
+	return mcpgw_v1.Decode(ctx, input, out)
+}
+
+func RegisterMCPAdminServiceServer(s mcpgw_v1.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&mcpgw_desc_AdminServiceServer, srv)
+}
+
+var mcpgw_desc_AdminServiceServer = mcpgw_v1.ServiceDesc{
+	Name:        "bookstore.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []*mcpgw_v1.MethodDesc{
+		{
+			Method:           AdminService_GetStats_FullMethodName,
+			Name:             "admin_stats",
+			Handler:          _AdminService_GetStats_MCPGW_Handler,
+			Decoder:          _AdminService_GetStats_MCPGW_Decoder,
+			ArgumentDecoding: mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_LENIENT,
+			InputSchema:      _AdminService_GetStats_MCPGW_InputSchema,
+			OutputSchema:     _AdminService_GetStats_MCPGW_OutputSchema,
+			Title:            "Get Stats",
+			Description:      "Get counts of the shelves and books in the bookstore",
+			ReadOnlyHint:     true,
+			Destructive:      false,
+			Idempotent:       false,
+			OpenWorldHint:    false,
+		},
+	},
+}
 
-	if len(input.RawArguments()) > 0 {
-		return protojson.Unmarshal(input.RawArguments(), out)
+var _AdminService_GetStats_MCPGW_InputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {},
+	"title": "GetStatsRequest",
+	"type": "object"
+}`)
+
+var _AdminService_GetStats_MCPGW_OutputSchema = mcpgw_v1.StaticSchema(`{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"additionalProperties": false,
+	"properties": {
+		"books": {
+			"description": "The number of books.",
+			"pattern": "^-?[0-9]+$",
+			"type": [
+				"string",
+				"null"
+			]
+		},
+		"shelves": {
+			"description": "The number of shelves.",
+			"pattern": "^-?[0-9]+$",
+			"type": [
+				"string",
+				"null"
+			]
+		}
+	},
+	"title": "GetStatsResponse",
+	"type": "object"
+}`)
+
+func _AdminService_GetStats_MCPGW_Handler(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(AdminServiceServer).GetStats(ctx, req.(*GetStatsRequest))
 	}
 
-	return mcpgw_v1.UnmarshalFromMap(input.Arguments(), out)
+	rv, err := interceptor(ctx, in, info, handler)
+	if err != nil {
+		return nil, err
+	}
+	return rv.(proto.Message), nil
+}
+
+func _AdminService_GetStats_MCPGW_Decoder(ctx context.Context, input mcpgw_v1.DecoderInput, out proto.Message) error {
+	return mcpgw_v1.DecodeLenient(ctx, input, out)
 }
@@ -3,6 +3,9 @@ package v1_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -168,6 +171,51 @@ func TestInputSchemaValidation(t *testing.T) {
 		err = schema.Validate(invalidInput)
 		t.Logf("Validation result for incomplete input: %v", err)
 	})
+
+	// Schemas generated by the plugin carry descriptions from the proto comments
+	t.Run("CreateBook_SchemaDescriptions", func(t *testing.T) {
+		methodDesc := mockRegistrar.methodDescs["/bookstore.v1.BookstoreService/CreateBook"]
+		require.NotNil(t, methodDesc, "Method descriptor for CreateBook should be registered")
+
+		schemaMap := methodDesc.InputSchema()
+		assert.Equal(t, "Request message for CreateBook method.", schemaMap["description"])
+		properties, ok := schemaMap["properties"].(map[string]any)
+		require.True(t, ok, "Schema should have properties")
+		assert.Equal(t, "The ID of the shelf on which to create a book.", properties["shelf"].(map[string]any)["description"])
+		book := properties["book"].(map[string]any)
+		assert.Equal(t, "A book resource to create on the shelf.", book["description"])
+		assert.Equal(t, "A book title.", book["properties"].(map[string]any)["title"].(map[string]any)["description"])
+	})
+
+	// Test the GetGenre method's output schema against an actual response
+	t.Run("GetGenre_OutputSchema", func(t *testing.T) {
+		methodDesc := mockRegistrar.methodDescs["/bookstore.v1.BookstoreService/GetGenre"]
+		require.NotNil(t, methodDesc, "Method descriptor for GetGenre should be registered")
+		require.NotNil(t, methodDesc.OutputSchema, "OutputSchema function should be available")
+
+		schemaMap := methodDesc.OutputSchema()
+		assert.Equal(t, "object", schemaMap["type"])
+		properties, ok := schemaMap["properties"].(map[string]any)
+		require.True(t, ok, "Schema should have properties")
+		assert.Contains(t, properties, "genre")
+
+		schemaBytes, err := json.Marshal(schemaMap)
+		require.NoError(t, err, "Failed to marshal schema to JSON")
+		schema, err := loadAndCompileSchema(schemaBytes)
+		require.NoError(t, err, "Schema should be valid JSON Schema")
+
+		genre := &v1.Genre{}
+		genre.SetId(7)
+		genre.SetName("Poetry")
+		resp := &v1.GetGenreResponse{}
+		resp.SetGenre(genre)
+		result, err := mcpgw_v1.ResultEncoder{}.Encode(resp)
+		require.NoError(t, err)
+
+		var structured any
+		require.NoError(t, json.Unmarshal(result.StructuredContent, &structured))
+		assert.NoError(t, schema.Validate(structured), "Structured content should match the output schema")
+	})
 }
 
 // Helper function to load and compile a JSON schema
@@ -251,6 +299,26 @@ func (m *MockDecoderInput) RawArguments() json.RawMessage {
 	return m.rawArgs
 }
 
+// TestDecodeOneof tests that setting more than one member of a oneof is rejected
+func TestDecodeOneof(t *testing.T) {
+	ctx := context.Background()
+
+	out := &v1.GetAuthorResponse{}
+	err := mcpgw_v1.Decode(ctx, NewMockDecoderInput("test", map[string]any{"fiction": true}), out)
+	require.NoError(t, err)
+	assert.True(t, out.GetFiction())
+
+	// A null member is unset, as in protojson
+	out = &v1.GetAuthorResponse{}
+	err = mcpgw_v1.Decode(ctx, NewMockDecoderInput("test", map[string]any{"fiction": nil, "nonfiction": true}), out)
+	require.NoError(t, err)
+	assert.True(t, out.GetNonfiction())
+
+	err = mcpgw_v1.Decode(ctx, NewMockDecoderInput("test", map[string]any{"fiction": true, "nonfiction": false}), &v1.GetAuthorResponse{})
+	require.Error(t, err)
+	assert.Equal(t, "only one of the genre oneof members fiction, nonfiction may be set, got fiction, nonfiction", err.Error())
+}
+
 func TestMCPGWRegistration(t *testing.T) {
 	// Create a mock service registrar
 	mockRegistrar := NewMockServiceRegistrar()
@@ -512,3 +580,43 @@ func (s *mockBookstoreServer) CreateBook(ctx context.Context, req *v1.CreateBook
 	resp.SetBook(req.GetBook())
 	return resp, nil
 }
+
+func (s *mockBookstoreServer) GetBook(ctx context.Context, req *v1.GetBookRequest) (*v1.GetBookResponse, error) {
+	book := &v1.Book{}
+	book.SetId(strconv.FormatInt(req.GetBook(), 10))
+	book.SetShelfId(req.GetShelf())
+	resp := &v1.GetBookResponse{}
+	resp.SetBook(book)
+	return resp, nil
+}
+
+func (s *mockBookstoreServer) ExportBooks(req *v1.ExportBooksRequest, stream grpc.ServerStreamingServer[v1.ExportBooksResponse]) error {
+	for _, title := range []string{"Dune", "Emma"} {
+		book := &v1.Book{}
+		book.SetTitle(title)
+		book.SetShelfId(req.GetShelf())
+		resp := &v1.ExportBooksResponse{}
+		resp.SetBook(book)
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *mockBookstoreServer) ImportBooks(stream grpc.ClientStreamingServer[v1.ImportBooksRequest, v1.ImportBooksResponse]) error {
+	var count int32
+	for {
+		_, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		count++
+	}
+	resp := &v1.ImportBooksResponse{}
+	resp.SetCount(count)
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,260 @@
+package v1_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+)
+
+// postMCP sends a JSON-RPC message to the handler and returns the HTTP response
+func postMCP(t *testing.T, url string, sessionID string, body string, accept string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	if sessionID != "" {
+		req.Header.Set(mcpgw_v1.HeaderSessionID, sessionID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func decodeRPCResponse(t *testing.T, resp *http.Response) *rpcResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	rv := &rpcResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(rv))
+	return rv
+}
+
+func TestServerHTTP(t *testing.T) {
+	var (
+		gotMD   metadata.MD
+		gotPeer *peer.Peer
+	)
+	interceptor := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		gotMD, _ = metadata.FromIncomingContext(ctx)
+		gotPeer, _ = peer.FromContext(ctx)
+		return handler(ctx, req)
+	}
+	srv := mcpgw_v1.NewServer(mcpgw_v1.WithUnaryInterceptors(interceptor))
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+	ts := httptest.NewServer(srv.HTTPHandler(mcpgw_v1.WithForwardedHeaders("Authorization")))
+	defer ts.Close()
+
+	const accept = "application/json, text/event-stream"
+	resp := postMCP(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`, accept)
+	sessionID := resp.Header.Get(mcpgw_v1.HeaderSessionID)
+	require.NotEmpty(t, sessionID)
+	require.Nil(t, decodeRPCResponse(t, resp).Error)
+
+	t.Run("MissingSession", func(t *testing.T) {
+		resp := postMCP(t, ts.URL, "", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, accept)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Notification", func(t *testing.T) {
+		resp := postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`, accept)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("ToolsCall", func(t *testing.T) {
+		resp := postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"Fantasy"}}}`, accept)
+		rpcResp := decodeRPCResponse(t, resp)
+		require.Nil(t, rpcResp.Error)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(rpcResp.Result, result))
+		assert.False(t, result.IsError)
+
+		assert.Equal(t, []string{"Bearer secret"}, gotMD.Get("authorization"))
+		assert.Empty(t, gotMD.Get("cookie"))
+		assert.Equal(t, []string{sessionID}, gotMD.Get("mcp-session-id"))
+		assert.Equal(t, []string{"/bookstore.v1.BookstoreService/CreateGenre"}, gotMD.Get(":path"))
+		require.NotNil(t, gotPeer)
+		assert.NotNil(t, gotPeer.Addr)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		resp := postMCP(t, ts.URL, sessionID, `[{"jsonrpc":"2.0","id":4,"method":"ping"},{"jsonrpc":"2.0","id":5,"method":"tools/list"}]`, accept)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var batch []*rpcResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&batch))
+		require.Len(t, batch, 2)
+		assert.Equal(t, 4, batch[0].ID)
+		assert.Equal(t, 5, batch[1].ID)
+	})
+
+	t.Run("EventStream", func(t *testing.T) {
+		// A progress token asks for a streamed response when the client accepts one
+		resp := postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"BookstoreService_ExportBooks","arguments":{"shelf":"s1"},"_meta":{"progressToken":"p1"}}}`, accept)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		var events []string
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if v, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				events = append(events, v)
+			}
+		}
+		// Two progress notifications, then the response
+		require.Len(t, events, 3)
+		for _, event := range events[:2] {
+			assert.Contains(t, event, `"method":"notifications/progress"`)
+		}
+		rpcResp := &rpcResponse{}
+		require.NoError(t, json.Unmarshal([]byte(events[2]), rpcResp))
+		assert.Equal(t, 6, rpcResp.ID)
+		assert.Nil(t, rpcResp.Error)
+	})
+
+	t.Run("GetStream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set(mcpgw_v1.HeaderSessionID, sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("DeleteSession", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodDelete, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set(mcpgw_v1.HeaderSessionID, sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp = postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":7,"method":"ping"}`, accept)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestServerHTTPStateless(t *testing.T) {
+	ts := httptest.NewServer(newBookstoreMCPServer().HTTPHandler(mcpgw_v1.WithStatelessHTTP()))
+	defer ts.Close()
+
+	resp := postMCP(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, "application/json")
+	assert.Empty(t, resp.Header.Get(mcpgw_v1.HeaderSessionID))
+	assert.Nil(t, decodeRPCResponse(t, resp).Error)
+
+	getResp, err := http.Get(ts.URL)
+	require.NoError(t, err)
+	_ = getResp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, getResp.StatusCode)
+}
+
+func TestServerHTTPOrigin(t *testing.T) {
+	const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`
+	post := func(t *testing.T, handler http.Handler, origin string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(initialize))
+		req.Header.Set("Content-Type", "application/json")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	srv := newBookstoreMCPServer()
+
+	t.Run("Default", func(t *testing.T) {
+		handler := srv.HTTPHandler()
+		assert.Equal(t, http.StatusOK, post(t, handler, ""))
+		assert.Equal(t, http.StatusForbidden, post(t, handler, "https://example.com"))
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		handler := srv.HTTPHandler(mcpgw_v1.WithAllowedOrigins("https://example.com"))
+		assert.Equal(t, http.StatusOK, post(t, handler, "https://example.com"))
+		assert.Equal(t, http.StatusForbidden, post(t, handler, "https://evil.example"))
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		handler := srv.HTTPHandler(mcpgw_v1.WithAllowedOrigins("*"))
+		assert.Equal(t, http.StatusOK, post(t, handler, "https://evil.example"))
+	})
+}
+
+func TestServerHTTPSessions(t *testing.T) {
+	const accept = "application/json, text/event-stream"
+	initialize := func(t *testing.T, url string) string {
+		t.Helper()
+		resp := postMCP(t, url, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`, accept)
+		require.Nil(t, decodeRPCResponse(t, resp).Error)
+		return resp.Header.Get(mcpgw_v1.HeaderSessionID)
+	}
+
+	t.Run("FailedInitialize", func(t *testing.T) {
+		ts := httptest.NewServer(newBookstoreMCPServer().HTTPHandler(mcpgw_v1.WithMaxSessions(1)))
+		defer ts.Close()
+
+		resp := postMCP(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":"v1"}`, accept)
+		assert.Empty(t, resp.Header.Get(mcpgw_v1.HeaderSessionID))
+		require.NotNil(t, decodeRPCResponse(t, resp).Error)
+		// The failed initialize took no room
+		assert.NotEmpty(t, initialize(t, ts.URL))
+	})
+
+	t.Run("MaxSessions", func(t *testing.T) {
+		ts := httptest.NewServer(newBookstoreMCPServer().HTTPHandler(mcpgw_v1.WithMaxSessions(1)))
+		defer ts.Close()
+
+		sessionID := initialize(t, ts.URL)
+		resp := postMCP(t, ts.URL, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`, accept)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		req, err := http.NewRequest(http.MethodDelete, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set(mcpgw_v1.HeaderSessionID, sessionID)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.NotEmpty(t, initialize(t, ts.URL))
+	})
+
+	t.Run("IdleTimeout", func(t *testing.T) {
+		ts := httptest.NewServer(newBookstoreMCPServer().HTTPHandler(
+			mcpgw_v1.WithSessionIdleTimeout(50*time.Millisecond),
+			mcpgw_v1.WithMaxSessions(1),
+		))
+		defer ts.Close()
+
+		sessionID := initialize(t, ts.URL)
+		resp := postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":2,"method":"ping"}`, accept)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		time.Sleep(100 * time.Millisecond)
+		resp = postMCP(t, ts.URL, sessionID, `{"jsonrpc":"2.0","id":3,"method":"ping"}`, accept)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		// The expired session makes room for another
+		assert.NotEmpty(t, initialize(t, ts.URL))
+	})
+}
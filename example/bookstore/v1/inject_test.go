@@ -0,0 +1,160 @@
+package v1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+)
+
+func TestDecodeServerPopulated(t *testing.T) {
+	args := map[string]any{
+		"shelf":    "s1",
+		"tenantId": "t1",
+		"book":     map[string]any{"title": "Dune", "createdBy": "me"},
+	}
+	want := "/book/createdBy: the field is set by the server; /tenantId: the field is set by the server"
+
+	err := mcpgw_v1.UnmarshalFromMap(args, &v1.CreateBookRequest{})
+	require.Error(t, err)
+	assert.Equal(t, want, err.Error())
+
+	// protojson accepts the raw arguments, Decode doesn't
+	input := NewMockDecoderInput("bookstore.v1.BookstoreService.CreateBook", args)
+	require.NotEmpty(t, input.RawArguments())
+	err = mcpgw_v1.Decode(context.Background(), input, &v1.CreateBookRequest{})
+	require.Error(t, err)
+	assert.Equal(t, want, err.Error())
+
+	input = NewMockDecoderInput("bookstore.v1.BookstoreService.CreateBook", map[string]any{"shelf": "s1", "book": map[string]any{"title": "Dune"}})
+	req := &v1.CreateBookRequest{}
+	require.NoError(t, mcpgw_v1.Decode(context.Background(), input, req))
+	assert.Equal(t, "Dune", req.GetBook().GetTitle())
+}
+
+type (
+	tokenKey  struct{}
+	tenantKey struct{}
+)
+
+// authenticate stands for an interceptor that authenticates the caller, and
+// passes its tenant on in the context.
+func authenticate(ctx context.Context) context.Context {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantKey{}, strings.TrimPrefix(token, "token-"))
+}
+
+// authenticatedStream is the stream a stream interceptor passes on with the
+// authenticated context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestServerFieldInjector(t *testing.T) {
+	var creators []string
+	srv := mcpgw_v1.NewServer(
+		mcpgw_v1.WithFieldInjector("bookstore.v1.CreateBookRequest.tenant_id", func(ctx context.Context, _ protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+			tenant, ok := ctx.Value(tenantKey{}).(string)
+			if !ok {
+				return protoreflect.Value{}, status.Error(codes.Unauthenticated, "no tenant")
+			}
+			return protoreflect.ValueOfString(tenant), nil
+		}),
+		mcpgw_v1.WithFieldInjector("bookstore.v1.Book.created_by", func(ctx context.Context, _ protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			creators = append(creators, "alice@"+tenant)
+			return protoreflect.ValueOfString("alice@" + tenant), nil
+		}),
+		mcpgw_v1.WithUnaryInterceptors(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(authenticate(ctx), req)
+		}),
+		mcpgw_v1.WithStreamInterceptors(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &authenticatedStream{ServerStream: ss, ctx: authenticate(ss.Context())})
+		}),
+	)
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+
+	t.Run("Injected", func(t *testing.T) {
+		// The transport's context is the parent of tool calls, and injectors
+		// see what interceptors add to it
+		ctx := context.WithValue(context.Background(), tokenKey{}, "token-t1")
+		out := &bytes.Buffer{}
+		in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateBook","arguments":{"shelf":"s1","book":{"title":"Dune"}}}}`)
+		require.NoError(t, srv.Serve(ctx, in, out))
+		resp := &rpcResponse{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), resp))
+		require.Nil(t, resp.Error)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(resp.Result, result))
+		assert.JSONEq(t, `{"book":{"title":"Dune","createdBy":"alice@t1"}}`, string(result.StructuredContent))
+	})
+
+	t.Run("ClientStreaming", func(t *testing.T) {
+		creators = nil
+		ctx := context.WithValue(context.Background(), tokenKey{}, "token-t2")
+		out := &bytes.Buffer{}
+		in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_ImportBooks","arguments":{"messages":[{"shelf":"s1","book":{"title":"Dune"}},{"shelf":"s2","book":{"title":"Emma"}}]}}}`)
+		require.NoError(t, srv.Serve(ctx, in, out))
+		resp := &rpcResponse{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), resp))
+		require.Nil(t, resp.Error)
+		assert.Equal(t, []string{"alice@t2", "alice@t2"}, creators)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		responses := serveLines(t, srv,
+			`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateBook","arguments":{"shelf":"s1","tenantId":"t2"}}}`,
+		)
+		assert.Equal(t, []string{"/tenantId: the field is set by the server"}, fieldViolations(t, responses[1]))
+	})
+
+	t.Run("InjectorError", func(t *testing.T) {
+		responses := serveLines(t, srv,
+			`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateBook","arguments":{"shelf":"s1"}}}`,
+		)
+		require.Nil(t, responses[1].Error)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, result))
+		assert.True(t, result.IsError)
+		assert.JSONEq(t, `{"error":{"code":"Unauthenticated","httpStatus":401,"message":"no tenant"}}`, string(result.StructuredContent))
+	})
+
+	t.Run("InputSchema", func(t *testing.T) {
+		responses := serveLines(t, srv, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		list := &struct {
+			Tools []*mcpgw_v1.Tool `json:"tools"`
+		}{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, list))
+		for _, tool := range list.Tools {
+			if tool.Name != "BookstoreService_CreateBook" {
+				continue
+			}
+			input, err := json.Marshal(tool.InputSchema)
+			require.NoError(t, err)
+			assert.NotContains(t, string(input), "tenantId")
+			assert.NotContains(t, string(input), "createdBy")
+			output, err := json.Marshal(tool.OutputSchema)
+			require.NoError(t, err)
+			assert.Contains(t, string(output), "createdBy")
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+)
+
+func TestCoerceArguments(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		prototype proto.Message
+		args      string
+		want      string
+		repairs   []string
+	}{
+		{
+			name:      "valid arguments are kept",
+			prototype: &v1.GetBookRequest{},
+			args:      `{"shelf": "s", "book": "5", "includeAuthor": true, "pageSize": 10}`,
+			want:      `{"shelf": "s", "book": "5", "includeAuthor": true, "pageSize": 10}`,
+		},
+		{
+			name:      "scalars",
+			prototype: &v1.GetBookRequest{},
+			args:      `{"shelf": 7, "book": " +5 ", "includeAuthor": "TRUE", "pageSize": "10 "}`,
+			want:      `{"shelf": "7", "book": "5", "includeAuthor": true, "pageSize": 10}`,
+			repairs: []string{
+				`/book: converted " +5 " to "5"`,
+				`/includeAuthor: converted "TRUE" to true`,
+				`/pageSize: converted "10 " to 10`,
+				`/shelf: converted 7 to "7"`,
+			},
+		},
+		{
+			name:      "valid values take the form of the schema",
+			prototype: &v1.GetBookRequest{},
+			args:      `{"book": 5, "page_size": "1e1"}`,
+			want:      `{"book": "5", "pageSize": 10}`,
+		},
+		{
+			name:      "values that can't be repaired are left to decoding",
+			prototype: &v1.GetBookRequest{},
+			args:      `{"book": "five", "includeAuthor": "yes", "pageSize": 1.5, "shelf": {}}`,
+			want:      `{"book": "five", "includeAuthor": "yes", "pageSize": 1.5, "shelf": {}}`,
+		},
+		{
+			name:      "unknown fields",
+			prototype: &v1.CreateBookRequest{},
+			args:      `{"shelf": "s", "color": "red", "book": {"title": "Dune", "pages": 412}}`,
+			want:      `{"shelf": "s", "book": {"title": "Dune"}}`,
+			repairs: []string{
+				`/book/pages: discarded the unknown field`,
+				`/color: discarded the unknown field`,
+			},
+		},
+		{
+			name:      "server populated fields",
+			prototype: &v1.CreateBookRequest{},
+			args:      `{"shelf": "s", "tenantId": "t", "book": {"title": "Dune", "created_by": "me"}}`,
+			want:      `{"shelf": "s", "book": {"title": "Dune"}}`,
+			repairs: []string{
+				`/book/created_by: discarded the field set by the server`,
+				`/tenantId: discarded the field set by the server`,
+			},
+		},
+		{
+			name:      "enums",
+			prototype: &v1.RepeatedRules{},
+			args:      `{"channels": ["email", "Contact Channel Phone", "3", 1, "CONTACT_CHANNEL_EMAIL", "fax", "7"]}`,
+			want:      `{"channels": ["CONTACT_CHANNEL_EMAIL", "CONTACT_CHANNEL_PHONE", "CONTACT_CHANNEL_POST", "CONTACT_CHANNEL_EMAIL", "CONTACT_CHANNEL_EMAIL", "fax", "7"]}`,
+			repairs: []string{
+				`/channels/0: converted "email" to "CONTACT_CHANNEL_EMAIL"`,
+				`/channels/1: converted "Contact Channel Phone" to "CONTACT_CHANNEL_PHONE"`,
+				`/channels/2: converted "3" to "CONTACT_CHANNEL_POST"`,
+			},
+		},
+		{
+			name:      "single values for repeated fields",
+			prototype: &v1.RepeatedRules{},
+			args:      `{"strings": "a", "int32s": " 1", "flags": null, "blobs": []}`,
+			want:      `{"strings": ["a"], "int32s": [1], "flags": null, "blobs": []}`,
+			repairs: []string{
+				`/int32s: wrapped the value in a list`,
+				`/int32s/0: converted " 1" to 1`,
+				`/strings: wrapped the value in a list`,
+			},
+		},
+		{
+			name:      "maps",
+			prototype: &v1.ShelfInventory{},
+			args:      `{"available": {"a": "false"}, "notes": {"true": 1}, "channels": {"1": "post"}}`,
+			want:      `{"available": {"a": false}, "notes": {"true": "1"}, "channels": {"1": "CONTACT_CHANNEL_POST"}}`,
+			repairs: []string{
+				`/available/a: converted "false" to false`,
+				`/channels/1: converted "post" to "CONTACT_CHANNEL_POST"`,
+				`/notes/true: converted 1 to "1"`,
+			},
+		},
+		{
+			name:      "well known types",
+			prototype: &v1.KnownTypes{},
+			args:      `{"boolValue": "true", "counts": 3, "struct": {"a": "true"}, "value": "1", "nullValue": "x"}`,
+			want:      `{"boolValue": true, "counts": ["3"], "struct": {"a": "true"}, "value": "1", "nullValue": "x"}`,
+			repairs: []string{
+				`/boolValue: converted "true" to true`,
+				`/counts: wrapped the value in a list`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args := map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(tc.args), &args))
+			got, repairs := mcpgw_v1.CoerceArguments(args, tc.prototype.ProtoReflect().Descriptor())
+
+			data, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(data))
+			var descriptions []string
+			for _, r := range repairs {
+				descriptions = append(descriptions, r.String())
+			}
+			assert.Equal(t, tc.repairs, descriptions)
+			// The arguments are copied
+			data, err = json.Marshal(args)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.args, string(data))
+		})
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	input := NewMockDecoderInput("bookstore.v1.BookstoreService.GetBook", map[string]any{
+		"shelf": 1,
+		"book":  "9007199254740993",
+		"extra": true,
+	})
+	req := &v1.GetBookRequest{}
+	require.NoError(t, mcpgw_v1.DecodeLenient(context.Background(), input, req))
+	assert.Equal(t, "1", req.GetShelf())
+	assert.Equal(t, int64(9007199254740993), req.GetBook())
+
+	// Strict decoding rejects the same arguments
+	assert.Error(t, mcpgw_v1.Decode(context.Background(), input, &v1.GetBookRequest{}))
+
+	// Repairs don't hide arguments that are still invalid
+	input = NewMockDecoderInput("bookstore.v1.BookstoreService.GetBook", map[string]any{"book": "five"})
+	assert.Error(t, mcpgw_v1.DecodeLenient(context.Background(), input, &v1.GetBookRequest{}))
+}
+
+func TestServerLenientArguments(t *testing.T) {
+	srv := newBookstoreMCPServer()
+	v1.RegisterMCPAdminServiceServer(srv, &mockAdminServer{})
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_GetBook","arguments":{"shelf":"s1","book":9007199254740993,"includeAuthor":"true","pageSize":"10","extra":1}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_GetBook","arguments":{"shelf":"s1","book":"5"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"BookstoreService_GetBook","arguments":{"book":"five"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"admin_stats","arguments":{"verbose":true}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"x","extra":1}}}`,
+	)
+
+	t.Run("Repaired", func(t *testing.T) {
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, result))
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.JSONEq(t, `{"book":{"id":"9007199254740993","shelfId":"s1"}}`, result.Content[0].Text)
+		assert.JSONEq(t, `{"book":{"id":"9007199254740993","shelfId":"s1"}}`, string(result.StructuredContent))
+		assert.Equal(t, "warning: the arguments were repaired before the call:\n"+
+			"/extra: discarded the unknown field\n"+
+			`/includeAuthor: converted "true" to true`, result.Content[1].Text)
+	})
+
+	t.Run("NothingToRepair", func(t *testing.T) {
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[2].Result, result))
+		assert.Len(t, result.Content, 1)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		require.NotNil(t, responses[3].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[3].Error.Code)
+	})
+
+	t.Run("ServiceOption", func(t *testing.T) {
+		// GetStats is not implemented, yet the repairs are reported with the error
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[4].Result, result))
+		assert.True(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.Equal(t, "warning: the arguments were repaired before the call:\n/verbose: discarded the unknown field", result.Content[1].Text)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		require.NotNil(t, responses[5].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[5].Error.Code)
+	})
+}
@@ -0,0 +1,410 @@
+package v1_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+	mcpgw_schema "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1/schema"
+)
+
+// rpcResponse is a decoded JSON-RPC response read back from the server
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	} `json:"error"`
+}
+
+// serveLines runs the stdio transport over the given requests and returns the responses keyed by id
+func serveLines(t *testing.T, srv *mcpgw_v1.Server, lines ...string) map[int]*rpcResponse {
+	t.Helper()
+	out := &bytes.Buffer{}
+	err := srv.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), out)
+	require.NoError(t, err)
+
+	rv := map[int]*rpcResponse{}
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		resp := &rpcResponse{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), resp), "response should be valid JSON: %s", scanner.Text())
+		rv[resp.ID] = resp
+	}
+	return rv
+}
+
+func newBookstoreMCPServer() *mcpgw_v1.Server {
+	srv := mcpgw_v1.NewServer(mcpgw_v1.WithImplementation("bookstore", "1.0.0"))
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+	return srv
+}
+
+func TestServerStdio(t *testing.T) {
+	srv := newBookstoreMCPServer()
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"Fantasy"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"does_not_exist","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"unknownField":1}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"BookstoreService_ListGenres","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"resources/list"}`,
+	)
+	// The notification must not produce a response
+	assert.Len(t, responses, 7)
+
+	t.Run("Initialize", func(t *testing.T) {
+		result := map[string]any{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+		assert.Equal(t, "2025-03-26", result["protocolVersion"])
+		assert.Equal(t, map[string]any{"name": "bookstore", "version": "1.0.0"}, result["serverInfo"])
+		assert.Contains(t, result["capabilities"], "tools")
+	})
+
+	t.Run("ToolsList", func(t *testing.T) {
+		result := struct {
+			Tools []*mcpgw_v1.Tool `json:"tools"`
+		}{}
+		require.NoError(t, json.Unmarshal(responses[2].Result, &result))
+		require.Len(t, result.Tools, 14)
+
+		tool := result.Tools[0]
+		assert.Equal(t, "BookstoreService_ListShelves", tool.Name)
+		assert.Equal(t, "List Shelves", tool.Title)
+		assert.Equal(t, "List all shelves in the bookstore", tool.Description)
+		assert.Equal(t, "object", tool.InputSchema["type"])
+		assert.True(t, tool.Annotations.ReadOnlyHint)
+		assert.True(t, tool.Annotations.IdempotentHint)
+		assert.False(t, tool.Annotations.DestructiveHint)
+		assert.Equal(t, "object", tool.OutputSchema["type"])
+		assert.Contains(t, tool.OutputSchema["properties"], "shelves")
+	})
+
+	t.Run("ToolsCall", func(t *testing.T) {
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[3].Result, result))
+		assert.False(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "text", result.Content[0].Type)
+		assert.JSONEq(t, `{"genre":{"id":"42","name":"Fantasy"}}`, result.Content[0].Text)
+		assert.JSONEq(t, `{"genre":{"id":"42","name":"Fantasy"}}`, string(result.StructuredContent))
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		require.NotNil(t, responses[4].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[4].Error.Code)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		require.NotNil(t, responses[5].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[5].Error.Code)
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		// ListGenres is not implemented by the mock server
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[6].Result, result))
+		assert.True(t, result.IsError)
+		assert.Equal(t, "Unimplemented: method ListGenres not implemented", result.Content[0].Text)
+		assert.JSONEq(t, `{"error":{"code":"Unimplemented","httpStatus":501,"message":"method ListGenres not implemented"}}`, string(result.StructuredContent))
+	})
+
+	t.Run("MethodNotFound", func(t *testing.T) {
+		require.NotNil(t, responses[7].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCMethodNotFound, responses[7].Error.Code)
+	})
+}
+
+func TestServerMarshalOptions(t *testing.T) {
+	srv := mcpgw_v1.NewServer(
+		mcpgw_v1.WithMarshalOptions(protojson.MarshalOptions{EmitUnpopulated: true}),
+		mcpgw_v1.WithMethodMarshalOptions(v1.BookstoreService_CreateBook_FullMethodName, protojson.MarshalOptions{UseProtoNames: true}),
+	)
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_CreateBook","arguments":{"shelf":"1","book":{"title":"Dune","shelfId":"1"}}}}`,
+	)
+
+	genre := &mcpgw_v1.CallToolResult{}
+	require.NoError(t, json.Unmarshal(responses[1].Result, genre))
+	structured := map[string]map[string]any{}
+	require.NoError(t, json.Unmarshal(genre.StructuredContent, &structured))
+	// EmitUnpopulated applies to every method without its own options
+	assert.Equal(t, "", structured["genre"]["name"])
+
+	book := &mcpgw_v1.CallToolResult{}
+	require.NoError(t, json.Unmarshal(responses[2].Result, book))
+	structured = map[string]map[string]any{}
+	require.NoError(t, json.Unmarshal(book.StructuredContent, &structured))
+	assert.Equal(t, "1", structured["book"]["shelf_id"])
+	assert.NotContains(t, structured["book"], "author")
+}
+
+func TestServerErrorDetails(t *testing.T) {
+	interceptor := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		st, err := status.New(codes.InvalidArgument, "invalid genre").WithDetails(
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "must not be empty"},
+			}},
+			&errdetails.ErrorInfo{Reason: "GENRE_INVALID", Domain: "bookstore.example.com", Metadata: map[string]string{"genre": "x"}},
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(5 * time.Second)},
+			&errdetails.Help{Links: []*errdetails.Help_Link{{Description: "genres", Url: "https://example.com/genres"}}},
+		)
+		require.NoError(t, err)
+		return nil, st.Err()
+	}
+	srv := mcpgw_v1.NewServer(mcpgw_v1.WithUnaryInterceptors(interceptor))
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"x"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":1}}}`,
+	)
+
+	// The handler's status becomes a tool error rather than a JSON-RPC error
+	require.Nil(t, responses[1].Error)
+	result := &mcpgw_v1.CallToolResult{}
+	require.NoError(t, json.Unmarshal(responses[1].Result, result))
+	assert.True(t, result.IsError)
+	assert.Equal(t, strings.Join([]string{
+		"InvalidArgument: invalid genre",
+		"invalid field name: must not be empty",
+		"reason: GENRE_INVALID (domain: bookstore.example.com)",
+		"genre: x",
+		"retry after 5s",
+		"see genres: https://example.com/genres",
+	}, "\n"), result.Content[0].Text)
+
+	structured := struct {
+		Error *mcpgw_v1.ToolError `json:"error"`
+	}{}
+	require.NoError(t, json.Unmarshal(result.StructuredContent, &structured))
+	assert.Equal(t, "InvalidArgument", structured.Error.Code)
+	assert.Equal(t, 400, structured.Error.HTTPStatus)
+	require.Len(t, structured.Error.Details, 4)
+	assert.JSONEq(t, `{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"name","description":"must not be empty"}]}`, string(structured.Error.Details[0]))
+	assert.JSONEq(t, `{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"5s"}`, string(structured.Error.Details[2]))
+
+	// Arguments that can't be decoded never reach the handler
+	require.NotNil(t, responses[2].Error)
+	assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[2].Error.Code)
+}
+
+// serveMessages runs the stdio transport over the given requests and returns every message written back
+func serveMessages(t *testing.T, srv *mcpgw_v1.Server, lines ...string) []map[string]any {
+	t.Helper()
+	out := &bytes.Buffer{}
+	require.NoError(t, srv.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), out))
+
+	var rv []map[string]any
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		msg := map[string]any{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		rv = append(rv, msg)
+	}
+	return rv
+}
+
+func TestServerStreaming(t *testing.T) {
+	t.Run("AllMessages", func(t *testing.T) {
+		var streamed bool
+		interceptor := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			streamed = info.IsServerStream && info.FullMethod == v1.BookstoreService_ExportBooks_FullMethodName
+			return handler(srv, ss)
+		}
+		srv := mcpgw_v1.NewServer(mcpgw_v1.WithStreamInterceptors(interceptor))
+		v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+
+		msgs := serveMessages(t, srv,
+			`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_ExportBooks","arguments":{"shelf":"s1"},"_meta":{"progressToken":"export"}}}`,
+		)
+		assert.True(t, streamed, "stream interceptor should see the call")
+		require.Len(t, msgs, 3)
+
+		// Every streamed message is reported as progress before the result
+		for i, msg := range msgs[:2] {
+			assert.Equal(t, "notifications/progress", msg["method"])
+			params := msg["params"].(map[string]any)
+			assert.Equal(t, "export", params["progressToken"])
+			assert.EqualValues(t, i+1, params["progress"])
+			assert.Contains(t, params["message"], "shelfId")
+		}
+
+		data, err := json.Marshal(msgs[2]["result"])
+		require.NoError(t, err)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(data, result))
+		assert.False(t, result.IsError)
+		assert.JSONEq(t, `{"messages":[{"book":{"title":"Dune","shelfId":"s1"}},{"book":{"title":"Emma","shelfId":"s1"}}]}`, string(result.StructuredContent))
+	})
+
+	t.Run("LastMessage", func(t *testing.T) {
+		registrar := NewMockServiceRegistrar()
+		v1.RegisterMCPBookstoreServiceServer(registrar, &mockBookstoreServer{})
+		md := *registrar.methodDescs[v1.BookstoreService_ExportBooks_FullMethodName]
+		md.StreamResult = mcpgw_v1.StreamResult_STREAM_RESULT_LAST
+		// The generated schema describes every message, which the result no longer holds
+		md.OutputSchema = func() map[string]any {
+			return mcpgw_schema.MustGenerateSchema((&v1.ExportBooksResponse{}).ProtoReflect().Descriptor())
+		}
+
+		srv := mcpgw_v1.NewServer()
+		srv.RegisterService(&mcpgw_v1.ServiceDesc{
+			Name:        "bookstore.v1.BookstoreService",
+			HandlerType: (*v1.BookstoreServiceServer)(nil),
+			Methods:     []*mcpgw_v1.MethodDesc{&md},
+		}, &mockBookstoreServer{})
+
+		responses := serveLines(t, srv,
+			`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_ExportBooks","arguments":{"shelf":"s1"}}}`,
+		)
+
+		tools := struct {
+			Tools []*mcpgw_v1.Tool `json:"tools"`
+		}{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, &tools))
+		require.Len(t, tools.Tools, 1)
+		assert.Contains(t, tools.Tools[0].OutputSchema["properties"], "book")
+
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[2].Result, result))
+		assert.JSONEq(t, `{"book":{"title":"Emma","shelfId":"s1"}}`, string(result.StructuredContent))
+	})
+}
+
+func TestServerClientStreaming(t *testing.T) {
+	srv := newBookstoreMCPServer()
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_ImportBooks","arguments":{"messages":[{"shelf":"s1","book":{"title":"Dune"}},{"shelf":"s2","book":{"title":"Emma"}}]}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"BookstoreService_ImportBooks","arguments":{"shelf":"s1"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"BookstoreService_ImportBooks","arguments":{"messages":[{"shelf":"s1"},{"shelf":1}]}}}`,
+	)
+
+	t.Run("InputSchema", func(t *testing.T) {
+		result := struct {
+			Tools []*mcpgw_v1.Tool `json:"tools"`
+		}{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+		var tool *mcpgw_v1.Tool
+		for _, tt := range result.Tools {
+			if tt.Name == "BookstoreService_ImportBooks" {
+				tool = tt
+			}
+		}
+		require.NotNil(t, tool)
+		assert.Equal(t, []any{"messages"}, tool.InputSchema["required"])
+		messages := tool.InputSchema["properties"].(map[string]any)["messages"].(map[string]any)
+		assert.Equal(t, "array", messages["type"])
+		assert.Contains(t, messages["items"].(map[string]any)["properties"], "book")
+		// The response of a client-streaming method is returned as is
+		assert.Contains(t, tool.OutputSchema["properties"], "count")
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[2].Result, result))
+		assert.False(t, result.IsError)
+		assert.JSONEq(t, `{"count":2}`, string(result.StructuredContent))
+	})
+
+	t.Run("MissingMessages", func(t *testing.T) {
+		require.NotNil(t, responses[3].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[3].Error.Code)
+	})
+
+	t.Run("InvalidMessage", func(t *testing.T) {
+		require.NotNil(t, responses[4].Error)
+		assert.Equal(t, mcpgw_v1.JSONRPCInvalidParams, responses[4].Error.Code)
+		assert.Contains(t, responses[4].Error.Message, "/messages/1/shelf: expected string, got 1")
+		assert.JSONEq(t, `{"error": {
+			"code": "InvalidArgument",
+			"httpStatus": 400,
+			"message": "invalid arguments",
+			"details": [{
+				"@type": "type.googleapis.com/google.rpc.BadRequest",
+				"fieldViolations": [{"field": "/messages/1/shelf", "description": "expected string, got 1"}]
+			}]
+		}}`, string(responses[4].Error.Data))
+	})
+}
+
+// mockAdminServer is a mock implementation of AdminServiceServer
+type mockAdminServer struct {
+	v1.UnimplementedAdminServiceServer
+}
+
+func TestServerMethodExposure(t *testing.T) {
+	registrar := NewMockServiceRegistrar()
+	v1.RegisterMCPAdminServiceServer(registrar, &mockAdminServer{})
+
+	// Unannotated methods are skipped by METHOD_EXPOSURE_ANNOTATED, and excluded methods always are
+	assert.Contains(t, registrar.methodDescs, v1.AdminService_GetStats_FullMethodName)
+	assert.NotContains(t, registrar.methodDescs, v1.AdminService_PurgeCache_FullMethodName)
+	assert.NotContains(t, registrar.methodDescs, v1.AdminService_RebuildIndex_FullMethodName)
+	assert.Len(t, registrar.methodDescs, 1)
+}
+
+func TestServerToolNames(t *testing.T) {
+	srv := newBookstoreMCPServer()
+	v1.RegisterMCPAdminServiceServer(srv, &mockAdminServer{})
+
+	responses := serveLines(t, srv, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	result := struct {
+		Tools []*mcpgw_v1.Tool `json:"tools"`
+	}{}
+	require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Contains(t, names, "BookstoreService_GetBook")
+	// AdminService sets a tool_prefix, and GetStats an explicit name
+	assert.Contains(t, names, "admin_stats")
+
+	// A service whose tools clash with registered ones is rejected
+	assert.PanicsWithValue(t,
+		`mcpgw: Server.RegisterService found duplicate tool name "admin_stats" for /other.v1.OtherService/Stats, already used by /bookstore.v1.AdminService/GetStats`,
+		func() {
+			srv.RegisterService(&mcpgw_v1.ServiceDesc{
+				Name:        "other.v1.OtherService",
+				HandlerType: (*v1.AdminServiceServer)(nil),
+				Methods: []*mcpgw_v1.MethodDesc{
+					{Method: "/other.v1.OtherService/Stats", Name: "admin_stats"},
+				},
+			}, &mockAdminServer{})
+		})
+}
+
+func TestServerDuplicateRegistration(t *testing.T) {
+	srv := newBookstoreMCPServer()
+	assert.Panics(t, func() {
+		v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+	})
+}
@@ -0,0 +1,473 @@
+package v1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+)
+
+// unmarshalViaJSON is what UnmarshalFromMap must match: protojson reading the
+// JSON encoding of the map.
+func unmarshalViaJSON(args map[string]any, out proto.Message) error {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return protojson.Unmarshal(data, out)
+}
+
+// assertSameAsProtojson decodes args with UnmarshalFromMap and with protojson,
+// and checks that both fail, or both succeed with the same message.
+func assertSameAsProtojson(t *testing.T, prototype proto.Message, args map[string]any) {
+	t.Helper()
+	want := prototype.ProtoReflect().New().Interface()
+	wantErr := unmarshalViaJSON(args, want)
+	got := prototype.ProtoReflect().New().Interface()
+	gotErr := mcpgw_v1.UnmarshalFromMap(args, got)
+
+	if wantErr != nil {
+		assert.Error(t, gotErr, "protojson failed with: %v", wantErr)
+		return
+	}
+	require.NoError(t, gotErr)
+	// Compare the wire form, as proto.Equal never finds NaN equal
+	opts := proto.MarshalOptions{Deterministic: true}
+	wantData, err := opts.Marshal(want)
+	require.NoError(t, err)
+	gotData, err := opts.Marshal(got)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(wantData, gotData), "got %v, want %v", got, want)
+}
+
+// decodeArgs decodes JSON arguments like the server does, and with numbers as
+// json.Number.
+func decodeArgs(t testing.TB, data string) (map[string]any, map[string]any) {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(data), &args); err != nil {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(data)))
+	dec.UseNumber()
+	var numbers map[string]any
+	require.NoError(t, dec.Decode(&numbers))
+	return args, numbers
+}
+
+var unmarshalCases = []struct {
+	name      string
+	prototype proto.Message
+	args      []string
+}{
+	{"names", &v1.Shelf{}, []string{
+		`{"id": "s1", "theme": "poetry"}`,
+		`{"search[decoded]": "a", "search_encoded": "b"}`,
+		`{"search_decoded": "a"}`,
+		`{"theme": "a", "unknown": 1}`,
+		`{"theme": null}`,
+		`{"theme": 1}`,
+		`{}`,
+	}},
+	{"duplicates", &v1.Author{}, []string{
+		`{"firstName": "a", "first_name": "b"}`,
+		`{"lname": "a", "last_name": "b"}`,
+		`{"lastName": "a"}`,
+		`{"firstName": null, "first_name": "b"}`,
+	}},
+	{"enums", &v1.Author{}, []string{
+		`{"gender": "GENDER_FEMALE"}`,
+		`{"gender": 1}`,
+		`{"gender": 7}`,
+		`{"gender": 1.0}`,
+		`{"gender": 1.5}`,
+		`{"gender": "1"}`,
+		`{"gender": "GENDER_OTHER"}`,
+		`{"gender": 2147483648}`,
+		`{"gender": true}`,
+	}},
+	{"nested", &v1.CreateBookRequest{}, []string{
+		`{"shelf": "s", "book": {"id": "b", "quotes": ["x", "y"]}}`,
+		`{"book": {}}`,
+		`{"book": null}`,
+		`{"book": []}`,
+		`{"book": {"quotes": "x"}}`,
+		`{"book": {"quotes": [null]}}`,
+		`{"book": {"quotes": null}}`,
+	}},
+	{"oneofs", &v1.GetAuthorResponse{}, []string{
+		`{"fiction": true}`,
+		`{"fiction": true, "nonfiction": false}`,
+		`{"fiction": null, "nonfiction": true}`,
+		`{"fiction": false, "author": {"firstName": "a"}}`,
+	}},
+	{"any", &v1.Author{}, []string{
+		`{"books": [{"@type": "type.googleapis.com/bookstore.v1.Book", "id": "b1", "title": "t"}]}`,
+		`{"books": [{"@type": "type.googleapis.com/bookstore.v1.Book", "nope": 1}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Duration", "value": "1.5s"}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Duration"}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Duration", "value": "1s", "extra": 1}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Empty"}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Struct", "value": {"a": [1, "b", null]}}]}`,
+		`{"books": [{"@type": "type.googleapis.com/google.protobuf.Any", "value": {"@type": "type.googleapis.com/bookstore.v1.Shelf", "id": "s"}}]}`,
+		`{"books": [{}]}`,
+		`{"books": [{"id": "b1"}]}`,
+		`{"books": [{"@type": 1}]}`,
+		`{"books": [{"@type": ""}]}`,
+		`{"books": [{"@type": "type.googleapis.com/bookstore.v1.Missing"}]}`,
+		`{"books": [null]}`,
+		`{"books": "b"}`,
+	}},
+	{"timestamps", &v1.Author{}, []string{
+		`{"createdAt": "2024-05-01T12:00:00Z"}`,
+		`{"createdAt": "2024-05-01T12:00:00.123456789+02:00"}`,
+		`{"createdAt": "2024-05-01T12:00:00.1234567891Z"}`,
+		`{"createdAt": "2024-05-01"}`,
+		`{"createdAt": "0000-01-01T00:00:00Z"}`,
+		`{"createdAt": 1714564800}`,
+	}},
+	{"field masks", &v1.ListShelvesResponse{}, []string{
+		`{"mask": "id,theme,searchDecoded"}`,
+		`{"mask": " id , theme "}`,
+		`{"mask": ""}`,
+		`{"mask": "search_decoded"}`,
+		`{"mask": "a..b"}`,
+		`{"mask": ["id"]}`,
+	}},
+	{"maps", &v1.ShelfInventory{}, []string{
+		`{"booksByShelf": {"1": 3, "-2": "4", "+3": 5}, "available": {"a": true}, "notes": {"true": "x", "false": "y"}, "channels": {"7": "CONTACT_CHANNEL_EMAIL"}}`,
+		`{"booksByShelf": {"1": 3, "01": 4}}`,
+		`{"booksByShelf": {"x": 3}}`,
+		`{"booksByShelf": {"1": null}}`,
+		`{"booksByShelf": {"9223372036854775808": 1}}`,
+		`{"notes": {"yes": "x"}}`,
+		`{"channels": {"-1": 1}}`,
+		`{"channels": {"1": "CONTACT_CHANNEL_FAX"}}`,
+		`{"available": []}`,
+	}},
+	{"scalars", &v1.RepeatedRules{}, []string{
+		`{"strings": ["a", "ü"], "blobs": ["AQID", "AQI", "-_8=", "_-8"], "flags": [true, false]}`,
+		`{"int32s": [1, -1, 2147483647, -2147483648, "7", "1e3", 1e2, 1.0, -0]}`,
+		`{"int32s": [2147483648]}`,
+		`{"int32s": [1.5]}`,
+		`{"int32s": [" 1"]}`,
+		`{"int32s": ["1 2"]}`,
+		`{"int32s": ["1x"]}`,
+		`{"int32s": ["0x10"]}`,
+		`{"int32s": ["-"]}`,
+		`{"int32s": [""]}`,
+		`{"int32s": [true]}`,
+		`{"int64s": ["9223372036854775807", -9223372036854775808, 9007199254740993, 1e18, 9.2e18, 1e19, 1e21, 12345678901234567890e-10]}`,
+		`{"int64s": [9223372036854775807]}`,
+		`{"int64s": ["9223372036854775808"]}`,
+		`{"int64s": ["1.5e1"]}`,
+		`{"int64s": ["15e-1"]}`,
+		`{"int64s": ["150e-1"]}`,
+		`{"int64s": ["0e+!"]}`,
+		`{"int64s": ["0e"]}`,
+		`{"int64s": ["0e!", "0E-0", "-0.0e99999999999"]}`,
+		`{"sint64s": [-5, "-6"], "sfixed64s": ["7"], "sint32s": [3], "sfixed32s": [-3]}`,
+		`{"uint32s": [4294967295, "4294967295"]}`,
+		`{"uint32s": [4294967296]}`,
+		`{"uint32s": [-1]}`,
+		`{"uint32s": [-0]}`,
+		`{"uint64s": ["18446744073709551615", 1.8446744073709552e19, 1e19]}`,
+		`{"fixed32s": [1], "fixed64s": ["2"]}`,
+		`{"floats": [1.5, "2.5", "NaN", "Infinity", "-Infinity", 3.4028235e38, 1e-46, 0.1]}`,
+		`{"floats": [3.5e38]}`,
+		`{"floats": ["nan"]}`,
+		`{"doubles": [1.7976931348623157e308, "1e309", 5e-324, -0.0, 0.30000000000000004]}`,
+		`{"doubles": ["1e309"]}`,
+		`{"doubles": ["Infinity", "1e2", " 1"]}`,
+		`{"channels": ["CONTACT_CHANNEL_EMAIL", 2, 99]}`,
+		`{"blobs": ["!!"]}`,
+		`{"blobs": [1]}`,
+		`{"flags": ["true"]}`,
+		`{"strings": [1]}`,
+		`{"strings": null}`,
+	}},
+	{"known types", &v1.KnownTypes{}, []string{
+		`{"duration": "1.5s", "timestamp": "1970-01-01T00:00:00Z", "fieldMask": "a.b,cD", "empty": {}}`,
+		`{"duration": "-0.000000001s"}`,
+		`{"duration": ".5s"}`,
+		`{"duration": "1.s"}`,
+		`{"duration": "1.0000000001s"}`,
+		`{"duration": "315576000001s"}`,
+		`{"duration": "1"}`,
+		`{"duration": 1}`,
+		`{"empty": {"a": 1}}`,
+		`{"empty": []}`,
+		`{"struct": {"a": 1, "b": [true, null, "x", {"c": 2.5}], "d": null}}`,
+		`{"struct": []}`,
+		`{"struct": null}`,
+		`{"value": null}`,
+		`{"value": 1}`,
+		`{"value": "NaN"}`,
+		`{"value": [1, [2]]}`,
+		`{"value": {"a": {}}}`,
+		`{"value": 1e400}`,
+		`{"listValue": [1, "a", null, [], {}]}`,
+		`{"listValue": {}}`,
+		`{"listValue": null}`,
+		`{"nullValue": null}`,
+		`{"nullValue": "NULL_VALUE"}`,
+		`{"nullValue": 0}`,
+		`{"nullValue": 1}`,
+		`{"boolValue": true, "int32Value": 1, "int64Value": "2", "uint32Value": 3, "uint64Value": "4", "floatValue": 1.5, "doubleValue": "NaN", "stringValue": "s", "bytesValue": "AQID"}`,
+		`{"boolValue": null}`,
+		`{"boolValue": "true"}`,
+		`{"int32Value": {"value": 1}}`,
+		`{"values": [null, 1, "a", true, {}, []]}`,
+		`{"labels": {"a": null, "b": 1, "c": {"d": [null]}}}`,
+		`{"counts": [1, "2"]}`,
+		`{"counts": [null]}`,
+		`{"nulls": [null, "NULL_VALUE", 0]}`,
+		`{"nulls": ["x"]}`,
+		`{"noteValue": null}`,
+		`{"noteValue": null, "noteText": "x"}`,
+		`{"noteValue": 1, "note_text": "x"}`,
+		`{"note_value": "a", "noteText": null}`,
+		`{"noteText": "x", "note_text": "x"}`,
+		`{"ratio": 0.1, "offset": "-9223372036854775808", "gender": "GENDER_MALE"}`,
+		`{"ratio": 1e39}`,
+		`{"ratio": -1e-50}`,
+	}},
+}
+
+// TestUnmarshalFromMapMatchesProtojson compares UnmarshalFromMap with
+// protojson.Unmarshal on arguments decoded from JSON, with numbers as float64
+// and as json.Number.
+func TestUnmarshalFromMapMatchesProtojson(t *testing.T) {
+	for _, tc := range unmarshalCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, data := range tc.args {
+				args, numbers := decodeArgs(t, data)
+				t.Run(data, func(t *testing.T) {
+					assertSameAsProtojson(t, tc.prototype, args)
+					assertSameAsProtojson(t, tc.prototype, numbers)
+				})
+			}
+		})
+	}
+}
+
+// TestUnmarshalFromMapGoValues compares UnmarshalFromMap with protojson on maps
+// built in Go, holding values encoding/json never decodes into.
+func TestUnmarshalFromMapGoValues(t *testing.T) {
+	type title struct {
+		Title string `json:"title"`
+	}
+	for name, args := range map[string]map[string]any{
+		"ints":           {"int32s": []any{int8(1), int16(2), int32(3), int64(4), 5, uint(6), uint8(7)}},
+		"uint64":         {"uint64s": []any{uint64(math.MaxUint64), uint32(1)}, "int64s": []any{int64(math.MinInt64)}},
+		"float32":        {"floats": []any{float32(0.1), float32(1e-7)}, "doubles": []any{float32(0.1)}},
+		"large float":    {"int64s": []any{float64(1 << 60), 1e20}, "uint64s": []any{float64(1 << 63)}},
+		"NaN":            {"doubles": []any{math.NaN()}},
+		"infinity":       {"floats": []any{math.Inf(1)}},
+		"typed slice":    {"strings": []string{"a", "b"}, "int32s": []int{1, 2}},
+		"nil slice":      {"strings": []any(nil)},
+		"nil map":        {"strings": map[string]any(nil)},
+		"byte slice":     {"blobs": [][]byte{{1, 2, 3}}},
+		"json.Number":    {"int32s": []any{json.Number("12"), json.Number("")}, "doubles": []any{json.Number("1e3")}},
+		"invalid number": {"int32s": []any{json.Number("1x")}},
+		"invalid UTF-8":  {"strings": []any{"a\xffb\xfe"}},
+		"raw message":    {"strings": json.RawMessage(`["a"]`)},
+		"channel":        {"strings": []any{make(chan int)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertSameAsProtojson(t, &v1.RepeatedRules{}, args)
+		})
+	}
+
+	assertSameAsProtojson(t, &v1.CreateBookRequest{}, map[string]any{"book": title{Title: "t"}})
+	assertSameAsProtojson(t, &v1.CreateBookRequest{}, map[string]any{"book": map[string]string{"title": "t"}})
+	assertSameAsProtojson(t, &v1.CreateBookRequest{}, map[string]any{"book": (*title)(nil)})
+	assertSameAsProtojson(t, &v1.CreateBookRequest{}, nil)
+
+	deep := map[string]any{}
+	for range 10001 {
+		deep = map[string]any{"a": deep}
+	}
+	assertSameAsProtojson(t, &v1.KnownTypes{}, map[string]any{"struct": deep})
+}
+
+// FuzzUnmarshalFromMap compares UnmarshalFromMap with protojson on arbitrary
+// JSON arguments of a message with every kind of field.
+func FuzzUnmarshalFromMap(f *testing.F) {
+	for _, tc := range unmarshalCases {
+		for _, data := range tc.args {
+			f.Add(data)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		args, numbers := decodeArgs(t, data)
+		if args == nil {
+			return
+		}
+		for _, prototype := range []proto.Message{&v1.KnownTypes{}, &v1.RepeatedRules{}, &v1.ShelfInventory{}, &v1.SearchBooksRequest{}} {
+			assertSameAsProtojson(t, prototype, args)
+			assertSameAsProtojson(t, prototype, numbers)
+		}
+	})
+}
+
+// benchmarkArgs are the arguments of a typical tool call.
+const benchmarkArgs = `{
+	"shelf": "shelf-1",
+	"book": {
+		"id": "book-1",
+		"author": "Frank Herbert",
+		"title": "Dune",
+		"quotes": ["Fear is the mind-killer.", "The spice must flow."],
+		"shelfId": "shelf-1"
+	}
+}`
+
+func BenchmarkUnmarshalFromMap(b *testing.B) {
+	args, _ := decodeArgs(b, benchmarkArgs)
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := mcpgw_v1.UnmarshalFromMap(args, &v1.CreateBookRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUnmarshalViaJSON is the JSON round trip UnmarshalFromMap replaces.
+func BenchmarkUnmarshalViaJSON(b *testing.B) {
+	args, _ := decodeArgs(b, benchmarkArgs)
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := unmarshalViaJSON(args, &v1.CreateBookRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestUnmarshalFromMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		prototype proto.Message
+		args      string
+		want      []string
+	}{
+		{
+			name:      "every problem is reported",
+			prototype: &v1.CreateBookRequest{},
+			args:      `{"shelf": 1, "book": {"quotes": ["a", "b", 3], "title": [], "pages": 1}, "extra": {}}`,
+			want: []string{
+				`/book/pages: unknown field`,
+				`/book/quotes/2: expected string, got 3`,
+				`/book/title: expected string, got an array`,
+				`/extra: unknown field`,
+				`/shelf: expected string, got 1`,
+			},
+		},
+		{
+			name:      "names and well known types",
+			prototype: &v1.Author{},
+			args:      `{"firstName": "a", "first_name": "b", "gender": "X", "createdAt": "yesterday", "books": [{"@type": 1}, {}, {"@type": "type.googleapis.com/google.protobuf.Duration", "value": 5}]}`,
+			want: []string{
+				`/books/0/@type: expected type URL string, got 1`,
+				`/books/2/value: expected duration string such as "1.5s", got 5`,
+				`/createdAt: expected RFC 3339 timestamp string such as "2006-01-02T15:04:05Z", got "yesterday"`,
+				`/firstName: duplicate field, also given as "first_name"`,
+				`/gender: expected one of GENDER_UNSPECIFIED, GENDER_MALE, GENDER_FEMALE, got "X"`,
+			},
+		},
+		{
+			name:      "maps",
+			prototype: &v1.ShelfInventory{},
+			args:      `{"booksByShelf": {"x": 1}, "available": {"a/b~c": 1}, "notes": {"true": "t", "1": "f"}}`,
+			want: []string{
+				`/available/a~1b~0c: expected bool, got 1`,
+				`/booksByShelf/x: invalid int64 map key`,
+				`/notes/1: invalid bool map key`,
+			},
+		},
+		{
+			name:      "indexes are ordered as numbers",
+			prototype: &v1.RepeatedRules{},
+			args:      `{"int32s": [1, 2, 3, 4, 5, 6, 7, 8, 9, "a", "b"], "flags": [1]}`,
+			want: []string{
+				`/flags/0: expected bool, got 1`,
+				`/int32s/9: expected int32, got "a"`,
+				`/int32s/10: expected int32, got "b"`,
+			},
+		},
+		{
+			name:      "oneofs",
+			prototype: &v1.KnownTypes{},
+			args:      `{"noteValue": 1, "noteText": "x", "duration": "1h"}`,
+			want: []string{
+				`only one of the note oneof members noteValue, noteText may be set, got noteValue, noteText`,
+				`/duration: expected duration string such as "1.5s", got "1h"`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args, _ := decodeArgs(t, tc.args)
+			err := mcpgw_v1.UnmarshalFromMap(args, tc.prototype.ProtoReflect().New().Interface())
+			var errs mcpgw_v1.ArgumentErrors
+			require.ErrorAs(t, err, &errs)
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("expected type and value", func(t *testing.T) {
+		err := mcpgw_v1.UnmarshalFromMap(map[string]any{"book": map[string]any{"quotes": []any{"a", 3.0}}}, &v1.CreateBookRequest{})
+		var errs mcpgw_v1.ArgumentErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		assert.Equal(t, &mcpgw_v1.ArgumentError{
+			Pointer:     "/book/quotes/1",
+			Expected:    "string",
+			Value:       3.0,
+			Description: "expected string, got 3",
+		}, errs[0])
+	})
+}
+
+func TestDecodeArgumentErrors(t *testing.T) {
+	// Raw arguments are decoded by protojson, whose error is replaced
+	input := NewMockDecoderInput("bookstore.v1.BookstoreService.CreateBook", map[string]any{
+		"shelf": 1,
+		"book":  map[string]any{"quotes": []any{"a", true}},
+	})
+	require.NotEmpty(t, input.RawArguments())
+	err := mcpgw_v1.Decode(context.Background(), input, &v1.CreateBookRequest{})
+	require.Error(t, err)
+	assert.Equal(t, "/book/quotes/1: expected string, got true; /shelf: expected string, got 1", err.Error())
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, br.GetFieldViolations(), 2)
+	assert.Equal(t, "/book/quotes/1", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "expected string, got true", br.GetFieldViolations()[0].GetDescription())
+
+	// What only protojson rejects is still reported
+	input = &MockDecoderInput{rawArgs: json.RawMessage(`{"shelf": "a", "shelf": "b"}`)}
+	err = mcpgw_v1.Decode(context.Background(), input, &v1.CreateBookRequest{})
+	var errs mcpgw_v1.ArgumentErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Contains(t, err.Error(), "duplicate field")
+}
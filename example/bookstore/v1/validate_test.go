@@ -0,0 +1,209 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+	mcpgw_schema "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1/schema"
+)
+
+// registerRulesTools registers tools taking the messages whose rules apply to
+// nested values, which the bookstore services don't use as requests.
+func registerRulesTools(srv *mcpgw_v1.Server) {
+	srv.RegisterService(&mcpgw_v1.ServiceDesc{
+		Name: "bookstore.v1.Rules",
+		Methods: []*mcpgw_v1.MethodDesc{
+			echoMethod("Rules_Inventory", &v1.ShelfInventory{}),
+			echoMethod("Rules_Repeated", &v1.RepeatedRules{}),
+			echoMethod("Rules_KnownTypes", &v1.KnownTypes{}),
+		},
+	}, nil)
+}
+
+// echoMethod describes a method returning its request.
+func echoMethod(name string, prototype proto.Message) *mcpgw_v1.MethodDesc {
+	method := "/bookstore.v1.Rules/" + name
+	return &mcpgw_v1.MethodDesc{
+		Method: method,
+		Name:   name,
+		Handler: func(srv any, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error) {
+			in := prototype.ProtoReflect().New().Interface()
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return in, nil
+			}
+			rv, err := interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: method}, func(_ context.Context, req any) (any, error) {
+				return req, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rv.(proto.Message), nil
+		},
+		Decoder: mcpgw_v1.Decode,
+		InputSchema: func() map[string]any {
+			return mcpgw_schema.MustGenerateSchema(prototype.ProtoReflect().Descriptor())
+		},
+	}
+}
+
+// fieldViolations returns the BadRequest field violations in the data of an
+// invalid params error, as "field: description".
+func fieldViolations(t *testing.T, resp *rpcResponse) []string {
+	t.Helper()
+	require.NotNil(t, resp.Error, "expected an error, got %s", resp.Result)
+	require.Equal(t, mcpgw_v1.JSONRPCInvalidParams, resp.Error.Code)
+	data := &struct {
+		Error struct {
+			Details []struct {
+				FieldViolations []struct {
+					Field       string `json:"field"`
+					Description string `json:"description"`
+				} `json:"fieldViolations"`
+			} `json:"details"`
+		} `json:"error"`
+	}{}
+	require.NoError(t, json.Unmarshal(resp.Error.Data, data))
+	require.Len(t, data.Error.Details, 1)
+	var rv []string
+	for _, v := range data.Error.Details[0].FieldViolations {
+		rv = append(rv, v.Field+": "+v.Description)
+	}
+	return rv
+}
+
+func TestServerSchemaValidation(t *testing.T) {
+	srv := mcpgw_v1.NewServer(mcpgw_v1.WithSchemaValidation())
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+	registerRulesTools(srv)
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"Fantasy"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":""}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"BookstoreService_CreateBook","arguments":{"shelf":1,"book":{"quotes":["a",2]}}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"BookstoreService_GetBook","arguments":{"shelf":"s1","book":"5","includeAuthor":"true"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"BookstoreService_ImportBooks","arguments":{"messages":[{"shelf":"s1"},{"shelf":1}]}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"Fantasy"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"BookstoreService_GetBook","arguments":{"shelf":"s1","book":5,"pageSize":"10"}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"Rules_KnownTypes","arguments":{"offset":-5,"int32_value":3,"gender":1,"ratio":"NaN"}}}`,
+		`{"jsonrpc":"2.0","id":9,"method":"tools/call","params":{"name":"Rules_KnownTypes","arguments":{"offset":"five","int32_value":"three"}}}`,
+	)
+
+	t.Run("Valid", func(t *testing.T) {
+		require.Nil(t, responses[1].Error)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[1].Result, result))
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, []string{"/name: length must be >= 1, but got 0"}, fieldViolations(t, responses[2]))
+		assert.Equal(t, []string{
+			"/book/quotes/1: expected string, but got number",
+			"/shelf: expected string or null, but got number",
+		}, fieldViolations(t, responses[3]))
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		// The schema applies to the repaired arguments
+		require.Nil(t, responses[4].Error)
+		// and to valid ones in the form it describes, such as 64-bit integers
+		// given as numbers
+		require.Nil(t, responses[7].Error)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		// Valid arguments protojson accepts in other forms than the schema's
+		// pass: 64-bit integers as numbers, proto field names, enum values by
+		// number and NaN
+		require.Nil(t, responses[8].Error)
+		result := &mcpgw_v1.CallToolResult{}
+		require.NoError(t, json.Unmarshal(responses[8].Result, result))
+		assert.False(t, result.IsError)
+		assert.JSONEq(t, `{"offset":"-5","int32Value":3,"gender":"GENDER_MALE","ratio":"NaN"}`, string(result.StructuredContent))
+		// while invalid ones are reported, under the JSON name of their field
+		assert.Equal(t, []string{
+			"/int32Value: expected integer or null, but got string",
+			"/offset: does not match pattern '^-?[0-9]+$'",
+		}, fieldViolations(t, responses[9]))
+	})
+
+	t.Run("ClientStreaming", func(t *testing.T) {
+		assert.Equal(t, []string{"/messages/1/shelf: expected string or null, but got number"}, fieldViolations(t, responses[5]))
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		// The schema compiled for the first call serves the others
+		require.Nil(t, responses[6].Error)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		responses := serveLines(t, newBookstoreMCPServer(),
+			`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":""}}}`,
+		)
+		require.Nil(t, responses[1].Error)
+	})
+}
+
+func TestServerProtoValidation(t *testing.T) {
+	var intercepted atomic.Int32
+	srv := mcpgw_v1.NewServer(
+		mcpgw_v1.WithProtoValidation(nil),
+		mcpgw_v1.WithUnaryInterceptors(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			intercepted.Add(1)
+			// Requests are validated as interceptors leave them
+			if r, ok := req.(*v1.CreateGenreRequest); ok && r.GetName() == "default" {
+				r.SetName("")
+			}
+			return handler(ctx, req)
+		}),
+	)
+	v1.RegisterMCPBookstoreServiceServer(srv, &mockBookstoreServer{})
+	registerRulesTools(srv)
+
+	responses := serveLines(t, srv,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"Fantasy"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":""}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"Rules_Inventory","arguments":{"booksByShelf":{"0":5,"2":2000},"available":{"x":true},"channels":{"1":0}}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"Rules_Repeated","arguments":{"int32s":[1,11]}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"BookstoreService_CreateGenre","arguments":{"name":"default"}}}`,
+	)
+
+	t.Run("Valid", func(t *testing.T) {
+		require.Nil(t, responses[1].Error)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, []string{"/name: value length must be at least 1 characters"}, fieldViolations(t, responses[2]))
+	})
+
+	t.Run("AfterInterceptors", func(t *testing.T) {
+		assert.Equal(t, []string{"/name: value length must be at least 1 characters"}, fieldViolations(t, responses[5]))
+		// Invalid requests reach the interceptors too
+		assert.Equal(t, int32(5), intercepted.Load())
+	})
+
+	t.Run("MapKeysAndValues", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"/available/x: value does not match regex pattern `^[0-9]{13}$`",
+			"/booksByShelf/0: value must be greater than or equal to 1",
+			"/booksByShelf/2: value must be less than or equal to 1000",
+			"/channels/1: value must not be in list [0]",
+		}, fieldViolations(t, responses[3]))
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		assert.Contains(t, fieldViolations(t, responses[4]), "/int32s/1: value must be greater than or equal to 1 and less than or equal to 10")
+	})
+}
@@ -0,0 +1,305 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+)
+
+// CEL idioms of buf.validate rules that translate to JSON Schema keywords.
+// Other expressions are described in text.
+var (
+	celSize         = regexp.MustCompile(`^(?:this\.size\(\)|size\(this\))\s*(>=|>|<=|<|==|!=)\s*(\d+)$`)
+	celNotEmpty     = regexp.MustCompile(`^this\s*!=\s*(?:''|"")$`)
+	celCompare      = regexp.MustCompile(`^this\s*(>=|>|<=|<)\s*(-?\d+(?:\.\d+)?)$`)
+	celMatches      = regexp.MustCompile(`^this\.matches\((r?)('|")(.*)('|")\)$`)
+	celAffix        = regexp.MustCompile(`^this\.(startsWith|endsWith|contains)\((r?)('|")(.*)('|")\)$`)
+	celFormat       = regexp.MustCompile(`^this\.(isEmail|isHostname|isUri|isUriRef|isIpv4|isIpv6|isUuid)\(\)$`)
+	celFieldCompare = regexp.MustCompile(`^this\.(\w+)\s*(>=|>|<=|<|==|!=)\s*this\.(\w+)$`)
+)
+
+var celFormats = map[string]string{
+	"isEmail":    "email",
+	"isHostname": "hostname",
+	"isUri":      "uri",
+	"isUriRef":   "uri-reference",
+	"isIpv4":     "ipv4",
+	"isIpv6":     "ipv6",
+	"isUuid":     "uuid",
+}
+
+var celOperators = map[string]string{
+	">=": "greater than or equal to",
+	">":  "greater than",
+	"<=": "less than or equal to",
+	"<":  "less than",
+	"==": "equal to",
+	"!=": "different from",
+}
+
+// applyFieldCEL applies the (buf.validate.field).cel rules of a field to its
+// schema, combined through allOf with keywords already set. this refers to the
+// whole value of the field, so the rules of repeated and map fields constrain
+// the array or object.
+func applyFieldCEL(fd protoreflect.FieldDescriptor, schema map[string]any) {
+	applyRulesCEL(fieldShape(fd), fieldRules(fd), schema)
+}
+
+// celShape is the shape of the value this refers to in a CEL rule: a whole
+// field, or an item, key or value of one.
+type celShape struct {
+	kind   protoreflect.Kind
+	isList bool
+	isMap  bool
+}
+
+func fieldShape(fd protoreflect.FieldDescriptor) celShape {
+	return celShape{kind: fd.Kind(), isList: fd.IsList(), isMap: fd.IsMap()}
+}
+
+// elementShape is the shape of a single item, key or value of a field.
+func elementShape(fd protoreflect.FieldDescriptor) celShape {
+	return celShape{kind: fd.Kind()}
+}
+
+// applyRulesCEL applies the CEL rules of a field, or of its items, keys or
+// values, to the schema of the value of that shape.
+func applyRulesCEL(shape celShape, rules *validate.FieldRules, schema map[string]any) {
+	for _, rule := range rules.GetCel() {
+		keywords, ok := celFieldKeywords(shape, rule.GetExpression())
+		if ok && shape.isDecimal() {
+			// 64-bit integers are strings in JSON, which numeric keywords
+			// don't apply to, so their bounds are described
+			appendDescription(schema, constraintText(rule, "must be "+describeBounds(keywords)))
+			continue
+		}
+		if ok {
+			for k, v := range keywords {
+				addKeyword(schema, k, v)
+			}
+			continue
+		}
+		appendDescription(schema, constraintText(rule, ""))
+	}
+}
+
+// applyMessageCEL describes the (buf.validate.message).cel rules of a message.
+// Comparisons between fields are spelled out with the fields' JSON names.
+func applyMessageCEL(md protoreflect.MessageDescriptor, schema map[string]any) {
+	rules := messageRules(md)
+	for _, rule := range rules.GetCel() {
+		var text string
+		if m := celFieldCompare.FindStringSubmatch(strings.TrimSpace(rule.GetExpression())); m != nil {
+			left, right := md.Fields().ByName(protoreflect.Name(m[1])), md.Fields().ByName(protoreflect.Name(m[3]))
+			if left != nil && right != nil {
+				text = fmt.Sprintf("%s must be %s %s", left.JSONName(), celOperators[m[2]], right.JSONName())
+			}
+		}
+		appendDescription(schema, constraintText(rule, text))
+	}
+}
+
+func messageRules(md protoreflect.MessageDescriptor) *validate.MessageRules {
+	rules, ok := proto.GetExtension(md.Options(), validate.E_Message).(*validate.MessageRules)
+	if !ok {
+		return nil
+	}
+	return rules
+}
+
+// constraintText describes a rule by its message, the given text, or its expression.
+func constraintText(rule *validate.Rule, text string) string {
+	switch {
+	case rule.GetMessage() != "":
+		text = rule.GetMessage()
+	case text == "":
+		text = rule.GetExpression()
+	}
+	return "Constraint: " + text
+}
+
+// celFieldKeywords translates a field CEL expression into JSON Schema
+// keywords. Conjunctions translate when each of their terms does.
+func celFieldKeywords(shape celShape, expr string) (map[string]any, bool) {
+	terms := []string{expr}
+	if !strings.ContainsAny(expr, `'"`) {
+		terms = strings.Split(expr, "&&")
+	}
+	rv := map[string]any{}
+	for _, term := range terms {
+		keywords, ok := celTermKeywords(shape, strings.TrimSpace(term))
+		if !ok || hasAnyKey(rv, keywords) {
+			return nil, false
+		}
+		for k, v := range keywords {
+			rv[k] = v
+		}
+	}
+	return rv, true
+}
+
+func celTermKeywords(shape celShape, term string) (map[string]any, bool) {
+	isString := shape.kind == protoreflect.StringKind && !shape.isList && !shape.isMap
+
+	if m := celSize.FindStringSubmatch(term); m != nil {
+		n, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		var suffix string
+		switch {
+		case shape.isMap:
+			suffix = "Properties"
+		case shape.isList:
+			suffix = "Items"
+		case isString:
+			suffix = "Length"
+		default:
+			return nil, false
+		}
+		return sizeKeywords(suffix, m[1], n)
+	}
+
+	if celNotEmpty.MatchString(term) && isString {
+		return map[string]any{"minLength": uint64(1)}, true
+	}
+
+	if m := celCompare.FindStringSubmatch(term); m != nil && (shape.isNumeric() || shape.isDecimal()) {
+		var value any
+		if i, err := strconv.ParseInt(m[2], 10, 64); err == nil {
+			value = i
+		} else if f, err := strconv.ParseFloat(m[2], 64); err == nil {
+			value = f
+		} else {
+			return nil, false
+		}
+		keyword := map[string]string{
+			">=": "minimum",
+			">":  "exclusiveMinimum",
+			"<=": "maximum",
+			"<":  "exclusiveMaximum",
+		}[m[1]]
+		return map[string]any{keyword: value}, true
+	}
+
+	if !isString {
+		return nil, false
+	}
+
+	if m := celMatches.FindStringSubmatch(term); m != nil && m[2] == m[4] {
+		pattern, ok := celString(m[1] != "", m[2], m[3])
+		if !ok {
+			return nil, false
+		}
+		return map[string]any{"pattern": pattern}, true
+	}
+
+	if m := celAffix.FindStringSubmatch(term); m != nil && m[3] == m[5] {
+		value, ok := celString(m[2] != "", m[3], m[4])
+		if !ok {
+			return nil, false
+		}
+		pattern := regexp.QuoteMeta(value)
+		switch m[1] {
+		case "startsWith":
+			pattern = "^" + pattern
+		case "endsWith":
+			pattern += "$"
+		}
+		return map[string]any{"pattern": pattern}, true
+	}
+
+	if m := celFormat.FindStringSubmatch(term); m != nil {
+		return map[string]any{"format": celFormats[m[1]]}, true
+	}
+
+	return nil, false
+}
+
+// sizeKeywords returns the min/max keywords of a size comparison.
+func sizeKeywords(suffix string, op string, n uint64) (map[string]any, bool) {
+	switch op {
+	case ">=":
+		return map[string]any{"min" + suffix: n}, true
+	case ">":
+		return map[string]any{"min" + suffix: n + 1}, true
+	case "<=":
+		return map[string]any{"max" + suffix: n}, true
+	case "<":
+		if n == 0 {
+			return nil, false
+		}
+		return map[string]any{"max" + suffix: n - 1}, true
+	case "==":
+		return map[string]any{"min" + suffix: n, "max" + suffix: n}, true
+	case "!=":
+		if n == 0 {
+			return map[string]any{"min" + suffix: uint64(1)}, true
+		}
+	}
+	return nil, false
+}
+
+// celString returns the value of a CEL string literal.
+func celString(raw bool, quote string, body string) (string, bool) {
+	if raw {
+		return body, !strings.Contains(body, quote)
+	}
+	if quote == "'" {
+		body = strings.ReplaceAll(body, `\'`, `'`)
+		body = strings.ReplaceAll(body, `"`, `\"`)
+	}
+	s, err := strconv.Unquote(`"` + body + `"`)
+	return s, err == nil
+}
+
+// isNumeric reports whether the value is a JSON number.
+func (shape celShape) isNumeric() bool {
+	if shape.isList || shape.isMap {
+		return false
+	}
+	switch shape.kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	}
+	return false
+}
+
+// isDecimal reports whether the value is a 64-bit integer, which is a decimal
+// string in JSON.
+func (shape celShape) isDecimal() bool {
+	if shape.isList || shape.isMap {
+		return false
+	}
+	switch shape.kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+func hasAnyKey(schema map[string]any, keywords map[string]any) bool {
+	for k := range keywords {
+		if _, ok := schema[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appendDescription adds a line of text to the description of a schema.
+func appendDescription(schema map[string]any, text string) {
+	if desc, ok := schema["description"].(string); ok && desc != "" {
+		text = desc + "\n" + text
+	}
+	schema["description"] = text
+}
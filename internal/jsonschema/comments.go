@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Descriptors linked into a binary by protoc-gen-go carry no source info, so
+// generated code registers the comments of its messages and fields here.
+var registeredComments sync.Map
+
+// RegisterComments records the cleaned leading comments of descriptors, keyed
+// by their full name, to be used as schema descriptions.
+func RegisterComments(comments map[string]string) {
+	for name, comment := range comments {
+		registeredComments.Store(protoreflect.FullName(name), comment)
+	}
+}
+
+// descriptorComment returns the leading comment of d, read from its source
+// info when available and from the registered comments otherwise.
+func descriptorComment(d protoreflect.Descriptor) string {
+	if f := d.ParentFile(); f != nil {
+		if loc := f.SourceLocations().ByDescriptor(d); loc.LeadingComments != "" {
+			return CleanComment(loc.LeadingComments)
+		}
+	}
+	if v, ok := registeredComments.Load(d.FullName()); ok {
+		return v.(string)
+	}
+	return ""
+}
+
+// CleanComment turns a proto source comment into description text: the lines
+// of each paragraph are joined with spaces and paragraphs are separated by a
+// blank line.
+func CleanComment(comment string) string {
+	var paragraphs []string
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, " "))
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return strings.Join(paragraphs, "\n\n")
+}
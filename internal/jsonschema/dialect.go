@@ -0,0 +1,416 @@
+package jsonschema
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Dialect names a schema dialect accepted by a model provider. Schemas are
+// generated as draft 2020-12 and converted to the selected dialect, dropping
+// the keywords it doesn't support. Dropped constraints are still enforced
+// when the arguments are decoded and validated.
+type Dialect string
+
+const (
+	// DialectDraft202012 is JSON Schema draft 2020-12, the default.
+	DialectDraft202012 Dialect = "draft-2020-12"
+	// DialectDraft07 is JSON Schema draft-07, with "definitions" for "$defs".
+	DialectDraft07 Dialect = "draft-07"
+	// DialectOpenAPI30 is the OpenAPI 3.0 Schema Object, which marks null
+	// with "nullable" instead of type arrays.
+	DialectOpenAPI30 Dialect = "openapi-3.0"
+	// DialectOpenAIStrict is the subset accepted by OpenAI strict mode, where
+	// every property is required and optional ones accept null.
+	DialectOpenAIStrict Dialect = "openai-strict"
+	// DialectGemini is the OpenAPI 3.0 subset of Gemini function declarations.
+	DialectGemini Dialect = "gemini"
+)
+
+// Dialects lists the supported dialects.
+var Dialects = []Dialect{DialectDraft202012, DialectDraft07, DialectOpenAPI30, DialectOpenAIStrict, DialectGemini}
+
+// ParseDialect returns the dialect of a name.
+func ParseDialect(name string) (Dialect, error) {
+	d := Dialect(name)
+	if !slices.Contains(Dialects, d) {
+		names := make([]string, 0, len(Dialects))
+		for _, d := range Dialects {
+			names = append(names, string(d))
+		}
+		return "", fmt.Errorf("unknown schema dialect '%s': must be one of %s", name, strings.Join(names, ", "))
+	}
+	return d, nil
+}
+
+// WithDialect generates schemas in a dialect other than draft 2020-12.
+func WithDialect(d Dialect) Option {
+	return func(o *options) {
+		o.dialect = d
+	}
+}
+
+const draft07Schema = "http://json-schema.org/draft-07/schema#"
+
+// Keywords kept by the provider subsets.
+var (
+	openAIKeywords = []string{
+		"type", "title", "description", "enum", "const", "properties", "required",
+		"additionalProperties", "items", "anyOf", "$ref", "$defs", "pattern", "format",
+		"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+		"minItems", "maxItems",
+	}
+	openAIFormats = []string{
+		"date-time", "time", "date", "duration", "email", "hostname", "ipv4", "ipv6", "uuid",
+	}
+	geminiKeywords = []string{
+		"type", "format", "title", "description", "nullable", "enum", "properties",
+		"required", "items", "minItems", "maxItems", "minimum", "maximum", "minLength",
+		"maxLength", "pattern", "minProperties", "maxProperties", "anyOf",
+	}
+	geminiFormats = map[string][]string{
+		"string":  {"enum", "date-time"},
+		"integer": {"int32", "int64"},
+		"number":  {"float", "double"},
+	}
+)
+
+// convertDialect converts a draft 2020-12 schema to the dialect of the options.
+func convertDialect(schema map[string]any, o options) (map[string]any, error) {
+	switch o.dialect {
+	case "", DialectDraft202012:
+		return schema, nil
+	case DialectDraft07:
+		if err := walkSchema(schema, "#", toDraft07); err != nil {
+			return nil, err
+		}
+		schema["$schema"] = draft07Schema
+		return schema, nil
+	case DialectOpenAPI30:
+		if o.defs {
+			return nil, fmt.Errorf("the %s dialect doesn't support $defs", o.dialect)
+		}
+		if err := walkSchema(schema, "#", toOpenAPI30); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	case DialectOpenAIStrict:
+		if err := walkSchema(schema, "#", toOpenAIStrict); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	case DialectGemini:
+		if o.defs {
+			return nil, fmt.Errorf("the %s dialect doesn't support $defs", o.dialect)
+		}
+		if err := walkSchema(schema, "#", toOpenAPI30); err != nil {
+			return nil, err
+		}
+		if err := walkSchema(schema, "#", toGemini); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("unknown schema dialect '%s'", o.dialect)
+	}
+}
+
+// walkSchema calls fn on a schema and then on each of its subschemas, with
+// their JSON Pointer.
+func walkSchema(schema map[string]any, ptr string, fn func(schema map[string]any, ptr string) error) error {
+	if err := fn(schema, ptr); err != nil {
+		return err
+	}
+	for _, k := range []string{"properties", "$defs", "definitions"} {
+		subs, ok := schema[k].(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(subs)) {
+			if sub, ok := subs[name].(map[string]any); ok {
+				if err := walkSchema(sub, ptr+"/"+k+"/"+escapePointer(name), fn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, k := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := schema[k].(map[string]any); ok {
+			if err := walkSchema(sub, ptr+"/"+k, fn); err != nil {
+				return err
+			}
+		}
+	}
+	for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, _ := schema[k].([]map[string]any)
+		for i, sub := range subs {
+			if err := walkSchema(sub, fmt.Sprintf("%s/%s/%d", ptr, k, i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func toDraft07(schema map[string]any, _ string) error {
+	delete(schema, "$schema")
+	if defs, ok := schema["$defs"]; ok {
+		delete(schema, "$defs")
+		schema["definitions"] = defs
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		schema["$ref"] = strings.Replace(ref, "#/$defs/", "#/definitions/", 1)
+	}
+	return nil
+}
+
+func toOpenAPI30(schema map[string]any, _ string) error {
+	delete(schema, "$schema")
+
+	// Null is marked with nullable, instead of a type or an alternative
+	switch t := schema["type"].(type) {
+	case []string:
+		types := slices.DeleteFunc(slices.Clone(t), func(t string) bool { return t == "null" })
+		if len(types) < len(t) {
+			schema["nullable"] = true
+		}
+		switch len(types) {
+		case 0:
+			delete(schema, "type")
+		case 1:
+			schema["type"] = types[0]
+		default:
+			delete(schema, "type")
+			branches := make([]map[string]any, 0, len(types))
+			for _, t := range types {
+				branch := map[string]any{"type": t}
+				if t == "array" {
+					branch["items"] = map[string]any{}
+				}
+				branches = append(branches, branch)
+			}
+			if _, ok := schema["anyOf"]; ok {
+				addConstraint(schema, map[string]any{"anyOf": branches})
+			} else {
+				schema["anyOf"] = branches
+			}
+		}
+	case string:
+		if t == "null" {
+			delete(schema, "type")
+			schema["nullable"] = true
+		}
+	}
+	for _, k := range []string{"anyOf", "oneOf"} {
+		branches, ok := schema[k].([]map[string]any)
+		if !ok {
+			continue
+		}
+		branches = slices.DeleteFunc(slices.Clone(branches), isNull)
+		if len(branches) < len(schema[k].([]map[string]any)) {
+			schema["nullable"] = true
+		}
+		schema[k] = branches
+	}
+
+	if v, ok := schema["const"]; ok {
+		delete(schema, "const")
+		schema["enum"] = []any{v}
+	}
+
+	// Exclusive bounds are flags of minimum and maximum
+	for _, bound := range []string{"Minimum", "Maximum"} {
+		exclusive, ok := schema["exclusive"+bound]
+		if _, isFlag := exclusive.(bool); !ok || isFlag {
+			continue
+		}
+		delete(schema, "exclusive"+bound)
+		keyword := strings.ToLower(bound)
+		if _, ok := schema[keyword]; ok {
+			addConstraint(schema, map[string]any{keyword: exclusive, "exclusive" + bound: true})
+			continue
+		}
+		schema[keyword] = exclusive
+		schema["exclusive"+bound] = true
+	}
+
+	if _, ok := schema["contentEncoding"]; ok {
+		delete(schema, "contentEncoding")
+		if _, ok := schema["format"]; !ok {
+			schema["format"] = "byte"
+		}
+	}
+	// Map keys can't be constrained
+	delete(schema, "propertyNames")
+
+	if examples, ok := schema["examples"].([]any); ok {
+		delete(schema, "examples")
+		if len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+	}
+	return nil
+}
+
+func toOpenAIStrict(schema map[string]any, ptr string) error {
+	delete(schema, "$schema")
+	alternatives(schema)
+
+	if isObject(schema) {
+		properties, hasProperties := schema["properties"].(map[string]any)
+		if closed, _ := schema["additionalProperties"].(bool); !hasProperties && closed {
+			properties, hasProperties = map[string]any{}, true
+			schema["properties"] = properties
+		}
+		if !hasProperties || schema["additionalProperties"] != false {
+			return fmt.Errorf("the %s dialect doesn't support objects with arbitrary properties, at %s", DialectOpenAIStrict, ptr)
+		}
+
+		// Every property is required, and optional ones accept null
+		required, _ := schema["required"].([]string)
+		for _, name := range slices.Sorted(maps.Keys(properties)) {
+			if slices.Contains(required, name) {
+				continue
+			}
+			required = append(required, name)
+			if prop, ok := properties[name].(map[string]any); ok {
+				properties[name] = nullable(prop)
+			}
+		}
+		schema["required"] = required
+	}
+
+	if format, ok := schema["format"].(string); ok && !slices.Contains(openAIFormats, format) {
+		delete(schema, "format")
+	}
+	keepKeywords(schema, openAIKeywords)
+	return nil
+}
+
+func toGemini(schema map[string]any, _ string) error {
+	alternatives(schema)
+
+	t, _ := schema["type"].(string)
+	if format, ok := schema["format"].(string); ok && !slices.Contains(geminiFormats[t], format) {
+		delete(schema, "format")
+	}
+	if t != "string" {
+		delete(schema, "enum")
+	}
+	keepKeywords(schema, geminiKeywords)
+	return nil
+}
+
+// alternatives rewrites oneOf for the provider subsets, which don't support
+// it. Enum values listed as alternatives become an enum, with their
+// descriptions added to the schema's. Alternatives between typed values
+// become anyOf unless the schema has a type. Others, such as the oneof
+// branches of a message, are dropped along with allOf and not.
+func alternatives(schema map[string]any) {
+	branches, ok := schema["oneOf"].([]map[string]any)
+	delete(schema, "oneOf")
+	if !ok || enumAlternatives(schema, branches) || schema["type"] != nil || schema["anyOf"] != nil {
+		return
+	}
+	for _, b := range branches {
+		if b["type"] == nil && b["$ref"] == nil {
+			return
+		}
+	}
+	schema["anyOf"] = branches
+}
+
+// enumAlternatives lists alternatives that are values, with an optional
+// description, as an enum.
+func enumAlternatives(schema map[string]any, branches []map[string]any) bool {
+	var values []any
+	var descriptions []string
+	for _, b := range branches {
+		var branchValues []any
+		switch {
+		case isNull(b):
+			branchValues = []any{nil}
+		case b["const"] != nil:
+			branchValues = []any{b["const"]}
+		case b["enum"] != nil:
+			enum := reflect.ValueOf(b["enum"])
+			if enum.Kind() != reflect.Slice || enum.Len() == 0 {
+				return false
+			}
+			for i := range enum.Len() {
+				branchValues = append(branchValues, enum.Index(i).Interface())
+			}
+		default:
+			return false
+		}
+		for k := range b {
+			if k != "const" && k != "enum" && k != "description" && k != "type" {
+				return false
+			}
+		}
+		values = append(values, branchValues...)
+		if desc, ok := b["description"].(string); ok && desc != "" {
+			descriptions = append(descriptions, fmt.Sprintf("%v: %s", branchValues[0], desc))
+		}
+	}
+	schema["enum"] = values
+	for _, desc := range descriptions {
+		appendDescription(schema, desc)
+	}
+	return true
+}
+
+func isNull(schema map[string]any) bool {
+	return len(schema) == 1 && schema["type"] == "null"
+}
+
+func isObject(schema map[string]any) bool {
+	switch t := schema["type"].(type) {
+	case string:
+		return t == "object"
+	case []string:
+		return slices.Contains(t, "object")
+	}
+	return false
+}
+
+// nullable allows null as the value of a schema.
+func nullable(schema map[string]any) map[string]any {
+	if _, ok := schema["const"]; ok {
+		return map[string]any{"anyOf": []map[string]any{schema, {"type": "null"}}}
+	}
+	switch t := schema["type"].(type) {
+	case string:
+		schema["type"] = []string{t, "null"}
+	case []string:
+		if !slices.Contains(t, "null") {
+			schema["type"] = append(t, "null")
+		}
+	default:
+		if branches, ok := schema["anyOf"].([]map[string]any); ok {
+			if !slices.ContainsFunc(branches, isNull) {
+				schema["anyOf"] = append(branches, map[string]any{"type": "null"})
+			}
+			return schema
+		}
+		return map[string]any{"anyOf": []map[string]any{schema, {"type": "null"}}}
+	}
+	if enum, ok := enumWithNull(schema["enum"]); ok {
+		schema["enum"] = enum
+	}
+	return schema
+}
+
+func keepKeywords(schema map[string]any, keywords []string) {
+	for k := range schema {
+		if !slices.Contains(keywords, k) {
+			delete(schema, k)
+		}
+	}
+}
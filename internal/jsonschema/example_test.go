@@ -1,12 +1,28 @@
 package jsonschema_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"buf.build/go/protovalidate"
 	v1 "github.com/ductone/protoc-gen-mcpgw/example/bookstore/v1"
 	jsonschema "github.com/ductone/protoc-gen-mcpgw/internal/jsonschema"
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
+	santhosh "github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 func TestCreateGenreRequestSchema(t *testing.T) {
@@ -215,3 +231,759 @@ func TestGetAuthorResponseSchema(t *testing.T) {
 	jsonBytes, _ := json.MarshalIndent(schema, "", "  ")
 	t.Logf("Generated schema: %s", jsonBytes)
 }
+
+// TestOneofSchema tests that oneof members are mutually exclusive
+func TestOneofSchema(t *testing.T) {
+	md := (&v1.GetAuthorResponse{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+
+	branches, ok := schema["oneOf"].([]map[string]any)
+	require.True(t, ok, "oneOf should list the oneof branches")
+	require.Len(t, branches, 3)
+	assert.Equal(t, []string{"fiction"}, branches[0]["required"])
+	assert.Equal(t, "fiction is set: only one of the genre oneof members fiction, nonfiction may be set", branches[0]["description"])
+	assert.Equal(t, []string{"nonfiction"}, branches[1]["required"])
+	assert.Equal(t, "none of the genre oneof members fiction, nonfiction is set", branches[2]["description"])
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	assert.NoError(t, compiled.Validate(map[string]any{}))
+	assert.NoError(t, compiled.Validate(map[string]any{"fiction": true}))
+	assert.NoError(t, compiled.Validate(map[string]any{"nonfiction": true}))
+	assert.Error(t, compiled.Validate(map[string]any{"fiction": true, "nonfiction": false}))
+}
+
+// TestNullableOneofSchema tests that null is a value of an optional message
+// field whose message has a oneof
+func TestNullableOneofSchema(t *testing.T) {
+	md := (&v1.FeaturedAuthor{}).ProtoReflect().Descriptor()
+	for _, dialect := range []jsonschema.Dialect{jsonschema.DialectDraft202012, jsonschema.DialectDraft07} {
+		schema, err := jsonschema.GenerateJSONSchema(md, jsonschema.WithDialect(dialect))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(schema)
+		require.NoError(t, err)
+		compiler := santhosh.NewCompiler()
+		require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+		compiled, err := compiler.Compile("schema.json")
+		require.NoError(t, err)
+		assert.NoError(t, compiled.Validate(map[string]any{"author": nil}), dialect)
+		assert.NoError(t, compiled.Validate(map[string]any{"author": map[string]any{}}), dialect)
+		assert.NoError(t, compiled.Validate(map[string]any{"author": map[string]any{"fiction": true}}), dialect)
+		assert.Error(t, compiled.Validate(map[string]any{"author": map[string]any{"fiction": true, "nonfiction": true}}), dialect)
+	}
+}
+
+// TestCELSchema tests that buf.validate CEL and message rules carry into the schema
+func TestCELSchema(t *testing.T) {
+	md := (&v1.SearchBooksRequest{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+
+	properties := schema["properties"].(map[string]any)
+
+	// Common idioms become keywords
+	query := properties["query"].(map[string]any)
+	assert.Equal(t, uint64(1), query["minLength"])
+	assert.Equal(t, uint64(100), query["maxLength"])
+	assert.Equal(t, "^[0-9]{13}$", properties["isbn"].(map[string]any)["pattern"])
+	assert.Equal(t, int64(1), properties["firstPage"].(map[string]any)["minimum"])
+	assert.Equal(t, uint64(1), properties["shelves"].(map[string]any)["minItems"])
+
+	// Other expressions are described
+	assert.Equal(t, "Constraint: tag must be lowercase", properties["tag"].(map[string]any)["description"])
+
+	// 64-bit integers are strings, so their bounds are described
+	maxPrice := properties["maxPriceCents"].(map[string]any)
+	assert.Equal(t, "Constraint: must be at least 0 and less than 1000000", maxPrice["description"])
+	assert.NotContains(t, maxPrice, "minimum")
+	assert.NotContains(t, maxPrice, "exclusiveMaximum")
+	assert.Equal(t, "Constraint: firstPage must be less than or equal to lastPage\n"+
+		"Constraint: published_after must be within the last century", schema["description"])
+
+	// The message oneof rule requires exactly one of its fields
+	branches, ok := schema["oneOf"].([]map[string]any)
+	require.True(t, ok, "oneOf should list the message oneof branches")
+	require.Len(t, branches, 2)
+	assert.Equal(t, "title is set: exactly one of the fields title, author must be set", branches[0]["description"])
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+
+	// The schema and protovalidate agree
+	valid := map[string]any{"query": "dune", "isbn": "9780441013593", "title": "Dune", "firstPage": 1, "lastPage": 2, "shelves": []any{"s1"}}
+	assert.NoError(t, compiled.Validate(valid))
+	msg := &v1.SearchBooksRequest{}
+	require.NoError(t, protojson.Unmarshal(mustMarshal(t, valid), msg))
+	assert.NoError(t, protovalidate.Validate(msg))
+
+	for _, invalid := range []map[string]any{
+		{"query": "", "isbn": "9780441013593", "title": "Dune", "firstPage": 1, "shelves": []any{"s1"}},
+		{"query": "dune", "isbn": "978", "title": "Dune", "firstPage": 1, "shelves": []any{"s1"}},
+		{"query": "dune", "isbn": "9780441013593", "firstPage": 1, "shelves": []any{"s1"}},
+		{"query": "dune", "isbn": "9780441013593", "title": "Dune", "author": "Herbert", "firstPage": 1, "shelves": []any{"s1"}},
+		{"query": "dune", "isbn": "9780441013593", "title": "Dune", "firstPage": 0, "shelves": []any{"s1"}},
+		{"query": "dune", "isbn": "9780441013593", "title": "Dune", "firstPage": 1, "shelves": []any{}},
+	} {
+		assert.Error(t, compiled.Validate(invalid), "schema should reject %v", invalid)
+		msg := &v1.SearchBooksRequest{}
+		require.NoError(t, protojson.Unmarshal(mustMarshal(t, invalid), msg))
+		assert.Error(t, protovalidate.Validate(msg), "protovalidate should reject %v", invalid)
+	}
+}
+
+func TestStringFormatSchema(t *testing.T) {
+	md := (&v1.PublisherContact{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, "uri-reference", properties["website"].(map[string]any)["format"])
+	assert.Equal(t, "uuid", properties["requestId"].(map[string]any)["format"])
+
+	// Rules on the same keyword combine through allOf
+	code := properties["code"].(map[string]any)
+	assert.Equal(t, "^[A-Z]{2}-[0-9]{4}-[A-Z]$", code["pattern"])
+	assert.Equal(t, []map[string]any{
+		{"not": map[string]any{"enum": []string{"BK-0000-X"}}},
+		{"pattern": "^BK-"},
+		{"pattern": "-X$"},
+		{"not": map[string]any{"type": "string", "pattern": "9999"}},
+	}, code["allOf"])
+
+	// Byte lengths are described
+	assert.Equal(t, "Constraint: must be at most 16 bytes long in UTF-8", properties["region"].(map[string]any)["description"])
+	assert.Equal(t, "Constraint: must be exactly 16 bytes long", properties["keyId"].(map[string]any)["description"])
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	compiler := santhosh.NewCompiler()
+	compiler.AssertFormat = true
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+
+	// The schema and protovalidate agree
+	valid := map[string]any{
+		"email":     "orders@example.com",
+		"website":   "/contact",
+		"host":      "example.com",
+		"ip":        "2001:db8::1",
+		"network":   "192.168.0.0/16",
+		"endpoint":  "example.com:443",
+		"requestId": "8a6e0804-2bd0-4672-b79d-d97027f9071a",
+		"traceId":   "8a6e08042bd04672b79dd97027f9071a",
+		"header":    "X-Request-Id",
+		"code":      "BK-1234-X",
+		"region":    "eu-west",
+		"keyId":     "AQIDBAUGBwgJCgsMDQ4PEA==",
+		"priority":  2,
+	}
+	assert.NoError(t, compiled.Validate(valid))
+	msg := &v1.PublisherContact{}
+	require.NoError(t, protojson.Unmarshal(mustMarshal(t, valid), msg))
+	assert.NoError(t, protovalidate.Validate(msg))
+
+	for field, value := range map[string]any{
+		"email":     "orders",
+		"host":      "not a host",
+		"ip":        "example.com",
+		"network":   "192.168.0.0",
+		"endpoint":  "example.com",
+		"requestId": "8a6e0804",
+		"traceId":   "8a6e0804-2bd0-4672-b79d-d97027f9071a",
+		"header":    "X Request",
+		"code":      "BK-0000-X",
+		"priority":  3,
+	} {
+		invalid := maps.Clone(valid)
+		invalid[field] = value
+		assert.Error(t, compiled.Validate(invalid), "schema should reject %s %v", field, value)
+		msg := &v1.PublisherContact{}
+		require.NoError(t, protojson.Unmarshal(mustMarshal(t, invalid), msg))
+		assert.Error(t, protovalidate.Validate(msg), "protovalidate should reject %s %v", field, value)
+	}
+	for _, code := range []string{"XX-1234-X", "BK-1234-Y", "BK-9999-X", "BK-12345-X"} {
+		invalid := maps.Clone(valid)
+		invalid["code"] = code
+		assert.Error(t, compiled.Validate(invalid), "schema should reject code %s", code)
+	}
+}
+
+func TestEnumSchema(t *testing.T) {
+	md := (&v1.PublisherContact{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+
+	// Values with a description are listed as alternatives, without the
+	// zero value when the rules exclude it
+	assert.Equal(t, []map[string]any{
+		{"const": "CONTACT_CHANNEL_EMAIL", "description": "Write to the email address."},
+		{"const": "CONTACT_CHANNEL_PHONE"},
+		{"const": "CONTACT_CHANNEL_POST"},
+		{"type": "null"},
+	}, properties["channel"].(map[string]any)["oneOf"])
+	assert.Equal(t, []map[string]any{
+		{"const": "CONTACT_CHANNEL_EMAIL", "description": "Write to the email address."},
+		{"const": "CONTACT_CHANNEL_PHONE"},
+		{"type": "null"},
+	}, properties["fallbackChannel"].(map[string]any)["oneOf"])
+
+	// Other enums list their names
+	author := (&v1.Author{}).ProtoReflect().Descriptor()
+	schema, err = jsonschema.GenerateJSONSchema(author)
+	require.NoError(t, err)
+	gender := schema["properties"].(map[string]any)["gender"].(map[string]any)
+	assert.Equal(t, []any{"GENDER_UNSPECIFIED", "GENDER_MALE", "GENDER_FEMALE", nil}, gender["enum"])
+
+	schema, err = jsonschema.GenerateJSONSchema(author, jsonschema.WithEnumNumbers())
+	require.NoError(t, err)
+	gender = schema["properties"].(map[string]any)["gender"].(map[string]any)
+	assert.Equal(t, []string{"string", "integer", "null"}, gender["type"])
+	assert.Equal(t, []any{"GENDER_UNSPECIFIED", int32(0), "GENDER_MALE", int32(1), "GENDER_FEMALE", int32(2), nil}, gender["enum"])
+
+	// Numbers are accepted by the schema and protojson alike
+	schema, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithEnumNumbers())
+	require.NoError(t, err)
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(mustMarshal(t, schema))))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	for _, channel := range []any{"CONTACT_CHANNEL_PHONE", 2, nil} {
+		valid := map[string]any{"channel": channel}
+		assert.NoError(t, compiled.Validate(valid), "schema should accept %v", channel)
+		require.NoError(t, protojson.Unmarshal(mustMarshal(t, valid), &v1.PublisherContact{}))
+	}
+	for _, channel := range []any{"CONTACT_CHANNEL_UNSPECIFIED", 0, 7, "PHONE"} {
+		assert.Error(t, compiled.Validate(map[string]any{"channel": channel}), "schema should reject %v", channel)
+	}
+
+	// Provider subsets list the values as an enum, with their descriptions
+	schema, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithDialect(jsonschema.DialectOpenAIStrict))
+	require.NoError(t, err)
+	channel := schema["properties"].(map[string]any)["channel"].(map[string]any)
+	assert.Equal(t, []any{"CONTACT_CHANNEL_EMAIL", "CONTACT_CHANNEL_PHONE", "CONTACT_CHANNEL_POST", nil}, channel["enum"])
+	assert.Equal(t, "CONTACT_CHANNEL_EMAIL: Write to the email address.", channel["description"])
+}
+
+func TestMapSchema(t *testing.T) {
+	md := (&v1.ShelfInventory{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+
+	// Keys are constrained by their type and the key rules
+	booksByShelf := properties["booksByShelf"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"pattern":     "^(0|-?[1-9][0-9]*)$",
+		"description": "Keys are integers, written in decimal.\nConstraint: keys must be at least 1",
+	}, booksByShelf["propertyNames"])
+	assert.Equal(t, map[string]any{"pattern": "^[0-9]{13}$"}, properties["available"].(map[string]any)["propertyNames"])
+	assert.Equal(t, []string{"true", "false"}, properties["notes"].(map[string]any)["propertyNames"].(map[string]any)["enum"])
+
+	// Value rules apply to the values
+	assert.Equal(t, int32(1000), booksByShelf["additionalProperties"].(map[string]any)["maximum"])
+
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(mustMarshal(t, schema))))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+
+	valid := map[string]any{
+		"booksByShelf": map[string]any{"1": 12, "20": 0},
+		"available":    map[string]any{"9780441013593": true},
+		"notes":        map[string]any{"true": "yes"},
+		"channels":     map[string]any{"7": "CONTACT_CHANNEL_EMAIL"},
+	}
+	assert.NoError(t, compiled.Validate(valid))
+	msg := &v1.ShelfInventory{}
+	require.NoError(t, protojson.Unmarshal(mustMarshal(t, valid), msg))
+	assert.NoError(t, protovalidate.Validate(msg))
+
+	for field, value := range map[string]any{
+		"booksByShelf": map[string]any{"one": 12},
+		"available":    map[string]any{"978": true},
+		"notes":        map[string]any{"yes": "no"},
+		"channels":     map[string]any{"-7": "CONTACT_CHANNEL_EMAIL"},
+	} {
+		invalid := maps.Clone(valid)
+		invalid[field] = value
+		assert.Error(t, compiled.Validate(invalid), "schema should reject %s %v", field, value)
+	}
+	for field, value := range map[string]any{
+		"booksByShelf": map[string]any{"1": 2000},
+		"channels":     map[string]any{"7": "CONTACT_CHANNEL_UNSPECIFIED"},
+	} {
+		invalid := maps.Clone(valid)
+		invalid[field] = value
+		assert.Error(t, compiled.Validate(invalid), "schema should reject %s %v", field, value)
+		msg := &v1.ShelfInventory{}
+		require.NoError(t, protojson.Unmarshal(mustMarshal(t, invalid), msg))
+		assert.Error(t, protovalidate.Validate(msg), "protovalidate should reject %s %v", field, value)
+	}
+}
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestRepeatedRulesGolden checks the array and item schemas of repeated
+// fields of every scalar kind against testdata/repeated_rules.golden.json.
+func TestRepeatedRulesGolden(t *testing.T) {
+	md := (&v1.RepeatedRules{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+	got, err := json.MarshalIndent(schema, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "repeated_rules.golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	// Container rules stay on the array, item rules go to its items
+	strs := schema["properties"].(map[string]any)["strings"].(map[string]any)
+	assert.Equal(t, uint64(1), strs["minItems"])
+	assert.Equal(t, true, strs["uniqueItems"])
+	assert.NotContains(t, strs, "minLength")
+	assert.Equal(t, uint64(1), strs["items"].(map[string]any)["minLength"])
+
+	// The schema and protovalidate agree
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(got)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	valid := map[string]any{
+		"strings":   []any{"bk-1", "bk-2"},
+		"blobs":     []any{"AQID"},
+		"flags":     []any{true},
+		"int32s":    []any{1, 10},
+		"sint32s":   []any{0},
+		"sfixed32s": []any{2},
+		"int64s":    []any{"1"},
+		"sint64s":   []any{"99"},
+		"sfixed64s": []any{"4"},
+		"uint32s":   []any{99},
+		"fixed32s":  []any{2},
+		"uint64s":   []any{"5"},
+		"fixed64s":  []any{"7"},
+		"floats":    []any{1.0},
+		"doubles":   []any{1.5},
+		"channels":  []any{"CONTACT_CHANNEL_EMAIL"},
+	}
+	assert.NoError(t, compiled.Validate(valid))
+	msg := &v1.RepeatedRules{}
+	require.NoError(t, protojson.Unmarshal(mustMarshal(t, valid), msg))
+	assert.NoError(t, protovalidate.Validate(msg))
+
+	for field, value := range map[string]any{
+		"strings":   []any{"bk-1", "xx-2"},
+		"int32s":    []any{11},
+		"sint32s":   []any{5},
+		"sfixed32s": []any{4},
+		"sfixed64s": []any{"0"},
+		"uint32s":   []any{},
+		"fixed32s":  []any{1},
+		"fixed64s":  []any{"8"},
+		"floats":    []any{2.0},
+		"channels":  []any{"CONTACT_CHANNEL_POST"},
+	} {
+		invalid := maps.Clone(valid)
+		invalid[field] = value
+		assert.Error(t, compiled.Validate(invalid), "schema should reject %s %v", field, value)
+		msg := &v1.RepeatedRules{}
+		require.NoError(t, protojson.Unmarshal(mustMarshal(t, invalid), msg))
+		assert.Error(t, protovalidate.Validate(msg), "protovalidate should reject %s %v", field, value)
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+// TestFieldOptionsSchema tests the schema keywords set by mcpgw.v1.field options
+func TestFieldOptionsSchema(t *testing.T) {
+	md := (&v1.BookListing{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+
+	properties := schema["properties"].(map[string]any)
+	id := properties["id"].(map[string]any)
+	assert.Equal(t, true, id["readOnly"])
+	assert.Equal(t, []any{"lst-1"}, id["examples"])
+
+	price := properties["priceCents"].(map[string]any)
+	assert.Equal(t, "Price", price["title"])
+	assert.Equal(t, "0", price["default"])
+	assert.Equal(t, []any{"1999", "2500"}, price["examples"])
+
+	assert.Equal(t, "date", properties["printedOn"].(map[string]any)["format"])
+	assert.Equal(t, []any{map[string]any{"main": json.Number("3")}}, properties["stock"].(map[string]any)["examples"])
+	assert.Equal(t, true, properties["payoutAccount"].(map[string]any)["writeOnly"])
+
+	isbn := properties["isbn10"].(map[string]any)
+	assert.Equal(t, true, isbn["deprecated"])
+	assert.Equal(t, "Deprecated: avoid setting this field.", isbn["description"])
+
+	// Every example and default is valid against the schema
+	data := mustMarshal(t, schema)
+	compiler := santhosh.NewCompiler()
+	compiler.AssertFormat = true
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	var generic map[string]any
+	require.NoError(t, json.Unmarshal(data, &generic))
+	for name, property := range generic["properties"].(map[string]any) {
+		property := property.(map[string]any)
+		values, _ := property["examples"].([]any)
+		if def, ok := property["default"]; ok {
+			values = append(values, def)
+		}
+		for _, value := range values {
+			assert.NoError(t, compiled.Validate(map[string]any{name: value}), "%s %v", name, value)
+		}
+	}
+
+	// Examples and defaults that don't decode as the field fail generation
+	for _, tc := range []struct {
+		field string
+		opts  *mcpgw_v1.FieldOptions
+		err   string
+	}{
+		{"price_cents", mcpgw_v1.FieldOptions_builder{Examples: []string{`"cheap"`}}.Build(), "invalid example"},
+		{"tags", mcpgw_v1.FieldOptions_builder{Examples: []string{`"signed"`}}.Build(), "invalid example"},
+		{"stock", mcpgw_v1.FieldOptions_builder{Default: proto.String(`{"main": 3`)}.Build(), "invalid default"},
+		{"id", mcpgw_v1.FieldOptions_builder{Examples: []string{`"a" "b"`}}.Build(), "not a single JSON value"},
+		{"id", mcpgw_v1.FieldOptions_builder{ReadOnly: proto.Bool(true), WriteOnly: proto.Bool(true)}.Build(), "exclusive"},
+	} {
+		fdp := protodesc.ToFileDescriptorProto(md.ParentFile())
+		for _, msg := range fdp.GetMessageType() {
+			if msg.GetName() != string(md.Name()) {
+				continue
+			}
+			for _, field := range msg.GetField() {
+				if field.GetName() == tc.field {
+					field.Options = &descriptorpb.FieldOptions{}
+					proto.SetExtension(field.Options, mcpgw_v1.E_Field, tc.opts)
+				}
+			}
+		}
+		fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+		require.NoError(t, err)
+		_, err = jsonschema.GenerateJSONSchema(fd.Messages().ByName(md.Name()))
+		assert.ErrorContains(t, err, tc.err, tc.field)
+	}
+}
+
+// TestDialects checks every bookstore message against the validator of each dialect
+func TestDialects(t *testing.T) {
+	messages := v1.File_bookstore_v1_bookstore_proto.Messages()
+	for _, dialect := range jsonschema.Dialects {
+		t.Run(string(dialect), func(t *testing.T) {
+			for i := range messages.Len() {
+				md := messages.Get(i)
+				schema, err := jsonschema.GenerateJSONSchema(md, jsonschema.WithDialect(dialect))
+				if dialect == jsonschema.DialectOpenAIStrict && err != nil {
+					assert.ErrorContains(t, err, "doesn't support objects with arbitrary properties", md.FullName())
+					continue
+				}
+				require.NoError(t, err, md.FullName())
+				data := mustMarshal(t, schema)
+
+				switch dialect {
+				case jsonschema.DialectDraft202012, jsonschema.DialectDraft07:
+					compiler := santhosh.NewCompiler()
+					require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+					_, err := compiler.Compile("schema.json")
+					assert.NoError(t, err, md.FullName())
+				case jsonschema.DialectOpenAPI30:
+					checkSubset(t, string(md.FullName()), schema, openAPI30Keywords, false)
+				case jsonschema.DialectOpenAIStrict:
+					checkSubset(t, string(md.FullName()), schema, openAIStrictKeywords, true)
+				case jsonschema.DialectGemini:
+					checkSubset(t, string(md.FullName()), schema, geminiKeywords, false)
+				}
+			}
+		})
+	}
+}
+
+var (
+	// The fields of the OpenAPI 3.0 Schema Object
+	openAPI30Keywords = []string{"title", "multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "maxProperties", "minProperties", "required", "enum", "type", "allOf", "oneOf", "anyOf", "not", "items", "properties", "additionalProperties", "description", "format", "default", "nullable", "discriminator", "readOnly", "writeOnly", "xml", "externalDocs", "example", "deprecated"}
+	openAIStrictKeywords = []string{"type", "title", "description", "enum", "const", "properties", "required", "additionalProperties", "items", "anyOf", "$ref", "$defs", "pattern", "format", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf", "minItems", "maxItems"}
+	geminiKeywords       = []string{"type", "format", "title", "description", "nullable", "enum", "properties", "required", "items", "minItems", "maxItems", "minimum", "maximum", "minLength", "maxLength", "pattern", "minProperties", "maxProperties", "anyOf"}
+)
+
+// checkSubset checks that a schema only uses the keywords of a subset of JSON
+// Schema. In strict mode objects are closed and require all their properties,
+// otherwise types are single names, arrays have items and exclusive bounds
+// are flags, as in OpenAPI 3.0.
+func checkSubset(t *testing.T, name string, schema map[string]any, keywords []string, strict bool) {
+	t.Helper()
+	var check func(ptr string, s any)
+	check = func(ptr string, s any) {
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(mustMarshal(t, s), &schema))
+		for k := range schema {
+			assert.Contains(t, keywords, k, "%s%s", name, ptr)
+		}
+		if strict {
+			if props, ok := schema["properties"].(map[string]any); ok {
+				assert.Equal(t, false, schema["additionalProperties"], "%s%s", name, ptr)
+				assert.ElementsMatch(t, slices.Collect(maps.Keys(props)), schema["required"], "%s%s", name, ptr)
+			}
+		} else {
+			if typ, ok := schema["type"]; ok {
+				assert.IsType(t, "", typ, "%s%s", name, ptr)
+			}
+			if schema["type"] == "array" {
+				assert.Contains(t, schema, "items", "%s%s", name, ptr)
+			}
+			for _, k := range []string{"exclusiveMinimum", "exclusiveMaximum"} {
+				if v, ok := schema[k]; ok {
+					assert.IsType(t, true, v, "%s%s/%s", name, ptr, k)
+				}
+			}
+		}
+		for _, k := range []string{"properties", "$defs"} {
+			subs, _ := schema[k].(map[string]any)
+			for n, sub := range subs {
+				check(ptr+"/"+k+"/"+n, sub)
+			}
+		}
+		for _, k := range []string{"items", "additionalProperties", "not"} {
+			if sub, ok := schema[k].(map[string]any); ok {
+				check(ptr+"/"+k, sub)
+			}
+		}
+		for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+			branches, _ := schema[k].([]any)
+			for i, b := range branches {
+				check(fmt.Sprintf("%s/%s/%d", ptr, k, i), b)
+			}
+		}
+	}
+	check("#", schema)
+}
+
+// TestServerPopulatedSchema tests that server populated fields are left out of input schemas only
+func TestServerPopulatedSchema(t *testing.T) {
+	md := (&v1.CreateBookRequest{}).ProtoReflect().Descriptor()
+
+	for _, opts := range [][]jsonschema.Option{
+		{jsonschema.WithoutServerPopulated()},
+		{jsonschema.WithoutServerPopulated(), jsonschema.WithDefs()},
+	} {
+		schema, err := jsonschema.GenerateJSONSchema(md, opts...)
+		require.NoError(t, err)
+		data := string(mustMarshal(t, schema))
+		assert.NotContains(t, data, "tenantId")
+		assert.NotContains(t, data, "createdBy")
+		assert.Contains(t, data, "shelfId")
+	}
+
+	// Responses keep them
+	schema, err := jsonschema.GenerateJSONSchema((&v1.CreateBookResponse{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+	book := schema["properties"].(map[string]any)["book"].(map[string]any)
+	assert.Contains(t, book["properties"], "createdBy")
+}
+
+func TestDialectConversions(t *testing.T) {
+	md := (&v1.CreateBookRequest{}).ProtoReflect().Descriptor()
+
+	// OpenAI strict requires every property, with null for optional ones
+	schema, err := jsonschema.GenerateJSONSchema(md, jsonschema.WithDialect(jsonschema.DialectOpenAIStrict), jsonschema.WithoutServerPopulated())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"shelf", "book"}, schema["required"])
+	book := schema["properties"].(map[string]any)["book"].(map[string]any)
+	assert.Equal(t, []string{"object", "null"}, book["type"])
+	assert.ElementsMatch(t, []string{"id", "author", "title", "quotes", "shelfId"}, book["required"])
+
+	data := mustMarshal(t, schema)
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	assert.NoError(t, compiled.Validate(map[string]any{"shelf": "s1", "book": nil}))
+	assert.Error(t, compiled.Validate(map[string]any{"shelf": "s1"}))
+
+	// OpenAPI and Gemini mark null with nullable
+	for _, dialect := range []jsonschema.Dialect{jsonschema.DialectOpenAPI30, jsonschema.DialectGemini} {
+		schema, err := jsonschema.GenerateJSONSchema((&v1.CreateGenreRequest{}).ProtoReflect().Descriptor(), jsonschema.WithDialect(dialect))
+		require.NoError(t, err)
+		name := schema["properties"].(map[string]any)["name"].(map[string]any)
+		assert.Equal(t, "string", name["type"], dialect)
+		assert.Equal(t, true, name["nullable"], dialect)
+		assert.NotContains(t, schema, "$schema", dialect)
+	}
+
+	// Draft-07 refers to definitions
+	schema, err = jsonschema.GenerateJSONSchema((&v1.RecursiveBookResponse{}).ProtoReflect().Descriptor(),
+		jsonschema.WithDefs(), jsonschema.WithDialect(jsonschema.DialectDraft07))
+	require.NoError(t, err)
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+	assert.Contains(t, schema, "definitions")
+	assert.NotContains(t, schema, "$defs")
+	assert.Contains(t, string(mustMarshal(t, schema)), `"$ref":"#/definitions/bookstore.v1.RecursivePage"`)
+
+	_, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithDefs(), jsonschema.WithDialect(jsonschema.DialectOpenAPI30))
+	assert.ErrorContains(t, err, "doesn't support $defs")
+
+	_, err = jsonschema.ParseDialect("draft-04")
+	assert.ErrorContains(t, err, "unknown schema dialect 'draft-04'")
+}
+
+// TestStreamMessagesSchema tests that the schemas of streamed messages are
+// wrapped before the dialect conversion, which then applies to the wrapper
+func TestStreamMessagesSchema(t *testing.T) {
+	md := (&v1.ImportBooksRequest{}).ProtoReflect().Descriptor()
+
+	// Draft-07 keeps definitions at the root, where the references point
+	schema, err := jsonschema.GenerateJSONSchema(md, jsonschema.WithStreamMessages(), jsonschema.WithDefs(), jsonschema.WithDialect(jsonschema.DialectDraft07))
+	require.NoError(t, err)
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+	assert.Contains(t, schema, "definitions")
+	assert.Equal(t, []any{"messages"}, schema["required"])
+	items := schema["properties"].(map[string]any)["messages"].(map[string]any)["items"].(map[string]any)
+	assert.NotContains(t, items, "definitions")
+	assert.NotContains(t, items, "$schema")
+	assert.Contains(t, string(mustMarshal(t, schema)), `"$ref":"#/definitions/bookstore.v1.Book"`)
+
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(mustMarshal(t, schema))))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	assert.NoError(t, compiled.Validate(map[string]any{"messages": []any{map[string]any{"shelf": "s1", "book": map[string]any{"title": "Dune"}}}}))
+	assert.Error(t, compiled.Validate(map[string]any{"messages": []any{map[string]any{"book": map[string]any{"title": 1}}}}))
+
+	// Gemini drops additionalProperties from the wrapper too
+	schema, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithStreamMessages(), jsonschema.WithDialect(jsonschema.DialectGemini))
+	require.NoError(t, err)
+	checkSubset(t, string(md.FullName()), schema, geminiKeywords, false)
+
+	// OpenAI strict closes the wrapper and requires its properties
+	schema, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithStreamMessages(), jsonschema.WithDefs(), jsonschema.WithDialect(jsonschema.DialectOpenAIStrict))
+	require.NoError(t, err)
+	checkSubset(t, string(md.FullName()), schema, openAIStrictKeywords, true)
+	assert.Contains(t, schema, "$defs")
+}
+
+// TestRecursiveSchemaDefs tests that recursive messages keep their structure with $defs
+func TestRecursiveSchemaDefs(t *testing.T) {
+	md := (&v1.RecursiveBookResponse{}).ProtoReflect().Descriptor()
+	schema, err := jsonschema.GenerateJSONSchema(md, jsonschema.WithDefs())
+	require.NoError(t, err)
+
+	assert.Equal(t, "RecursiveBookResponse", schema["title"])
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, []map[string]any{
+		{"$ref": "#/$defs/bookstore.v1.RecursivePage"},
+		{"type": "null"},
+	}, properties["page"].(map[string]any)["anyOf"])
+
+	defs, ok := schema["$defs"].(map[string]any)
+	require.True(t, ok, "$defs should be a map")
+	assert.Len(t, defs, 2)
+	page := defs["bookstore.v1.RecursivePage"].(map[string]any)
+	assert.NotContains(t, page, "$schema")
+	assert.Equal(t, "RecursivePage", page["title"])
+	extraPages := page["properties"].(map[string]any)["extraPages"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/bookstore.v1.RecursivePage"}, extraPages["items"])
+	assert.Contains(t, defs, "bookstore.v1.RecursiveBookResponse")
+
+	// The references resolve, and the recursive structure is validated
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	compiler := santhosh.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", bytes.NewReader(data)))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	assert.NoError(t, compiled.Validate(map[string]any{
+		"page": map[string]any{"extraPages": []any{map[string]any{"prop": "x"}}},
+	}))
+	assert.Error(t, compiled.Validate(map[string]any{
+		"page": map[string]any{"extraPages": []any{map[string]any{"prop": 1}}},
+	}))
+}
+
+// TestInlineDepth tests that inlined messages are expanded up to the depth limit
+func TestInlineDepth(t *testing.T) {
+	md := (&v1.RecursiveBookResponse{}).ProtoReflect().Descriptor()
+
+	// Without a limit, recursion stops at the first repeated message
+	schema, err := jsonschema.GenerateJSONSchema(md)
+	require.NoError(t, err)
+	page := schema["properties"].(map[string]any)["page"].(map[string]any)
+	extraPages := page["properties"].(map[string]any)["extraPages"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "object"}, extraPages["items"])
+
+	// With a limit, recursive messages are expanded until the limit
+	schema, err = jsonschema.GenerateJSONSchema(md, jsonschema.WithInlineDepth(2))
+	require.NoError(t, err)
+	page = schema["properties"].(map[string]any)["page"].(map[string]any)
+	extraPages = page["properties"].(map[string]any)["extraPages"].(map[string]any)
+	extraPage := extraPages["items"].(map[string]any)
+	assert.Equal(t, "RecursivePage", extraPage["title"])
+	nested := extraPage["properties"].(map[string]any)["extraPages"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "object"}, nested["items"])
+}
+
+// TestCommentDescriptions tests that leading comments describe messages and fields
+func TestCommentDescriptions(t *testing.T) {
+	// Compiled descriptors don't retain source info, so add comments back for the test
+	md := (&v1.CreateBookRequest{}).ProtoReflect().Descriptor()
+	fdp := protodesc.ToFileDescriptorProto(md.ParentFile())
+	msgPath := []int32{4, int32(md.Index())}
+	fdp.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{
+				Path:            msgPath,
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(" Request message for CreateBook\n method.\n"),
+			},
+			{
+				Path:            append(slices.Clone(msgPath), 2, int32(md.Fields().ByName("book").Index())),
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(" A book resource to create on the shelf.\n"),
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	schema, err := jsonschema.GenerateJSONSchema(fd.Messages().ByName("CreateBookRequest"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "CreateBookRequest", schema["title"])
+	assert.Equal(t, "Request message for CreateBook method.", schema["description"])
+
+	properties, ok := schema["properties"].(map[string]any)
+	assert.True(t, ok, "Properties should be a map")
+	assert.NotContains(t, properties["shelf"], "description")
+	assert.Equal(t, "A book resource to create on the shelf.", properties["book"].(map[string]any)["description"])
+}
+
+func TestCleanComment(t *testing.T) {
+	assert.Equal(t, "", jsonschema.CleanComment(""))
+	assert.Equal(t, "A book resource.", jsonschema.CleanComment(" A book resource.\n"))
+	assert.Equal(t, "The API manages shelves and books resources.\n\nShelves contain books.",
+		jsonschema.CleanComment(" The API manages shelves\n and books resources.\n\n Shelves contain books.\n"))
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+)
+
+// Patterns of the protovalidate string formats that JSON Schema has no format for.
+const (
+	ipv4PrefixPattern  = `^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])/([0-9]|[12][0-9]|3[0-2])$`
+	ipv6PrefixPattern  = `^[0-9A-Fa-f:.]*:[0-9A-Fa-f:.]*/([0-9]|[1-9][0-9]|1[01][0-9]|12[0-8])$`
+	hostAndPortPattern = `^([0-9A-Za-z.-]+|\[[0-9A-Fa-f:.]+\]):(0|[1-9][0-9]{0,4})$`
+	tuuidPattern       = `^[0-9a-fA-F]{32}$`
+
+	// The header patterns hold control characters literally, so they read
+	// the same as Go and ECMA 262 regular expressions.
+	httpHeaderNamePattern  = "^:?[0-9a-zA-Z!#$%&'*+\\-.^_|~`]+$"
+	httpHeaderValuePattern = "^[^\x00-\x08\x0A-\x1F\x7F]*$"
+	httpHeaderLoosePattern = "^[^\x00\x0A\x0D]*$"
+)
+
+// applyStringFormat applies the well known format of string rules.
+func applyStringFormat(rules *validate.StringRules, schema map[string]any) {
+	switch {
+	case rules.GetEmail():
+		addKeyword(schema, "format", "email")
+	case rules.GetHostname():
+		addKeyword(schema, "format", "hostname")
+	case rules.GetIp():
+		addConstraint(schema, anyOfFormats("ipv4", "ipv6"))
+	case rules.GetIpv4():
+		addKeyword(schema, "format", "ipv4")
+	case rules.GetIpv6():
+		addKeyword(schema, "format", "ipv6")
+	case rules.GetUri():
+		addKeyword(schema, "format", "uri")
+	case rules.GetUriRef():
+		addKeyword(schema, "format", "uri-reference")
+	case rules.GetAddress():
+		addConstraint(schema, anyOfFormats("hostname", "ipv4", "ipv6"))
+	case rules.GetUuid():
+		addKeyword(schema, "format", "uuid")
+	case rules.GetTuuid():
+		addKeyword(schema, "pattern", tuuidPattern)
+	case rules.GetIpWithPrefixlen():
+		addConstraint(schema, anyOfPatterns(ipv4PrefixPattern, ipv6PrefixPattern))
+	case rules.GetIpv4WithPrefixlen():
+		addKeyword(schema, "pattern", ipv4PrefixPattern)
+	case rules.GetIpv6WithPrefixlen():
+		addKeyword(schema, "pattern", ipv6PrefixPattern)
+	case rules.GetIpPrefix():
+		addConstraint(schema, anyOfPatterns(ipv4PrefixPattern, ipv6PrefixPattern))
+		appendDescription(schema, "Constraint: must be an IP prefix, with no bits set after the prefix length")
+	case rules.GetIpv4Prefix():
+		addKeyword(schema, "pattern", ipv4PrefixPattern)
+		appendDescription(schema, "Constraint: must be an IPv4 prefix, with no bits set after the prefix length")
+	case rules.GetIpv6Prefix():
+		addKeyword(schema, "pattern", ipv6PrefixPattern)
+		appendDescription(schema, "Constraint: must be an IPv6 prefix, with no bits set after the prefix length")
+	case rules.GetHostAndPort():
+		addKeyword(schema, "pattern", hostAndPortPattern)
+	case rules.HasWellKnownRegex():
+		strict := !rules.HasStrict() || rules.GetStrict()
+		switch rules.GetWellKnownRegex() {
+		case validate.KnownRegex_KNOWN_REGEX_HTTP_HEADER_NAME:
+			if strict {
+				addKeyword(schema, "pattern", httpHeaderNamePattern)
+			} else {
+				addKeyword(schema, "pattern", httpHeaderLoosePattern)
+			}
+		case validate.KnownRegex_KNOWN_REGEX_HTTP_HEADER_VALUE:
+			if strict {
+				addKeyword(schema, "pattern", httpHeaderValuePattern)
+			} else {
+				addKeyword(schema, "pattern", httpHeaderLoosePattern)
+			}
+		}
+	}
+}
+
+// applyBytesFormat describes the well known format of bytes rules, which
+// constrain the decoded bytes rather than their base64 form.
+func applyBytesFormat(rules *validate.BytesRules, schema map[string]any) {
+	switch {
+	case rules.GetIp():
+		appendDescription(schema, "Constraint: must be an IPv4 or IPv6 address in byte form (4 or 16 bytes)")
+	case rules.GetIpv4():
+		appendDescription(schema, "Constraint: must be an IPv4 address in byte form (4 bytes)")
+	case rules.GetIpv6():
+		appendDescription(schema, "Constraint: must be an IPv6 address in byte form (16 bytes)")
+	}
+}
+
+func anyOfFormats(formats ...string) map[string]any {
+	branches := make([]map[string]any, 0, len(formats))
+	for _, format := range formats {
+		branches = append(branches, map[string]any{"format": format})
+	}
+	return map[string]any{"anyOf": branches}
+}
+
+func anyOfPatterns(patterns ...string) map[string]any {
+	branches := make([]map[string]any, 0, len(patterns))
+	for _, pattern := range patterns {
+		branches = append(branches, map[string]any{"pattern": pattern})
+	}
+	return map[string]any{"anyOf": branches}
+}
+
+// addKeyword sets a keyword of a schema. A keyword that is already set is
+// combined with the new value through allOf, so both constraints apply.
+func addKeyword(schema map[string]any, keyword string, value any) {
+	if _, ok := schema[keyword]; !ok {
+		schema[keyword] = value
+		return
+	}
+	addConstraint(schema, map[string]any{keyword: value})
+}
+
+// addConstraint adds a subschema that values must also be valid against.
+func addConstraint(schema map[string]any, constraint map[string]any) {
+	allOf, _ := schema["allOf"].([]map[string]any)
+	schema["allOf"] = append(allOf, constraint)
+}
+
+// notString rejects strings valid against a schema, while other values,
+// such as null, stay valid.
+func notString(schema map[string]any) map[string]any {
+	schema["type"] = "string"
+	return map[string]any{"not": schema}
+}
+
+// byteCount describes a number of bytes.
+func byteCount(n uint64) string {
+	if n == 1 {
+		return "1 byte"
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
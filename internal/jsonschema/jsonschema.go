@@ -55,12 +55,20 @@ package jsonschema
 //   [ ] Write unit tests covering various types, validations, WKTs, nesting, and edge cases.
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
@@ -95,36 +103,59 @@ const (
 
 // GenerateJSONSchema generates a JSON Schema (draft-2020-12) for a Protobuf message.
 // The schema is returned as a map[string]any that can be marshaled to JSON.
-// All schemas are fully inlined (no $ref or $defs).
-func GenerateJSONSchema(md protoreflect.MessageDescriptor) (map[string]any, error) {
+// By default nested messages are inlined (no $ref or $defs), see WithDefs
+// and WithInlineDepth. WithDialect selects another dialect.
+func GenerateJSONSchema(md protoreflect.MessageDescriptor, opts ...Option) (map[string]any, error) {
 	if md == nil {
 		return nil, fmt.Errorf("message descriptor cannot be nil")
 	}
 
-	// Track visited message types to prevent infinite recursion
-	visited := make(map[protoreflect.FullName]bool)
-
-	return schemaForMessage(md, visited)
+	g := newGenerator(opts)
+	var schema map[string]any
+	var err error
+	if !g.opts.defs {
+		schema, err = inlineMessage(md, g)
+	} else {
+		schema, err = schemaForMessage(md, g)
+		if err == nil && len(g.defs) > 0 {
+			schema["$defs"] = g.defs
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if g.opts.streamMessages {
+		schema = messagesSchema(schema)
+	}
+	return convertDialect(schema, g.opts)
 }
 
-// schemaForMessage generates a JSON Schema for a message type, tracking visited messages to prevent recursion
-func schemaForMessage(md protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) (map[string]any, error) {
-	// Check for recursion
-	if visited[md.FullName()] {
-		// For recursive types, return a generic object schema
-		return map[string]any{
-			"type": "object",
-			// No further details to prevent infinite recursion
-		}, nil
+// messagesSchema wraps the schema of a message into the schema of an object
+// whose "messages" property is an array of them.
+func messagesSchema(schema map[string]any) map[string]any {
+	rv := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"messages": map[string]any{
+				"type":  "array",
+				"items": schema,
+			},
+		},
+		"required":             []any{"messages"},
+		"additionalProperties": false,
 	}
+	// Keywords that only apply at the root of a schema stay there
+	for _, k := range []string{"$schema", "$defs"} {
+		if v, ok := schema[k]; ok {
+			rv[k] = v
+			delete(schema, k)
+		}
+	}
+	return rv
+}
 
-	// Mark this message as visited
-	visited[md.FullName()] = true
-	defer func() {
-		// Remove from visited when done with this branch
-		delete(visited, md.FullName())
-	}()
-
+// schemaForMessage generates a JSON Schema for a message type
+func schemaForMessage(md protoreflect.MessageDescriptor, g *generator) (map[string]any, error) {
 	// Initialize the schema
 	schema := map[string]any{
 		"$schema":              "https://json-schema.org/draft/2020-12/schema",
@@ -133,6 +164,9 @@ func schemaForMessage(md protoreflect.MessageDescriptor, visited map[protoreflec
 		"properties":           map[string]any{},
 		"additionalProperties": false,
 	}
+	if comment := descriptorComment(md); comment != "" {
+		schema["description"] = comment
+	}
 
 	// Fields that are required
 	var requiredFields []string
@@ -141,9 +175,12 @@ func schemaForMessage(md protoreflect.MessageDescriptor, visited map[protoreflec
 	fields := md.Fields()
 	for i := 0; i < fields.Len(); i++ {
 		fd := fields.Get(i)
+		if g.omitted(fd) {
+			continue
+		}
 
 		// Generate schema for this field
-		fieldSchema, err := schemaForField(fd, visited)
+		fieldSchema, err := schemaForField(fd, g)
 		if err != nil {
 			return nil, fmt.Errorf("error processing field %s: %w", fd.Name(), err)
 		}
@@ -163,19 +200,130 @@ func schemaForMessage(md protoreflect.MessageDescriptor, visited map[protoreflec
 		schema["required"] = requiredFields
 	}
 
+	applyOneofs(md, schema, g)
+	applyMessageCEL(md, schema)
+
 	return schema, nil
 }
 
-// schemaForField generates a JSON Schema for a single field
-func schemaForField(fd protoreflect.FieldDescriptor, visited map[protoreflect.FullName]bool) (map[string]any, error) {
+// oneofGroup is a set of fields of which at most one may be set: the members
+// of a oneof, or the fields of a (buf.validate.message).oneof rule.
+type oneofGroup struct {
+	label    string
+	members  []string
+	required bool
+}
+
+// applyOneofs allows at most one member of each oneof group to be set, with
+// one alternative per member, and unless the group is required one for none
+// of them. Synthetic oneofs of proto3 optional fields are skipped, as are
+// members left out of the schema.
+func applyOneofs(md protoreflect.MessageDescriptor, schema map[string]any, g *generator) {
+	var groups []oneofGroup
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		group := oneofGroup{label: fmt.Sprintf("the %s oneof members", od.Name())}
+		for j := 0; j < od.Fields().Len(); j++ {
+			if fd := od.Fields().Get(j); !g.omitted(fd) {
+				group.members = append(group.members, fd.JSONName())
+			}
+		}
+		if rules, ok := proto.GetExtension(od.Options(), validate.E_Oneof).(*validate.OneofRules); ok {
+			group.required = rules.GetRequired()
+		}
+		groups = append(groups, group)
+	}
+	for _, rule := range messageRules(md).GetOneof() {
+		group := oneofGroup{label: "the fields", required: rule.GetRequired()}
+		for _, name := range rule.GetFields() {
+			if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil && !g.omitted(fd) {
+				group.members = append(group.members, fd.JSONName())
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	var constraints []map[string]any
+	for _, group := range groups {
+		// Omitted members can't be set, so neither can a group of them
+		if len(group.members) == 0 {
+			continue
+		}
+		constraints = append(constraints, map[string]any{"oneOf": oneofBranches(group)})
+	}
+	switch len(constraints) {
+	case 0:
+	case 1:
+		schema["oneOf"] = constraints[0]["oneOf"]
+	default:
+		schema["allOf"] = constraints
+	}
+}
+
+func oneofBranches(group oneofGroup) []map[string]any {
+	memberList := strings.Join(group.members, ", ")
+	rule := fmt.Sprintf("only one of %s %s may be set", group.label, memberList)
+	if group.required {
+		rule = fmt.Sprintf("exactly one of %s %s must be set", group.label, memberList)
+	}
+
+	branches := make([]map[string]any, 0, len(group.members)+1)
+	anyMember := make([]map[string]any, 0, len(group.members))
+	for _, member := range group.members {
+		branches = append(branches, map[string]any{
+			"required":    []string{member},
+			"description": fmt.Sprintf("%s is set: %s", member, rule),
+		})
+		anyMember = append(anyMember, map[string]any{"required": []string{member}})
+	}
+	if !group.required {
+		branches = append(branches, map[string]any{
+			"not":         map[string]any{"anyOf": anyMember},
+			"description": fmt.Sprintf("none of %s %s is set", group.label, memberList),
+		})
+	}
+	return branches
+}
+
+// schemaForField generates a JSON Schema for a single field, described by the
+// field's leading comment unless its options set a description.
+func schemaForField(fd protoreflect.FieldDescriptor, g *generator) (map[string]any, error) {
+	fieldSchema, err := schemaForFieldType(fd, fieldRules(fd), g)
+	if err != nil {
+		return nil, err
+	}
+	if comment := descriptorComment(fd); comment != "" {
+		fieldSchema["description"] = comment
+	}
+
+	// Apply custom field options (if any)
+	if err := applyCustomFieldOptions(fd, fieldSchema); err != nil {
+		return nil, err
+	}
+
+	// Apply validation rules and CEL rules from buf.validate after the
+	// description they may extend
+	applyValidationRules(fd, fieldSchema)
+	applyFieldCEL(fd, fieldSchema)
+
+	return fieldSchema, nil
+}
+
+// schemaForFieldType generates the schema of a field's value, with the rules
+// of the field that select enum values
+func schemaForFieldType(fd protoreflect.FieldDescriptor, rules *validate.FieldRules, g *generator) (map[string]any, error) {
 	// Handle repeated fields (non-map)
 	if fd.IsList() && !fd.IsMap() {
-		return schemaForRepeatedField(fd, visited)
+		return schemaForRepeatedField(fd, g)
 	}
 
 	// Handle map fields
 	if fd.IsMap() {
-		return schemaForMapField(fd, visited)
+		return schemaForMapField(fd, g)
 	}
 
 	// Handle well-known types
@@ -187,17 +335,11 @@ func schemaForField(fd protoreflect.FieldDescriptor, visited map[protoreflect.Fu
 	}
 
 	// Handle regular fields based on kind
-	fieldSchema, err := schemaForKind(fd, visited)
+	fieldSchema, err := schemaForKind(fd, rules, g)
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply custom field options (if any)
-	applyCustomFieldOptions(fd, fieldSchema)
-
-	// Apply validation rules from buf.validate (if any)
-	applyValidationRules(fd, fieldSchema)
-
 	// Handle nullability for proto3 optional fields or proto2 optional fields
 	applyNullability(fd, fieldSchema)
 
@@ -205,7 +347,7 @@ func schemaForField(fd protoreflect.FieldDescriptor, visited map[protoreflect.Fu
 }
 
 // schemaForKind generates a schema based on the field's kind
-func schemaForKind(fd protoreflect.FieldDescriptor, visited map[protoreflect.FullName]bool) (map[string]any, error) {
+func schemaForKind(fd protoreflect.FieldDescriptor, rules *validate.FieldRules, g *generator) (map[string]any, error) {
 	switch fd.Kind() {
 	case protoreflect.BoolKind:
 		return map[string]any{"type": "boolean"}, nil
@@ -237,21 +379,27 @@ func schemaForKind(fd protoreflect.FieldDescriptor, visited map[protoreflect.Ful
 
 	// Floating point types
 	case protoreflect.FloatKind, protoreflect.DoubleKind:
-		schema := map[string]any{"type": "number"}
-		// Optionally allow "NaN", "Infinity", "-Infinity" as strings
-		// which are valid in Proto JSON
-		schema["oneOf"] = []map[string]any{
-			{"type": "number"},
-			{"type": "string", "enum": []string{"NaN", "Infinity", "-Infinity"}},
-		}
-		return schema, nil
+		// "NaN", "Infinity" and "-Infinity" are valid in Proto JSON, so the
+		// value is a number or one of them
+		return map[string]any{
+			"oneOf": []map[string]any{
+				{"type": "number"},
+				{"type": "string", "enum": []string{"NaN", "Infinity", "-Infinity"}},
+			},
+		}, nil
 
 	case protoreflect.EnumKind:
-		return schemaForEnum(fd.Enum()), nil
+		return schemaForEnum(fd.Enum(), rules, g), nil
 
 	case protoreflect.MessageKind:
 		// Generate schema for nested message
-		msgSchema, err := schemaForMessage(fd.Message(), visited)
+		var msgSchema map[string]any
+		var err error
+		if g.opts.defs {
+			msgSchema, err = refMessage(fd.Message(), g)
+		} else {
+			msgSchema, err = inlineMessage(fd.Message(), g)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("error processing nested message %s: %w", fd.Message().Name(), err)
 		}
@@ -262,32 +410,84 @@ func schemaForKind(fd protoreflect.FieldDescriptor, visited map[protoreflect.Ful
 	}
 }
 
-// schemaForEnum generates a schema for an enum field
-func schemaForEnum(ed protoreflect.EnumDescriptor) map[string]any {
+// schemaForEnum generates a schema for an enum field. Values are listed by
+// name, and with WithEnumNumbers by number too, as protojson accepts both.
+// Values excluded by the field's enum rules are left out, as is the zero
+// value when the rules require a set or defined value. If any value has a
+// description, the values are listed as oneOf alternatives carrying theirs.
+func schemaForEnum(ed protoreflect.EnumDescriptor, rules *validate.FieldRules, g *generator) map[string]any {
 	schema := map[string]any{
 		"type": "string",
 	}
+	if g.opts.enumNumbers {
+		schema["type"] = []string{"string", "integer"}
+	}
 
 	// Collect enum values
 	values := ed.Values()
-	enumValues := make([]string, 0, values.Len())
+	enumValues := make([]any, 0, values.Len())
+	branches := make([]map[string]any, 0, values.Len())
+	described := false
 
 	for i := 0; i < values.Len(); i++ {
 		enumValue := values.Get(i)
+		if !enumValueAllowed(enumValue, rules) {
+			continue
+		}
+		branch := map[string]any{"const": string(enumValue.Name())}
 		enumValues = append(enumValues, string(enumValue.Name()))
+		if g.opts.enumNumbers {
+			branch = map[string]any{"enum": []any{string(enumValue.Name()), int32(enumValue.Number())}}
+			enumValues = append(enumValues, int32(enumValue.Number()))
+		}
+		if desc := enumValueDescription(enumValue); desc != "" {
+			branch["description"] = desc
+			described = true
+		}
+		branches = append(branches, branch)
 	}
 
-	if len(enumValues) > 0 {
+	switch {
+	case described:
+		schema["oneOf"] = branches
+	case len(enumValues) > 0:
 		schema["enum"] = enumValues
 	}
 
 	return schema
 }
 
+// enumValueAllowed reports whether the enum rules of a field accept a value.
+func enumValueAllowed(v protoreflect.EnumValueDescriptor, rules *validate.FieldRules) bool {
+	number := int32(v.Number())
+	if number == 0 && (rules.GetRequired() || rules.GetEnum().GetDefinedOnly()) {
+		return false
+	}
+	enumRules := rules.GetEnum()
+	if enumRules.HasConst() && enumRules.GetConst() != number {
+		return false
+	}
+	if in := enumRules.GetIn(); len(in) > 0 && !slices.Contains(in, number) {
+		return false
+	}
+	return !slices.Contains(enumRules.GetNotIn(), number)
+}
+
+// enumValueDescription is the description of an enum value from its
+// mcpgw.v1.enum_value options, or its leading comment.
+func enumValueDescription(v protoreflect.EnumValueDescriptor) string {
+	opts, ok := proto.GetExtension(v.Options(), mcpgw_v1.E_EnumValue).(*mcpgw_v1.EnumValueOptions)
+	if ok && opts.GetDescription() != "" {
+		return opts.GetDescription()
+	}
+	return descriptorComment(v)
+}
+
 // schemaForRepeatedField handles repeated fields (creates an array schema)
-func schemaForRepeatedField(fd protoreflect.FieldDescriptor, visited map[protoreflect.FullName]bool) (map[string]any, error) {
-	// Create item schema (schema for a single element of the array)
-	itemSchema, err := schemaForKind(fd, visited)
+func schemaForRepeatedField(fd protoreflect.FieldDescriptor, g *generator) (map[string]any, error) {
+	// Create item schema (schema for a single element of the array), with
+	// the repeated.items rules
+	itemSchema, err := schemaForElement(fd, fieldRules(fd).GetRepeated().GetItems(), g)
 	if err != nil {
 		return nil, err
 	}
@@ -304,11 +504,31 @@ func schemaForRepeatedField(fd protoreflect.FieldDescriptor, visited map[protore
 	return schema, nil
 }
 
-// schemaForMapField handles map fields
-func schemaForMapField(fd protoreflect.FieldDescriptor, visited map[protoreflect.FullName]bool) (map[string]any, error) {
+// schemaForElement generates the schema of an item of a repeated field or a
+// value of a map field, with the rules that apply to each element.
+func schemaForElement(fd protoreflect.FieldDescriptor, rules *validate.FieldRules, g *generator) (map[string]any, error) {
+	if fd.Kind() == protoreflect.MessageKind {
+		if wktSchema, isWKT := schemaForWellKnownType(fd.Message()); isWKT {
+			return wktSchema, nil
+		}
+	}
+	schema, err := schemaForKind(fd, rules, g)
+	if err != nil {
+		return nil, err
+	}
+	applyValueRules(fd.Kind(), rules, schema)
+	applyRulesCEL(elementShape(fd), rules, schema)
+	return schema, nil
+}
+
+// schemaForMapField handles map fields. The keys of a map are JSON strings,
+// constrained through propertyNames by the key type and the map.keys rules.
+// The map.values rules apply to the value schema.
+func schemaForMapField(fd protoreflect.FieldDescriptor, g *generator) (map[string]any, error) {
+	rules := fieldRules(fd).GetMap()
+
 	// Get the value descriptor and generate its schema
-	valueDesc := fd.MapValue()
-	valueSchema, err := schemaForField(valueDesc, visited)
+	valueSchema, err := schemaForElement(fd.MapValue(), rules.GetValues(), g)
 	if err != nil {
 		return nil, err
 	}
@@ -318,10 +538,105 @@ func schemaForMapField(fd protoreflect.FieldDescriptor, visited map[protoreflect
 		"type":                 "object",
 		"additionalProperties": valueSchema,
 	}
+	if keySchema := schemaForMapKey(fd.MapKey(), rules.GetKeys()); len(keySchema) > 0 {
+		schema["propertyNames"] = keySchema
+	}
 
 	return schema, nil
 }
 
+// schemaForMapKey generates the schema of the keys of a map, which protojson
+// writes as strings. Rules of integer keys are described, as they can't
+// constrain a string.
+func schemaForMapKey(kd protoreflect.FieldDescriptor, rules *validate.FieldRules) map[string]any {
+	schema := map[string]any{}
+	switch kd.Kind() {
+	case protoreflect.StringKind:
+		applyFieldRules(kd, rules, schema)
+		applyRulesCEL(elementShape(kd), rules, schema)
+		return schema
+
+	case protoreflect.BoolKind:
+		schema["enum"] = []string{"true", "false"}
+		if rules.GetBool().HasConst() {
+			schema["enum"] = []string{strconv.FormatBool(rules.GetBool().GetConst())}
+		}
+		schema["description"] = `Keys are the booleans "true" and "false".`
+		return schema
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		schema["pattern"] = "^(0|[1-9][0-9]*)$"
+		schema["description"] = "Keys are non-negative integers, written in decimal."
+
+	default:
+		schema["pattern"] = "^(0|-?[1-9][0-9]*)$"
+		schema["description"] = "Keys are integers, written in decimal."
+	}
+
+	bounds := map[string]any{}
+	applyKeyBounds(kd.Kind(), rules, bounds)
+	applyRulesCEL(elementShape(kd), rules, bounds)
+	if text := describeBounds(bounds); text != "" {
+		appendDescription(schema, "Constraint: keys must be "+text)
+	}
+	if desc, ok := bounds["description"].(string); ok {
+		appendDescription(schema, desc)
+	}
+	return schema
+}
+
+// applyKeyBounds applies the rules of integer map keys as numeric keywords,
+// which schemaForMapKey describes, as keys are strings.
+func applyKeyBounds(kind protoreflect.Kind, rules *validate.FieldRules, schema map[string]any) {
+	switch kind {
+	case protoreflect.Int32Kind:
+		applyNumericRules[int32](rules.GetInt32(), schema)
+	case protoreflect.Sint32Kind:
+		applyNumericRules[int32](rules.GetSint32(), schema)
+	case protoreflect.Sfixed32Kind:
+		applyNumericRules[int32](rules.GetSfixed32(), schema)
+	case protoreflect.Int64Kind:
+		applyNumericRules[int64](rules.GetInt64(), schema)
+	case protoreflect.Sint64Kind:
+		applyNumericRules[int64](rules.GetSint64(), schema)
+	case protoreflect.Sfixed64Kind:
+		applyNumericRules[int64](rules.GetSfixed64(), schema)
+	case protoreflect.Uint32Kind:
+		applyNumericRules[uint32](rules.GetUint32(), schema)
+	case protoreflect.Fixed32Kind:
+		applyNumericRules[uint32](rules.GetFixed32(), schema)
+	case protoreflect.Uint64Kind:
+		applyNumericRules[uint64](rules.GetUint64(), schema)
+	case protoreflect.Fixed64Kind:
+		applyNumericRules[uint64](rules.GetFixed64(), schema)
+	}
+}
+
+// describeBounds describes the numeric keywords of a schema.
+func describeBounds(schema map[string]any) string {
+	var terms []string
+	for _, bound := range []struct{ keyword, text string }{
+		{"const", "equal to"},
+		{"enum", "one of"},
+		{"minimum", "at least"},
+		{"exclusiveMinimum", "greater than"},
+		{"maximum", "at most"},
+		{"exclusiveMaximum", "less than"},
+	} {
+		if v, ok := schema[bound.keyword]; ok {
+			terms = append(terms, fmt.Sprintf("%s %v", bound.text, v))
+		}
+	}
+	constraints, _ := schema["allOf"].([]map[string]any)
+	for _, c := range constraints {
+		if not, ok := c["not"].(map[string]any); ok && not["enum"] != nil {
+			terms = append(terms, fmt.Sprintf("none of %v", not["enum"]))
+		}
+	}
+	return strings.Join(terms, " and ")
+}
+
 // schemaForWellKnownType returns specialized schema for well-known Protobuf types
 func schemaForWellKnownType(md protoreflect.MessageDescriptor) (map[string]any, bool) {
 	fullName := string(md.FullName())
@@ -482,45 +797,115 @@ func isFieldRequired(fd protoreflect.FieldDescriptor) bool {
 	return false
 }
 
-// applyCustomFieldOptions applies mcpgw.v1.field options if present
-func applyCustomFieldOptions(fd protoreflect.FieldDescriptor, schema map[string]any) {
-	opts := fd.Options()
-	if opts == nil {
-		return
+// omitted reports whether a field is left out of the schema, see
+// WithoutServerPopulated.
+func (g *generator) omitted(fd protoreflect.FieldDescriptor) bool {
+	if !g.opts.input {
+		return false
 	}
+	fieldOpts, _ := proto.GetExtension(fd.Options(), mcpgw_v1.E_Field).(*mcpgw_v1.FieldOptions)
+	return fieldOpts.GetServerPopulated()
+}
 
-	// Check if our extension is registered
-	if !proto.HasExtension(opts, mcpgw_v1.E_Field) {
-		return
+// applyCustomFieldOptions applies mcpgw.v1.field options if present, and
+// marks deprecated fields. Examples and defaults must decode as the field.
+func applyCustomFieldOptions(fd protoreflect.FieldDescriptor, schema map[string]any) error {
+	opts := fd.Options()
+	if opts == nil {
+		return nil
 	}
 
 	// Extract our field options
-	fieldOpts, ok := proto.GetExtension(opts, mcpgw_v1.E_Field).(*mcpgw_v1.FieldOptions)
-	if !ok || fieldOpts == nil {
-		return
-	}
+	fieldOpts, _ := proto.GetExtension(opts, mcpgw_v1.E_Field).(*mcpgw_v1.FieldOptions)
 
 	// Add description from our field options
 	if fieldOpts.GetDescription() != "" {
 		schema["description"] = fieldOpts.GetDescription()
 	}
+	if fieldOpts.GetTitle() != "" {
+		schema["title"] = fieldOpts.GetTitle()
+	}
+	if fieldOpts.GetFormat() != "" {
+		schema["format"] = fieldOpts.GetFormat()
+	}
+
+	if fieldOpts.GetReadOnly() && fieldOpts.GetWriteOnly() {
+		return fmt.Errorf("read_only and write_only are exclusive")
+	}
+	if fieldOpts.GetReadOnly() {
+		schema["readOnly"] = true
+	}
+	if fieldOpts.GetWriteOnly() {
+		schema["writeOnly"] = true
+	}
+
+	if fieldOpts.GetDefault() != "" {
+		value, err := fieldValue(fd, fieldOpts.GetDefault())
+		if err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+		schema["default"] = value
+	}
+	if len(fieldOpts.GetExamples()) > 0 {
+		examples := make([]any, 0, len(fieldOpts.GetExamples()))
+		for _, example := range fieldOpts.GetExamples() {
+			value, err := fieldValue(fd, example)
+			if err != nil {
+				return fmt.Errorf("invalid example: %w", err)
+			}
+			examples = append(examples, value)
+		}
+		schema["examples"] = examples
+	}
+
+	if descOpts, ok := opts.(*descriptorpb.FieldOptions); ok && descOpts.GetDeprecated() {
+		schema["deprecated"] = true
+		appendDescription(schema, "Deprecated: avoid setting this field.")
+	}
+	return nil
 }
 
-// applyValidationRules applies buf.validate rules if present
-func applyValidationRules(fd protoreflect.FieldDescriptor, schema map[string]any) {
-	opts := fd.Options()
-	if opts == nil {
-		return
+// fieldValue decodes a value of a field written as JSON, checking that
+// protojson accepts it as the field's value.
+func fieldValue(fd protoreflect.FieldDescriptor, text string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%s is not JSON: %w", text, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%s is not a single JSON value", text)
 	}
 
-	// Check if our extension is registered
-	if !proto.HasExtension(opts, validate.E_Field) {
-		return
+	data, err := json.Marshal(map[string]json.RawMessage{fd.JSONName(): json.RawMessage(text)})
+	if err != nil {
+		return nil, fmt.Errorf("%s is not JSON: %w", text, err)
+	}
+	if err := protojson.Unmarshal(data, dynamicpb.NewMessage(fd.ContainingMessage())); err != nil {
+		return nil, fmt.Errorf("%s is not a value of the field: %w", text, err)
 	}
+	return value, nil
+}
 
-	// Extract our field options
-	fieldOpts, ok := proto.GetExtension(opts, validate.E_Field).(*validate.FieldRules)
-	if !ok || fieldOpts == nil {
+// fieldRules returns the buf.validate rules of a field, or nil.
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	rules, ok := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRules)
+	if !ok {
+		return nil
+	}
+	return rules
+}
+
+// applyValidationRules applies buf.validate rules if present
+func applyValidationRules(fd protoreflect.FieldDescriptor, schema map[string]any) {
+	applyFieldRules(fd, fieldRules(fd), schema)
+}
+
+// applyFieldRules applies the rules of a field, or of the keys, values or
+// items of a field, to a schema.
+func applyFieldRules(fd protoreflect.FieldDescriptor, fieldOpts *validate.FieldRules, schema map[string]any) {
+	if fieldOpts == nil {
 		return
 	}
 
@@ -530,338 +915,218 @@ func applyValidationRules(fd protoreflect.FieldDescriptor, schema map[string]any
 		// schema will be marked as required in the parent object's required array
 	}
 
+	// Container rules apply to the array or object, and the rules of items,
+	// keys and values to their schemas when those are generated
+	switch {
+	case fd.IsMap():
+		applyMapValidationRules(fieldOpts, schema)
+	case fd.IsList():
+		applyRepeatedValidationRules(fieldOpts, schema)
+	default:
+		applyValueRules(fd.Kind(), fieldOpts, schema)
+	}
+}
+
+// applyValueRules applies the rules of a single value of a kind to its schema
+func applyValueRules(kind protoreflect.Kind, fieldOpts *validate.FieldRules, schema map[string]any) {
+	if fieldOpts == nil {
+		return
+	}
+
 	// Process validation rules based on field type
-	switch fd.Kind() {
+	switch kind {
 	case protoreflect.StringKind:
 		applyStringValidationRules(fieldOpts, schema)
-	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
-		applyInt32ValidationRules(fieldOpts, schema)
-	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
-		applyInt64ValidationRules(fieldOpts, schema)
-	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
-		applyUint32ValidationRules(fieldOpts, schema)
-	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-		applyUint64ValidationRules(fieldOpts, schema)
+	case protoreflect.Int32Kind:
+		applyNumericRules[int32](fieldOpts.GetInt32(), schema)
+	case protoreflect.Sint32Kind:
+		applyNumericRules[int32](fieldOpts.GetSint32(), schema)
+	case protoreflect.Sfixed32Kind:
+		applyNumericRules[int32](fieldOpts.GetSfixed32(), schema)
+	case protoreflect.Int64Kind:
+		applyDecimalRules[int64](fieldOpts.GetInt64(), schema)
+	case protoreflect.Sint64Kind:
+		applyDecimalRules[int64](fieldOpts.GetSint64(), schema)
+	case protoreflect.Sfixed64Kind:
+		applyDecimalRules[int64](fieldOpts.GetSfixed64(), schema)
+	case protoreflect.Uint32Kind:
+		applyNumericRules[uint32](fieldOpts.GetUint32(), schema)
+	case protoreflect.Fixed32Kind:
+		applyNumericRules[uint32](fieldOpts.GetFixed32(), schema)
+	case protoreflect.Uint64Kind:
+		applyDecimalRules[uint64](fieldOpts.GetUint64(), schema)
+	case protoreflect.Fixed64Kind:
+		applyDecimalRules[uint64](fieldOpts.GetFixed64(), schema)
 	case protoreflect.FloatKind:
-		applyFloatValidationRules(fieldOpts, schema)
+		applyNumericRules[float32](fieldOpts.GetFloat(), schema)
 	case protoreflect.DoubleKind:
-		applyDoubleValidationRules(fieldOpts, schema)
+		applyNumericRules[float64](fieldOpts.GetDouble(), schema)
 	case protoreflect.BytesKind:
 		applyBytesValidationRules(fieldOpts, schema)
 	case protoreflect.BoolKind:
 		applyBoolValidationRules(fieldOpts, schema)
 	case protoreflect.EnumKind:
-		applyEnumValidationRules(fieldOpts, schema)
-	}
-
-	// If this is a repeated field, apply repeated rules
-	if fd.IsList() && !fd.IsMap() {
-		applyRepeatedValidationRules(fieldOpts, schema)
-	}
-
-	// If this is a map field, apply map rules
-	if fd.IsMap() {
-		applyMapValidationRules(fieldOpts, schema)
+		// Applied by schemaForEnum, which lists the allowed values
 	}
 }
 
-// applyStringValidationRules applies string validation rules to a schema
+// applyStringValidationRules applies string validation rules to a schema.
+// Rules mapping to the same keyword, such as prefix and pattern, are combined
+// through allOf.
 func applyStringValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
 	stringRules := fieldOpts.GetString()
 	if stringRules == nil {
 		return
 	}
 
-	// Handle min_len
+	// Handle len, min_len and max_len
+	if stringRules.Len != nil {
+		addKeyword(schema, "minLength", stringRules.GetLen())
+		addKeyword(schema, "maxLength", stringRules.GetLen())
+	}
 	if stringRules.MinLen != nil {
-		schema["minLength"] = stringRules.GetMinLen()
+		addKeyword(schema, "minLength", stringRules.GetMinLen())
 	}
-
-	// Handle max_len
 	if stringRules.MaxLen != nil {
-		schema["maxLength"] = stringRules.GetMaxLen()
+		addKeyword(schema, "maxLength", stringRules.GetMaxLen())
 	}
 
 	// Handle pattern
 	if stringRules.Pattern != nil {
-		schema["pattern"] = stringRules.GetPattern()
+		addKeyword(schema, "pattern", stringRules.GetPattern())
 	}
 
-	// Handle email format
-	if stringRules.GetEmail() {
-		schema["format"] = "email"
-	}
-
-	// Handle hostname format
-	if stringRules.GetHostname() {
-		schema["format"] = "hostname"
-	}
-
-	// Handle uri format
-	if stringRules.GetUri() {
-		schema["format"] = "uri"
-	}
+	// Handle the well known formats
+	applyStringFormat(stringRules, schema)
 
 	// Handle const
 	if stringRules.Const != nil {
 		schema["const"] = stringRules.GetConst()
 	}
 
-	// Handle in (enum)
+	// Handle in (enum) and not_in
 	if len(stringRules.GetIn()) > 0 {
 		schema["enum"] = stringRules.GetIn()
 	}
+	if len(stringRules.GetNotIn()) > 0 {
+		addConstraint(schema, map[string]any{"not": map[string]any{"enum": stringRules.GetNotIn()}})
+	}
 
-	// Handle prefix
+	// Handle prefix, suffix and contains as patterns
 	if stringRules.Prefix != nil {
-		// JSON Schema doesn't have prefix directly, we could use pattern
-		prefix := stringRules.GetPrefix()
-		schema["pattern"] = fmt.Sprintf("^%s.*", regexp.QuoteMeta(prefix))
+		addKeyword(schema, "pattern", "^"+regexp.QuoteMeta(stringRules.GetPrefix()))
 	}
-
-	// Handle suffix
 	if stringRules.Suffix != nil {
-		// JSON Schema doesn't have suffix directly, we could use pattern
-		suffix := stringRules.GetSuffix()
-		schema["pattern"] = fmt.Sprintf(".*%s$", regexp.QuoteMeta(suffix))
+		addKeyword(schema, "pattern", regexp.QuoteMeta(stringRules.GetSuffix())+"$")
 	}
-
-	// Handle contains
 	if stringRules.Contains != nil {
-		// JSON Schema doesn't have contains for strings directly
-		// We might need a more complex schema for this
-	}
-}
-
-// applyInt32ValidationRules applies int32 validation rules to a schema
-func applyInt32ValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	intRules := fieldOpts.GetInt32()
-	if intRules == nil {
-		return
+		addKeyword(schema, "pattern", regexp.QuoteMeta(stringRules.GetContains()))
 	}
-
-	// Handle const
-	if intRules.Const != nil {
-		schema["const"] = intRules.GetConst()
+	if stringRules.NotContains != nil {
+		addConstraint(schema, notString(map[string]any{"pattern": regexp.QuoteMeta(stringRules.GetNotContains())}))
 	}
 
-	// Handle minimum (gte - greater than or equal)
-	if intRules.GetGte() != 0 {
-		schema["minimum"] = intRules.GetGte()
+	// JSON Schema counts characters, so byte lengths are described in text
+	if stringRules.LenBytes != nil {
+		appendDescription(schema, "Constraint: must be exactly "+byteCount(stringRules.GetLenBytes())+" long in UTF-8")
 	}
-
-	// Handle maximum (lte - less than or equal)
-	if intRules.GetLte() != 0 {
-		schema["maximum"] = intRules.GetLte()
+	if stringRules.MinBytes != nil {
+		appendDescription(schema, "Constraint: must be at least "+byteCount(stringRules.GetMinBytes())+" long in UTF-8")
 	}
-
-	// Handle exclusiveMinimum (gt - greater than)
-	if intRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = intRules.GetGt()
-	}
-
-	// Handle exclusiveMaximum (lt - less than)
-	if intRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = intRules.GetLt()
-	}
-
-	// Handle in (enum)
-	if len(intRules.GetIn()) > 0 {
-		schema["enum"] = intRules.GetIn()
+	if stringRules.MaxBytes != nil {
+		appendDescription(schema, "Constraint: must be at most "+byteCount(stringRules.GetMaxBytes())+" long in UTF-8")
 	}
 }
 
-// applyInt64ValidationRules applies int64 validation rules to a schema
-func applyInt64ValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	intRules := fieldOpts.GetInt64()
-	if intRules == nil {
-		return
-	}
-
-	// Handle const
-	if intRules.Const != nil {
-		schema["const"] = intRules.GetConst()
-	}
-
-	// Handle minimum (gte - greater than or equal)
-	if intRules.GetGte() != 0 {
-		schema["minimum"] = intRules.GetGte()
-	}
-
-	// Handle maximum (lte - less than or equal)
-	if intRules.GetLte() != 0 {
-		schema["maximum"] = intRules.GetLte()
-	}
-
-	// Handle exclusiveMinimum (gt - greater than)
-	if intRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = intRules.GetGt()
-	}
-
-	// Handle exclusiveMaximum (lt - less than)
-	if intRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = intRules.GetLt()
-	}
-
-	// Handle in (enum)
-	if len(intRules.GetIn()) > 0 {
-		schema["enum"] = intRules.GetIn()
-	}
+// numericRules are the rules of the numeric kinds, such as Int32Rules and
+// SFixed64Rules, which share their shape.
+type numericRules[T int32 | int64 | uint32 | uint64 | float32 | float64] interface {
+	HasConst() bool
+	GetConst() T
+	HasGt() bool
+	GetGt() T
+	HasGte() bool
+	GetGte() T
+	HasLt() bool
+	GetLt() T
+	HasLte() bool
+	GetLte() T
+	GetIn() []T
+	GetNotIn() []T
 }
 
-// applyUint32ValidationRules applies uint32 validation rules to a schema
-func applyUint32ValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	uintRules := fieldOpts.GetUint32()
-	if uintRules == nil {
-		return
-	}
-
+// applyNumericRules applies numeric validation rules to a schema. The rules
+// may be nil, as their getters are.
+func applyNumericRules[T int32 | int64 | uint32 | uint64 | float32 | float64](rules numericRules[T], schema map[string]any) {
 	// Handle const
-	if uintRules.Const != nil {
-		schema["const"] = uintRules.GetConst()
+	if rules.HasConst() {
+		schema["const"] = rules.GetConst()
 	}
 
 	// Handle minimum (gte - greater than or equal)
-	if uintRules.GetGte() != 0 {
-		schema["minimum"] = uintRules.GetGte()
+	if rules.HasGte() {
+		schema["minimum"] = rules.GetGte()
 	}
 
 	// Handle maximum (lte - less than or equal)
-	if uintRules.GetLte() != 0 {
-		schema["maximum"] = uintRules.GetLte()
+	if rules.HasLte() {
+		schema["maximum"] = rules.GetLte()
 	}
 
 	// Handle exclusiveMinimum (gt - greater than)
-	if uintRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = uintRules.GetGt()
+	if rules.HasGt() {
+		schema["exclusiveMinimum"] = rules.GetGt()
 	}
 
 	// Handle exclusiveMaximum (lt - less than)
-	if uintRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = uintRules.GetLt()
+	if rules.HasLt() {
+		schema["exclusiveMaximum"] = rules.GetLt()
 	}
 
-	// Handle in (enum)
-	if len(uintRules.GetIn()) > 0 {
-		schema["enum"] = uintRules.GetIn()
+	// Handle in (enum) and not_in
+	if len(rules.GetIn()) > 0 {
+		schema["enum"] = rules.GetIn()
 	}
-}
-
-// applyUint64ValidationRules applies uint64 validation rules to a schema
-func applyUint64ValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	uintRules := fieldOpts.GetUint64()
-	if uintRules == nil {
-		return
-	}
-
-	// Handle const
-	if uintRules.Const != nil {
-		schema["const"] = uintRules.GetConst()
-	}
-
-	// Handle minimum (gte - greater than or equal)
-	if uintRules.GetGte() != 0 {
-		schema["minimum"] = uintRules.GetGte()
-	}
-
-	// Handle maximum (lte - less than or equal)
-	if uintRules.GetLte() != 0 {
-		schema["maximum"] = uintRules.GetLte()
-	}
-
-	// Handle exclusiveMinimum (gt - greater than)
-	if uintRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = uintRules.GetGt()
-	}
-
-	// Handle exclusiveMaximum (lt - less than)
-	if uintRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = uintRules.GetLt()
-	}
-
-	// Handle in (enum)
-	if len(uintRules.GetIn()) > 0 {
-		schema["enum"] = uintRules.GetIn()
+	if len(rules.GetNotIn()) > 0 {
+		addConstraint(schema, map[string]any{"not": map[string]any{"enum": rules.GetNotIn()}})
 	}
 }
 
-// applyFloatValidationRules applies float validation rules to a schema
-func applyFloatValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	floatRules := fieldOpts.GetFloat()
-	if floatRules == nil {
-		return
-	}
-
-	// Handle const
-	if floatRules.Const != nil {
-		schema["const"] = floatRules.GetConst()
-	}
-
-	// Handle minimum (gte - greater than or equal)
-	if floatRules.GetGte() != 0 {
-		schema["minimum"] = floatRules.GetGte()
-	}
-
-	// Handle maximum (lte - less than or equal)
-	if floatRules.GetLte() != 0 {
-		schema["maximum"] = floatRules.GetLte()
-	}
-
-	// Handle exclusiveMinimum (gt - greater than)
-	if floatRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = floatRules.GetGt()
-	}
-
-	// Handle exclusiveMaximum (lt - less than)
-	if floatRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = floatRules.GetLt()
-	}
-
-	// Handle in (enum)
-	if len(floatRules.GetIn()) > 0 {
-		schema["enum"] = floatRules.GetIn()
-	}
-}
-
-// applyDoubleValidationRules applies double validation rules to a schema
-func applyDoubleValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	doubleRules := fieldOpts.GetDouble()
-	if doubleRules == nil {
-		return
-	}
-
-	// Handle const
-	if doubleRules.Const != nil {
-		schema["const"] = doubleRules.GetConst()
-	}
-
-	// Handle minimum (gte - greater than or equal)
-	if doubleRules.GetGte() != 0 {
-		schema["minimum"] = doubleRules.GetGte()
+// applyDecimalRules applies the rules of a 64-bit integer kind, whose values
+// are decimal strings in JSON. Allowed values become strings, and bounds,
+// which JSON Schema can't express on strings, are described.
+func applyDecimalRules[T int64 | uint64](rules numericRules[T], schema map[string]any) {
+	decimal := func(values []T) []string {
+		rv := make([]string, 0, len(values))
+		for _, v := range values {
+			rv = append(rv, fmt.Sprint(v))
+		}
+		return rv
 	}
 
-	// Handle maximum (lte - less than or equal)
-	if doubleRules.GetLte() != 0 {
-		schema["maximum"] = doubleRules.GetLte()
+	if rules.HasConst() {
+		schema["const"] = fmt.Sprint(rules.GetConst())
 	}
-
-	// Handle exclusiveMinimum (gt - greater than)
-	if doubleRules.GetGt() != 0 {
-		schema["exclusiveMinimum"] = doubleRules.GetGt()
+	if len(rules.GetIn()) > 0 {
+		schema["enum"] = decimal(rules.GetIn())
 	}
-
-	// Handle exclusiveMaximum (lt - less than)
-	if doubleRules.GetLt() != 0 {
-		schema["exclusiveMaximum"] = doubleRules.GetLt()
+	if len(rules.GetNotIn()) > 0 {
+		addConstraint(schema, map[string]any{"not": map[string]any{"enum": decimal(rules.GetNotIn())}})
 	}
 
-	// Handle in (enum)
-	if len(doubleRules.GetIn()) > 0 {
-		schema["enum"] = doubleRules.GetIn()
+	bounds := map[string]any{}
+	applyNumericRules(rules, bounds)
+	delete(bounds, "const")
+	delete(bounds, "enum")
+	delete(bounds, "allOf")
+	if text := describeBounds(bounds); text != "" {
+		appendDescription(schema, "Constraint: must be "+text)
 	}
 }
 
-// applyBytesValidationRules applies bytes validation rules to a schema
+// applyBytesValidationRules applies bytes validation rules to a schema. The
+// value is base64 encoded in JSON, so rules on the decoded bytes other than
+// const and in are described in text.
 func applyBytesValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
 	bytesRules := fieldOpts.GetBytes()
 	if bytesRules == nil {
@@ -873,20 +1138,41 @@ func applyBytesValidationRules(fieldOpts *validate.FieldRules, schema map[string
 		schema["const"] = bytesRules.GetConst()
 	}
 
-	// Handle min_len
-	if bytesRules.MinLen != nil {
-		schema["minByteLength"] = bytesRules.GetMinLen()
+	// Handle in (enum) and not_in
+	if len(bytesRules.GetIn()) > 0 {
+		schema["enum"] = bytesRules.GetIn()
+	}
+	if len(bytesRules.GetNotIn()) > 0 {
+		addConstraint(schema, map[string]any{"not": map[string]any{"enum": bytesRules.GetNotIn()}})
 	}
 
-	// Handle max_len
+	// Handle len, min_len and max_len
+	if bytesRules.Len != nil {
+		appendDescription(schema, "Constraint: must be exactly "+byteCount(bytesRules.GetLen())+" long")
+	}
+	if bytesRules.MinLen != nil {
+		appendDescription(schema, "Constraint: must be at least "+byteCount(bytesRules.GetMinLen())+" long")
+	}
 	if bytesRules.MaxLen != nil {
-		schema["maxByteLength"] = bytesRules.GetMaxLen()
+		appendDescription(schema, "Constraint: must be at most "+byteCount(bytesRules.GetMaxLen())+" long")
 	}
 
-	// Handle pattern
+	// Handle pattern, prefix, suffix and contains
 	if bytesRules.Pattern != nil {
-		// Not directly applicable to bytes in JSON Schema
+		appendDescription(schema, fmt.Sprintf("Constraint: decoded bytes must match the pattern %q", bytesRules.GetPattern()))
+	}
+	if bytesRules.Prefix != nil {
+		appendDescription(schema, fmt.Sprintf("Constraint: decoded bytes must start with %q", bytesRules.GetPrefix()))
+	}
+	if bytesRules.Suffix != nil {
+		appendDescription(schema, fmt.Sprintf("Constraint: decoded bytes must end with %q", bytesRules.GetSuffix()))
 	}
+	if bytesRules.Contains != nil {
+		appendDescription(schema, fmt.Sprintf("Constraint: decoded bytes must contain %q", bytesRules.GetContains()))
+	}
+
+	// Handle the well known formats
+	applyBytesFormat(bytesRules, schema)
 }
 
 // applyBoolValidationRules applies bool validation rules to a schema
@@ -902,34 +1188,6 @@ func applyBoolValidationRules(fieldOpts *validate.FieldRules, schema map[string]
 	}
 }
 
-// applyEnumValidationRules applies enum validation rules to a schema
-func applyEnumValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
-	enumRules := fieldOpts.GetEnum()
-	if enumRules == nil {
-		return
-	}
-
-	// Handle const
-	if enumRules.Const != nil {
-		schema["const"] = enumRules.GetConst()
-	}
-
-	// Handle defined_only
-	if enumRules.DefinedOnly != nil && enumRules.GetDefinedOnly() {
-		// Already handled by the enum schema generator
-	}
-
-	// Handle in
-	if len(enumRules.GetIn()) > 0 {
-		schema["enum"] = enumRules.GetIn()
-	}
-
-	// Handle not_in
-	if len(enumRules.GetNotIn()) > 0 {
-		// Not directly mappable to JSON Schema without more complex schema
-	}
-}
-
 // applyRepeatedValidationRules applies validation rules for repeated fields
 func applyRepeatedValidationRules(fieldOpts *validate.FieldRules, schema map[string]any) {
 	repeatedRules := fieldOpts.GetRepeated()
@@ -980,6 +1238,24 @@ func applyNullability(fd protoreflect.FieldDescriptor, schema map[string]any) {
 
 	// For proto3 optional fields or proto2 optional fields, allow null
 	if fd.HasPresence() {
+		// A referenced message schema can't be extended with a type
+		if ref, ok := schema["$ref"]; ok {
+			delete(schema, "$ref")
+			schema["anyOf"] = []map[string]any{
+				{"$ref": ref},
+				{"type": "null"},
+			}
+		}
+
+		// null matches every oneof alternative of a message that requires a
+		// member, so it is an alternative to the whole message instead
+		if fd.Message() != nil && (schema["oneOf"] != nil || schema["allOf"] != nil) {
+			msg := maps.Clone(schema)
+			clear(schema)
+			schema["anyOf"] = []map[string]any{msg, {"type": "null"}}
+			return
+		}
+
 		// If schema already has a type field
 		if typeVal, ok := schema["type"]; ok {
 			// If type is already an array of types
@@ -1017,5 +1293,28 @@ func applyNullability(fd protoreflect.FieldDescriptor, schema map[string]any) {
 				schema["oneOf"] = append(oneOf, map[string]any{"type": "null"})
 			}
 		}
+
+		// If schema lists enum values, add null to them
+		if enum, ok := enumWithNull(schema["enum"]); ok {
+			schema["enum"] = enum
+		}
+	}
+}
+
+// enumWithNull returns the values of an enum keyword with null added, or
+// false if there are none or null is one of them.
+func enumWithNull(enum any) ([]any, bool) {
+	v := reflect.ValueOf(enum)
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	values := make([]any, 0, v.Len()+1)
+	for i := range v.Len() {
+		value := v.Index(i).Interface()
+		if value == nil {
+			return nil, false
+		}
+		values = append(values, value)
 	}
+	return append(values, nil), true
 }
@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Option configures GenerateJSONSchema.
+type Option func(*options)
+
+type options struct {
+	defs           bool
+	inlineDepth    int
+	dialect        Dialect
+	enumNumbers    bool
+	input          bool
+	streamMessages bool
+}
+
+// WithDefs puts the schema of each nested message once under "$defs", keyed
+// by the message's full name, and refers to it with "$ref". Recursive
+// messages keep their full structure.
+func WithDefs() Option {
+	return func(o *options) {
+		o.defs = true
+	}
+}
+
+// WithInlineDepth limits how many levels of nested messages are expanded when
+// messages are inlined, which is the default. Recursive messages are expanded
+// until the limit is reached. Deeper messages, and with no limit recursive
+// messages, are described as any object.
+func WithInlineDepth(depth int) Option {
+	return func(o *options) {
+		o.inlineDepth = depth
+	}
+}
+
+// WithEnumNumbers accepts the numbers of enum values as well as their names.
+func WithEnumNumbers() Option {
+	return func(o *options) {
+		o.enumNumbers = true
+	}
+}
+
+// WithoutServerPopulated leaves out the fields marked server_populated, which
+// tool arguments must not set, for the input schemas of tools.
+func WithoutServerPopulated() Option {
+	return func(o *options) {
+		o.input = true
+	}
+}
+
+// WithStreamMessages describes a stream of messages, for the tools of
+// streaming methods: an object whose required "messages" property is an array
+// of them.
+func WithStreamMessages() Option {
+	return func(o *options) {
+		o.streamMessages = true
+	}
+}
+
+// generator holds the state of a single GenerateJSONSchema call.
+type generator struct {
+	opts options
+
+	// visited and depth track the path of inlined messages.
+	visited map[protoreflect.FullName]bool
+	depth   int
+
+	// defs holds the schemas of referenced messages, by full name.
+	defs map[string]any
+}
+
+func newGenerator(opts []Option) *generator {
+	g := &generator{
+		visited: make(map[protoreflect.FullName]bool),
+		defs:    make(map[string]any),
+	}
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+	return g
+}
+
+// anyObjectSchema describes a message that is not expanded.
+func anyObjectSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+	}
+}
+
+// inlineMessage generates the schema of a nested message in place.
+func inlineMessage(md protoreflect.MessageDescriptor, g *generator) (map[string]any, error) {
+	name := md.FullName()
+	if g.opts.inlineDepth > 0 {
+		if g.depth > g.opts.inlineDepth {
+			return anyObjectSchema(), nil
+		}
+	} else if g.visited[name] {
+		// For recursive types, return a generic object schema
+		return anyObjectSchema(), nil
+	}
+
+	prev := g.visited[name]
+	g.visited[name] = true
+	g.depth++
+	defer func() {
+		g.visited[name] = prev
+		g.depth--
+	}()
+	return schemaForMessage(md, g)
+}
+
+// refMessage generates the schema of a nested message under $defs and
+// returns a reference to it.
+func refMessage(md protoreflect.MessageDescriptor, g *generator) (map[string]any, error) {
+	name := string(md.FullName())
+	if _, ok := g.defs[name]; !ok {
+		// Reserve the name first, so recursive references stop here
+		g.defs[name] = nil
+		def, err := schemaForMessage(md, g)
+		if err != nil {
+			return nil, err
+		}
+		delete(def, "$schema")
+		g.defs[name] = def
+	}
+	return map[string]any{"$ref": "#/$defs/" + name}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	pgs "github.com/lyft/protoc-gen-star/v2"
 	pgsgo "github.com/lyft/protoc-gen-star/v2/lang/go"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 func New() pgs.Module {
@@ -23,6 +24,11 @@ const version = "0.1.0"
 type Module struct {
 	*pgs.ModuleBase
 	ctx pgsgo.Context
+
+	// staticSchema is set when schemas are generated by the plugin, from files.
+	staticSchema bool
+	files        *protoregistry.Files
+	schemaOpts   schemaOptions
 }
 
 var _ pgs.Module = (*Module)(nil)
@@ -37,12 +43,33 @@ func (m *Module) Name() string {
 }
 
 func (m *Module) Execute(targets map[string]pgs.File, pkgs map[string]pgs.Package) []pgs.Artifact {
+	if err := m.initSchemas(pkgs); err != nil {
+		m.Logf("couldn't load schemas: %s", err)
+		m.Fail("code generation failed")
+		return m.Artifacts()
+	}
 	for _, f := range targets {
 		m.processFile(m.ctx, f)
 	}
 	return m.Artifacts()
 }
 
+func (m *Module) initSchemas(pkgs map[string]pgs.Package) error {
+	static, err := m.staticSchemas()
+	if err != nil {
+		return err
+	}
+	m.staticSchema = static
+	if m.schemaOpts, err = m.schemaOptions(); err != nil {
+		return err
+	}
+	if !static {
+		return nil
+	}
+	m.files, err = newFileRegistry(pkgs)
+	return err
+}
+
 func (m *Module) processFile(ctx pgsgo.Context, f pgs.File) {
 	out := bytes.Buffer{}
 	rendered, err := m.applyTemplate(ctx, &out, f)
@@ -72,13 +99,14 @@ func (m *Module) applyTemplate(ctx pgsgo.Context, w *bytes.Buffer, f pgs.File) (
 	headerBuf := &bytes.Buffer{}
 	bodyBuf := &bytes.Buffer{}
 
+	comments := newCommentCollector()
 	services := f.Services()
 	for _, service := range services {
 		sopt := getServiceOptions(service)
 		if sopt != nil && !sopt.GetEnabled() {
 			continue
 		}
-		err := m.renderService(ctx, bodyBuf, f, service, ix)
+		err := m.renderService(ctx, bodyBuf, f, service, ix, comments)
 		if err != nil {
 			return false, err
 		}
@@ -88,6 +116,14 @@ func (m *Module) applyTemplate(ctx pgsgo.Context, w *bytes.Buffer, f pgs.File) (
 		return false, nil
 	}
 
+	// Static schemas already include the comments.
+	if !m.staticSchema {
+		err := m.renderComments(bodyBuf, comments, ix)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	err := m.renderHeader(ctx, headerBuf, f, ix)
 	if err != nil {
 		return false, err
@@ -0,0 +1,127 @@
+package mcpgw
+
+import (
+	"cmp"
+	"io"
+	"slices"
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+
+	"github.com/ductone/protoc-gen-mcpgw/internal/jsonschema"
+)
+
+type commentTemplateContext struct {
+	Name    string
+	Comment string
+}
+
+type commentsTemplateContext struct {
+	Comments []*commentTemplateContext
+}
+
+// leadingComment returns the cleaned leading comment of an entity, if the
+// source info of its file is available.
+func leadingComment(e pgs.Entity) string {
+	info := e.SourceCodeInfo()
+	if info == nil {
+		return ""
+	}
+	return jsonschema.CleanComment(info.LeadingComments())
+}
+
+// methodDescription is the tool description of a method: its description
+// option, or its leading comment.
+func methodDescription(method pgs.Method) string {
+	if desc := getMethodOptions(method).GetDescription(); desc != "" {
+		return desc
+	}
+	return leadingComment(method)
+}
+
+// commentCollector gathers the leading comments of the messages, fields and
+// enum values reachable from the exposed methods, which the schema generator
+// can't read at runtime.
+type commentCollector struct {
+	seen     map[string]bool
+	comments map[string]string
+}
+
+func newCommentCollector() *commentCollector {
+	return &commentCollector{
+		seen:     make(map[string]bool),
+		comments: make(map[string]string),
+	}
+}
+
+func (cc *commentCollector) addMethod(method pgs.Method) {
+	cc.addMessage(method.Input())
+	cc.addMessage(method.Output())
+}
+
+func (cc *commentCollector) addMessage(msg pgs.Message) {
+	name := fullName(msg)
+	if cc.seen[name] {
+		return
+	}
+	cc.seen[name] = true
+	cc.add(name, leadingComment(msg))
+
+	for _, field := range msg.Fields() {
+		cc.add(fullName(field), leadingComment(field))
+
+		ft := field.Type()
+		switch {
+		case ft.IsEmbed():
+			cc.addMessage(ft.Embed())
+		case ft.IsEnum():
+			cc.addEnum(ft.Enum())
+		case ft.IsRepeated() || ft.IsMap():
+			if el := ft.Element(); el.IsEmbed() {
+				cc.addMessage(el.Embed())
+			} else if el.IsEnum() {
+				cc.addEnum(el.Enum())
+			}
+		}
+	}
+}
+
+func (cc *commentCollector) addEnum(enum pgs.Enum) {
+	name := fullName(enum)
+	if cc.seen[name] {
+		return
+	}
+	cc.seen[name] = true
+
+	// Enum values are scoped to the parent of their enum
+	for _, value := range enum.Values() {
+		cc.add(strings.TrimPrefix(fullName(enum.Parent())+"."+value.Name().String(), "."), leadingComment(value))
+	}
+}
+
+func (cc *commentCollector) add(name string, comment string) {
+	if comment != "" {
+		cc.comments[name] = comment
+	}
+}
+
+// fullName is the protoreflect full name of an entity, without the leading dot.
+func fullName(e pgs.Entity) string {
+	return strings.TrimPrefix(e.FullyQualifiedName(), ".")
+}
+
+func (module *Module) renderComments(w io.Writer, cc *commentCollector, ix *importTracker) error {
+	if len(cc.comments) == 0 {
+		return nil
+	}
+	ix.MCPGWV1Schema = true
+
+	c := &commentsTemplateContext{}
+	for name, comment := range cc.comments {
+		c.Comments = append(c.Comments, &commentTemplateContext{Name: name, Comment: comment})
+	}
+	slices.SortFunc(c.Comments, func(a, b *commentTemplateContext) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return templates["comments.tmpl"].Execute(w, c)
+}
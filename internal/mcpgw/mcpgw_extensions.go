@@ -23,6 +23,12 @@ func getMethodOptions(m pgs.Method) *mcpgw_v1.MethodOptions {
 	return mopt
 }
 
+// hasMethodOptions reports whether the method sets the (mcpgw.v1.method) option.
+func hasMethodOptions(m pgs.Method) bool {
+	ok, err := m.Extension(mcpgw_v1.E_Method, &mcpgw_v1.MethodOptions{})
+	return err == nil && ok
+}
+
 func getMessageOptions(m pgs.Message) *mcpgw_v1.MessageOptions {
 	mopt := &mcpgw_v1.MessageOptions{}
 	_, err := m.Extension(mcpgw_v1.E_Message, mopt)
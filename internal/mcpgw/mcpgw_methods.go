@@ -8,18 +8,32 @@ import (
 	pgs "github.com/lyft/protoc-gen-star/v2"
 	pgsgo "github.com/lyft/protoc-gen-star/v2/lang/go"
 
+	"github.com/ductone/protoc-gen-mcpgw/internal/jsonschema"
 	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
 )
 
 type methodTemplateContext struct {
 	mcpgw_v1.MethodDesc
-	MethodHandlerName      string
-	DecoderHandlerName     string
-	InputSchemaHandlerName string
-	RequestType            string
-	ServerName             string
-	MethodName             string
-	FullMethodName         string
+	MethodHandlerName       string
+	DecoderHandlerName      string
+	InputSchemaHandlerName  string
+	OutputSchemaHandlerName string
+	RequestType             string
+	ResponseType            string
+	ServerName              string
+	MethodName              string
+	FullMethodName          string
+
+	// InputSchemaLiteral and OutputSchemaLiteral hold the JSON of static schemas.
+	InputSchemaLiteral  string
+	OutputSchemaLiteral string
+	// SchemaArgs are the extra arguments of runtime schema generation.
+	SchemaArgs string
+	// LenientArguments makes the decoder repair arguments, see argumentDecoding.
+	LenientArguments bool
+	// MessagesResult is set when the result of the tool holds every streamed
+	// message, under "messages".
+	MessagesResult bool
 }
 
 func (module *Module) methodContext(ctx pgsgo.Context, w io.Writer, f pgs.File, service pgs.Service, method pgs.Method, ix *importTracker) (*methodTemplateContext, error) {
@@ -28,26 +42,41 @@ func (module *Module) methodContext(ctx pgsgo.Context, w io.Writer, f pgs.File,
 		return nil, fmt.Errorf("apigw: methodContext: failed to extract Method extension from '%s' (on enabled service '%s')", method.FullyQualifiedName(), service.FullyQualifiedName())
 	}
 
-	ix.Protojson = true
+	if method.ClientStreaming() && !mext.GetAllowClientStreaming() {
+		return nil, fmt.Errorf("apigw: methodContext: '%s' is a client-streaming method, which can't be exposed as a tool unless (mcpgw.v1.method).allow_client_streaming is set", method.FullyQualifiedName())
+	}
+
+	toolName, err := module.toolName(service, method)
+	if err != nil {
+		return nil, fmt.Errorf("apigw: methodContext: '%s': %w", method.FullyQualifiedName(), err)
+	}
+
 	ix.MCPGWV1 = true
-	ix.MCPGWV1Schema = true
 
 	serviceShortName := strings.TrimSuffix(ctx.Name(service).String(), "Server")
 	methodFullName := fmt.Sprintf("%s_%s_FullMethodName", serviceShortName, ctx.Name(method).String())
 	ix.Context = true
 	ix.Proto = true
-	ix.Protojson = true
 	ix.GRPC = true
 
+	streamResult := mext.GetStreamResult()
+	if streamResult == mcpgw_v1.StreamResult_STREAM_RESULT_UNSPECIFIED {
+		streamResult = mcpgw_v1.StreamResult_STREAM_RESULT_ALL
+	}
+
 	rv := &methodTemplateContext{
 		MethodDesc: mcpgw_v1.MethodDesc{
 			Method:        methodFullName,
+			Name:          toolName,
 			Title:         mext.GetTitle(),
-			Description:   mext.GetDescription(),
+			Description:   methodDescription(method),
 			ReadOnlyHint:  mext.GetReadOnlyHint(),
 			Destructive:   mext.GetDestructiveHint(),
 			Idempotent:    mext.GetIdempotentHint(),
 			OpenWorldHint: mext.GetOpenWorldHint(),
+			ServerStreams: method.ServerStreaming(),
+			ClientStreams: method.ClientStreaming(),
+			StreamResult:  streamResult,
 		},
 		ServerName:     ctx.ServerName(service).String(),
 		MethodName:     ctx.Name(method).String(),
@@ -64,7 +93,47 @@ func (module *Module) methodContext(ctx pgsgo.Context, w io.Writer, f pgs.File,
 			serviceShortName,
 			ctx.Name(method).String(),
 		),
-		RequestType: ctx.Name(method.Input()).String(),
+		OutputSchemaHandlerName: fmt.Sprintf("_%s_%s_MCPGW_OutputSchema",
+			serviceShortName,
+			ctx.Name(method).String(),
+		),
+		RequestType:      ctx.Name(method.Input()).String(),
+		ResponseType:     ctx.Name(method.Output()).String(),
+		LenientArguments: argumentDecoding(service, method) == mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_LENIENT,
+		MessagesResult:   method.ServerStreaming() && streamResult != mcpgw_v1.StreamResult_STREAM_RESULT_LAST,
+	}
+
+	if !module.staticSchema {
+		ix.MCPGWV1Schema = true
+		rv.SchemaArgs = module.schemaOpts.runtimeArgs()
+		return rv, nil
+	}
+	// Streamed messages are described together, under "messages"
+	inputOpts := []jsonschema.Option{jsonschema.WithoutServerPopulated()}
+	if method.ClientStreaming() {
+		inputOpts = append(inputOpts, jsonschema.WithStreamMessages())
+	}
+	var outputOpts []jsonschema.Option
+	if rv.MessagesResult {
+		outputOpts = append(outputOpts, jsonschema.WithStreamMessages())
+	}
+	if rv.InputSchemaLiteral, err = module.schemaLiteral(method.Input(), inputOpts...); err != nil {
+		return nil, fmt.Errorf("apigw: methodContext: '%s': input schema: %w", method.FullyQualifiedName(), err)
+	}
+	if rv.OutputSchemaLiteral, err = module.schemaLiteral(method.Output(), outputOpts...); err != nil {
+		return nil, fmt.Errorf("apigw: methodContext: '%s': output schema: %w", method.FullyQualifiedName(), err)
 	}
 	return rv, nil
 }
+
+// argumentDecoding returns the method's argument_decoding, falling back to
+// the service's, and then to strict decoding.
+func argumentDecoding(service pgs.Service, method pgs.Method) mcpgw_v1.ArgumentDecoding {
+	if rv := getMethodOptions(method).GetArgumentDecoding(); rv != mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED {
+		return rv
+	}
+	if rv := getServiceOptions(service).GetArgumentDecoding(); rv != mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED {
+		return rv
+	}
+	return mcpgw_v1.ArgumentDecoding_ARGUMENT_DECODING_STRICT
+}
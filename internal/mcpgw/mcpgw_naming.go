@@ -0,0 +1,45 @@
+package mcpgw
+
+import (
+	"fmt"
+	"regexp"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+)
+
+// The tool_naming plugin parameter selects how tool names are derived from methods:
+//
+//	service_qualified (default): BookstoreService_GetBook
+//	snake_case:                  get_book
+//	camel_case:                  getBook
+const (
+	toolNamingParam            = "tool_naming"
+	toolNamingServiceQualified = "service_qualified"
+	toolNamingSnakeCase        = "snake_case"
+	toolNamingCamelCase        = "camel_case"
+)
+
+// validToolName is the tool name format accepted by MCP clients.
+var validToolName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+func (m *Module) toolName(service pgs.Service, method pgs.Method) (string, error) {
+	name := getMethodOptions(method).GetName()
+	if name == "" {
+		switch style := m.Parameters().StrDefault(toolNamingParam, toolNamingServiceQualified); style {
+		case toolNamingServiceQualified:
+			name = fmt.Sprintf("%s_%s", service.Name().String(), method.Name().String())
+		case toolNamingSnakeCase:
+			name = method.Name().LowerSnakeCase().String()
+		case toolNamingCamelCase:
+			name = method.Name().LowerCamelCase().String()
+		default:
+			return "", fmt.Errorf("invalid %s parameter '%s': must be one of %s, %s or %s",
+				toolNamingParam, style, toolNamingServiceQualified, toolNamingSnakeCase, toolNamingCamelCase)
+		}
+	}
+	name = getServiceOptions(service).GetToolPrefix() + name
+	if !validToolName.MatchString(name) {
+		return "", fmt.Errorf("tool name '%s' must match %s", name, validToolName.String())
+	}
+	return name, nil
+}
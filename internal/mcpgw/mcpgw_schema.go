@@ -0,0 +1,158 @@
+package mcpgw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/ductone/protoc-gen-mcpgw/internal/jsonschema"
+)
+
+// The schema plugin parameter selects where tool schemas are generated:
+//
+//	static (default): by the plugin, embedded in the generated code as JSON
+//	runtime:          from the message descriptors when the tools are listed
+//
+// Static schemas include the descriptions taken from source comments, and
+// keep the schema generator out of the server binary.
+//
+// Nested messages are inlined, unless schema_refs=true puts them under $defs.
+// schema_inline_depth=N limits the expansion of inlined messages.
+// schema_dialect selects the dialect of a model provider, such as
+// openai-strict or gemini, instead of draft-2020-12.
+// schema_enum_numbers=true accepts enum values by number as well as by name.
+const (
+	schemaParam   = "schema"
+	schemaStatic  = "static"
+	schemaRuntime = "runtime"
+
+	schemaRefsParam        = "schema_refs"
+	schemaInlineDepthParam = "schema_inline_depth"
+	schemaDialectParam     = "schema_dialect"
+	schemaEnumNumbersParam = "schema_enum_numbers"
+)
+
+// schemaOptions holds the schema generation options set by parameters.
+type schemaOptions struct {
+	refs        bool
+	inlineDepth int
+	dialect     jsonschema.Dialect
+	enumNumbers bool
+}
+
+func (m *Module) schemaOptions() (schemaOptions, error) {
+	var rv schemaOptions
+	var err error
+	if rv.refs, err = m.Parameters().Bool(schemaRefsParam); err != nil {
+		return rv, fmt.Errorf("invalid %s parameter: %w", schemaRefsParam, err)
+	}
+	if rv.inlineDepth, err = m.Parameters().Int(schemaInlineDepthParam); err != nil {
+		return rv, fmt.Errorf("invalid %s parameter: %w", schemaInlineDepthParam, err)
+	}
+	if rv.inlineDepth < 0 {
+		return rv, fmt.Errorf("invalid %s parameter: must not be negative", schemaInlineDepthParam)
+	}
+	dialect := m.Parameters().StrDefault(schemaDialectParam, string(jsonschema.DialectDraft202012))
+	if rv.dialect, err = jsonschema.ParseDialect(dialect); err != nil {
+		return rv, fmt.Errorf("invalid %s parameter: %w", schemaDialectParam, err)
+	}
+	if rv.enumNumbers, err = m.Parameters().Bool(schemaEnumNumbersParam); err != nil {
+		return rv, fmt.Errorf("invalid %s parameter: %w", schemaEnumNumbersParam, err)
+	}
+	return rv, nil
+}
+
+func (o schemaOptions) jsonschemaOptions() []jsonschema.Option {
+	var rv []jsonschema.Option
+	if o.refs {
+		rv = append(rv, jsonschema.WithDefs())
+	}
+	if o.inlineDepth > 0 {
+		rv = append(rv, jsonschema.WithInlineDepth(o.inlineDepth))
+	}
+	if o.dialect != jsonschema.DialectDraft202012 {
+		rv = append(rv, jsonschema.WithDialect(o.dialect))
+	}
+	if o.enumNumbers {
+		rv = append(rv, jsonschema.WithEnumNumbers())
+	}
+	return rv
+}
+
+// runtimeArgs are the arguments passing the options to mcpgw_schema.MustGenerateSchema.
+func (o schemaOptions) runtimeArgs() string {
+	var rv string
+	if o.refs {
+		rv += ", mcpgw_schema.WithDefs()"
+	}
+	if o.inlineDepth > 0 {
+		rv += fmt.Sprintf(", mcpgw_schema.WithInlineDepth(%d)", o.inlineDepth)
+	}
+	if o.dialect != jsonschema.DialectDraft202012 {
+		rv += fmt.Sprintf(", mcpgw_schema.WithDialect(%q)", o.dialect)
+	}
+	if o.enumNumbers {
+		rv += ", mcpgw_schema.WithEnumNumbers()"
+	}
+	return rv
+}
+
+func (m *Module) staticSchemas() (bool, error) {
+	switch mode := m.Parameters().StrDefault(schemaParam, schemaStatic); mode {
+	case schemaStatic:
+		return true, nil
+	case schemaRuntime:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid %s parameter '%s': must be %s or %s", schemaParam, mode, schemaStatic, schemaRuntime)
+	}
+}
+
+// newFileRegistry builds the descriptors of every file in the request,
+// including their source info, so schemas can be generated by the plugin.
+func newFileRegistry(pkgs map[string]pgs.Package) (*protoregistry.Files, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files() {
+			fds.File = append(fds.File, f.Descriptor())
+		}
+	}
+	return protodesc.NewFiles(fds)
+}
+
+// schemaLiteral generates the schema of a message, formatted as a Go string literal.
+func (m *Module) schemaLiteral(msg pgs.Message, opts ...jsonschema.Option) (string, error) {
+	d, err := m.files.FindDescriptorByName(protoreflect.FullName(fullName(msg)))
+	if err != nil {
+		return "", err
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return "", fmt.Errorf("'%s' is not a message", msg.FullyQualifiedName())
+	}
+	schema, err := jsonschema.GenerateJSONSchema(md, append(m.schemaOpts.jsonschemaOptions(), opts...)...)
+	if err != nil {
+		return "", fmt.Errorf("generating schema of '%s': %w", msg.FullyQualifiedName(), err)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(schema); err != nil {
+		return "", err
+	}
+	data := strings.TrimSuffix(buf.String(), "\n")
+	if strings.Contains(data, "`") {
+		return strconv.Quote(data), nil
+	}
+	return "`" + data + "`", nil
+}
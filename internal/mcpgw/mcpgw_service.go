@@ -6,6 +6,8 @@ import (
 
 	pgs "github.com/lyft/protoc-gen-star/v2"
 	pgsgo "github.com/lyft/protoc-gen-star/v2/lang/go"
+
+	mcpgw_v1 "github.com/ductone/protoc-gen-mcpgw/mcpgw/v1"
 )
 
 type serviceTemplateContext struct {
@@ -14,7 +16,7 @@ type serviceTemplateContext struct {
 	Methods            []*methodTemplateContext
 }
 
-func (module *Module) renderService(ctx pgsgo.Context, w io.Writer, f pgs.File, in pgs.Service, ix *importTracker) error {
+func (module *Module) renderService(ctx pgsgo.Context, w io.Writer, f pgs.File, in pgs.Service, ix *importTracker, comments *commentCollector) error {
 	ix.MCPGWV1 = true
 
 	c := &serviceTemplateContext{
@@ -24,7 +26,14 @@ func (module *Module) renderService(ctx pgsgo.Context, w io.Writer, f pgs.File,
 			in.Name().String(),
 		),
 	}
+	toolNames := make(map[string]string)
 	for _, method := range in.Methods() {
+		if !methodExposed(in, method) {
+			continue
+		}
+		if methodDescription(method) == "" {
+			module.Logf("warning: method '%s' is exposed as a tool without a description", method.FullyQualifiedName())
+		}
 		methodCtx, err := module.methodContext(ctx, w, f, in, method, ix)
 		if err != nil {
 			return fmt.Errorf("method generation failed [%st: %w", method.FullyQualifiedName(), err)
@@ -32,8 +41,27 @@ func (module *Module) renderService(ctx pgsgo.Context, w io.Writer, f pgs.File,
 		if methodCtx == nil {
 			continue
 		}
+		if other, ok := toolNames[methodCtx.Name]; ok {
+			return fmt.Errorf("tool name '%s' of '%s' is already used by '%s'", methodCtx.Name, method.FullyQualifiedName(), other)
+		}
+		toolNames[methodCtx.Name] = method.FullyQualifiedName()
+		comments.addMethod(method)
 		c.Methods = append(c.Methods, methodCtx)
 	}
 
 	return templates["service.tmpl"].Execute(w, c)
 }
+
+// methodExposed applies the service's method_exposure policy and the
+// method's exclude option.
+func methodExposed(service pgs.Service, method pgs.Method) bool {
+	if getMethodOptions(method).GetExclude() {
+		return false
+	}
+	switch getServiceOptions(service).GetMethodExposure() {
+	case mcpgw_v1.MethodExposure_METHOD_EXPOSURE_ANNOTATED:
+		return hasMethodOptions(method)
+	default:
+		return true
+	}
+}
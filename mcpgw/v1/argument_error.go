@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ArgumentError describes a tool argument that can't be decoded.
+type ArgumentError struct {
+	// Pointer is the JSON Pointer (RFC 6901) of the argument, eg "/book/quotes/2".
+	// It is empty for the arguments as a whole.
+	Pointer string
+	// Expected describes the JSON value the argument takes, eg "int32" or
+	// "object". It is empty when the value isn't the problem, as for unknown
+	// fields.
+	Expected string
+	// Value is the received value, as decoded from JSON. It is nil for the
+	// errors of WithSchemaValidation and WithProtoValidation.
+	Value any
+	// Description tells what is wrong, in terms of the JSON arguments.
+	Description string
+}
+
+func (e *ArgumentError) Error() string {
+	if e.Pointer == "" {
+		return e.Description
+	}
+	return e.Pointer + ": " + e.Description
+}
+
+// ArgumentErrors are all the problems found in the arguments of a tool call,
+// in the order of their pointers.
+//
+// They convert to an InvalidArgument status carrying an errdetails.BadRequest
+// with a field violation per error, whose field is the JSON Pointer.
+type ArgumentErrors []*ArgumentError
+
+func (e ArgumentErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GRPCStatus implements the interface used by status.FromError.
+func (e ArgumentErrors) GRPCStatus() *status.Status {
+	br := &errdetails.BadRequest{}
+	for _, err := range e {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       err.Pointer,
+			Description: err.Description,
+		})
+	}
+	st := status.New(codes.InvalidArgument, "invalid arguments")
+	if withDetails, err := st.WithDetails(br); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// orNil returns nil rather than an empty list, as an error.
+func (e ArgumentErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// sorted sorts the errors by pointer, comparing array indexes as numbers.
+func (e ArgumentErrors) sorted() ArgumentErrors {
+	slices.SortStableFunc(e, func(a, b *ArgumentError) int {
+		return comparePointers(a.Pointer, b.Pointer)
+	})
+	return e
+}
+
+func comparePointers(a, b string) int {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return cmp.Compare(an, bn)
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// argumentErrors returns err as ArgumentErrors. Other errors become a single
+// error about the value at pointer.
+func argumentErrors(err error, pointer string) ArgumentErrors {
+	var errs ArgumentErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var argErr *ArgumentError
+	if errors.As(err, &argErr) {
+		return ArgumentErrors{argErr}
+	}
+	return ArgumentErrors{{Pointer: pointer, Description: err.Error()}}
+}
+
+// prefixArgumentErrors locates the errors of a message found at prefix in the
+// arguments.
+func prefixArgumentErrors(err error, prefix string) ArgumentErrors {
+	errs := argumentErrors(err, "")
+	rv := make(ArgumentErrors, 0, len(errs))
+	for _, e := range errs {
+		prefixed := *e
+		prefixed.Pointer = prefix + e.Pointer
+		rv = append(rv, &prefixed)
+	}
+	return rv
+}
+
+// pointerTo returns the JSON Pointer of a member of the value at pointer.
+func pointerTo(pointer string, token string) string {
+	if strings.ContainsAny(token, "~/") {
+		token = strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+	}
+	return pointer + "/" + token
+}
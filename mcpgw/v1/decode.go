@@ -1,6 +1,14 @@
 package v1
 
-import "encoding/json"
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
 
 type DecoderInput interface {
 	Method() string
@@ -9,3 +17,48 @@ type DecoderInput interface {
 	// Otherwise, the arguments are already unmarshaled into the Arguments map
 	RawArguments() json.RawMessage
 }
+
+// Decode unmarshals the arguments of a tool call into out. It is used by the
+// Decoder of generated methods.
+//
+// Invalid arguments are reported as ArgumentErrors, which locate every
+// problem in the arguments by JSON Pointer. Fields marked server_populated are
+// invalid arguments. The arguments are decoded with protojson, and again with
+// UnmarshalFromMap when it fails or out has server populated fields; the
+// errors of UnmarshalFromMap are returned, and those of protojson only when
+// UnmarshalFromMap accepts the arguments.
+func Decode(ctx context.Context, input DecoderInput, out proto.Message) error {
+	raw := input.RawArguments()
+	if len(raw) == 0 {
+		return UnmarshalFromMap(input.Arguments(), out)
+	}
+	jsonErr := protojson.Unmarshal(raw, out)
+	if jsonErr == nil && !holdsServerPopulated(out.ProtoReflect().Descriptor()) {
+		return nil
+	}
+	// protojson stops at the first error and locates it in the text, and
+	// accepts server populated fields, so the arguments are decoded again.
+	args, err := numberArguments(raw)
+	if err != nil {
+		return argumentErrors(cmp.Or(jsonErr, err), "")
+	}
+	if mapErr := UnmarshalFromMap(args, out); mapErr != nil {
+		return mapErr
+	}
+	if jsonErr == nil {
+		return nil
+	}
+	// What protojson alone rejects, such as duplicate names, is lost in the map
+	return argumentErrors(jsonErr, "")
+}
+
+// numberArguments decodes raw arguments, keeping numbers as json.Number.
+func numberArguments(raw json.RawMessage) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var args map[string]any
+	if err := dec.Decode(&args); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
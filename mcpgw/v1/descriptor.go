@@ -15,13 +15,28 @@ type ServiceDesc struct {
 
 type methodHandler func(srv interface{}, ctx context.Context, dec func(proto.Message) error, interceptor grpc.UnaryServerInterceptor) (proto.Message, error)
 type decoderHandler func(ctx context.Context, input DecoderInput, out proto.Message) error
-type inputSchemaHandler func() (map[string]any)
+type inputSchemaHandler func() map[string]any
+type outputSchemaHandler func() map[string]any
 
 type MethodDesc struct {
-	Method        string
-	Handler       methodHandler
+	Method string
+	// Name is the MCP tool name of the method.
+	Name    string
+	Handler methodHandler
+	// StreamHandler is set instead of Handler for streaming methods.
+	StreamHandler grpc.StreamHandler
+	ServerStreams bool
+	ClientStreams bool
+	StreamResult  StreamResult
 	Decoder       decoderHandler
+	// ArgumentDecoding is how Decoder treats the arguments, for the checks the
+	// Server makes before decoding.
+	ArgumentDecoding ArgumentDecoding
+	// InputSchema and OutputSchema describe the arguments and the structured
+	// result of the tool. Streamed messages are described together, as the
+	// items of a "messages" property.
 	InputSchema   inputSchemaHandler
+	OutputSchema  outputSchemaHandler
 	Title         string
 	Description   string
 	ReadOnlyHint  bool
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ToolError is the structured form of a failed tool call, found under the
+// "error" key of the result's structuredContent.
+type ToolError struct {
+	// Code is the name of the gRPC status code, eg "NotFound".
+	Code string `json:"code"`
+	// HTTPStatus is the HTTP status corresponding to Code, see HTTPStatusFromCode.
+	HTTPStatus int    `json:"httpStatus"`
+	Message    string `json:"message"`
+	// Details holds the protojson form of each status detail, including its "@type".
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// ErrorResult converts an error returned by a method handler into a tool result
+// with isError set, so the model is told what went wrong instead of the call
+// failing at the protocol level.
+//
+// The gRPC status of err is rendered both as text and as structuredContent.
+// Well known details, such as errdetails.BadRequest, ErrorInfo, RetryInfo and
+// Help, are additionally spelled out in the text block. Errors that do not
+// carry a status are reported with their context code, or codes.Unknown.
+func ErrorResult(err error) *CallToolResult {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	toolErr, text := newToolError(st)
+	rv := &CallToolResult{
+		Content: []*Content{TextContent(text)},
+		IsError: true,
+	}
+	if data, err := json.Marshal(map[string]*ToolError{"error": toolErr}); err == nil {
+		rv.StructuredContent = data
+	}
+	return rv
+}
+
+// newToolError returns the structured form of a status, and its description
+// as text.
+func newToolError(st *status.Status) (*ToolError, string) {
+	toolErr := &ToolError{
+		Code:       st.Code().String(),
+		HTTPStatus: HTTPStatusFromCode(st.Code()),
+		Message:    st.Message(),
+	}
+	text := &strings.Builder{}
+	fmt.Fprintf(text, "%s: %s", toolErr.Code, toolErr.Message)
+	for _, detail := range st.Proto().GetDetails() {
+		msg, err := detail.UnmarshalNew()
+		if err != nil {
+			// The detail type isn't linked into the binary, so only its type is known.
+			data, _ := json.Marshal(map[string]string{"@type": detail.GetTypeUrl()})
+			toolErr.Details = append(toolErr.Details, data)
+			continue
+		}
+		if data, err := protojson.Marshal(detail); err == nil {
+			toolErr.Details = append(toolErr.Details, data)
+		}
+		writeErrorDetail(text, msg)
+	}
+	return toolErr, text.String()
+}
+
+// writeErrorDetail appends a readable description of well known error details.
+func writeErrorDetail(w *strings.Builder, detail proto.Message) {
+	switch d := detail.(type) {
+	case *errdetails.BadRequest:
+		for _, v := range d.GetFieldViolations() {
+			fmt.Fprintf(w, "\ninvalid field %s: %s", v.GetField(), v.GetDescription())
+		}
+	case *errdetails.ErrorInfo:
+		fmt.Fprintf(w, "\nreason: %s", d.GetReason())
+		if d.GetDomain() != "" {
+			fmt.Fprintf(w, " (domain: %s)", d.GetDomain())
+		}
+		for _, k := range slices.Sorted(maps.Keys(d.GetMetadata())) {
+			fmt.Fprintf(w, "\n%s: %s", k, d.GetMetadata()[k])
+		}
+	case *errdetails.RetryInfo:
+		if delay := d.GetRetryDelay(); delay != nil {
+			fmt.Fprintf(w, "\nretry after %s", delay.AsDuration())
+		}
+	case *errdetails.Help:
+		for _, link := range d.GetLinks() {
+			fmt.Fprintf(w, "\nsee %s: %s", link.GetDescription(), link.GetUrl())
+		}
+	case *errdetails.LocalizedMessage:
+		fmt.Fprintf(w, "\n%s", d.GetMessage())
+	}
+}
@@ -0,0 +1,595 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Headers defined by the MCP Streamable HTTP transport.
+// See: https://modelcontextprotocol.io/specification/2025-06-18/basic/transports#streamable-http
+const (
+	HeaderSessionID       = "Mcp-Session-Id"
+	HeaderProtocolVersion = "Mcp-Protocol-Version"
+)
+
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeEventStream = "text/event-stream"
+
+	// maxHTTPMessageSize bounds the size of a POST body accepted by the HTTP handler.
+	maxHTTPMessageSize = 16 * 1024 * 1024
+
+	defaultSessionIdleTimeout = 30 * time.Minute
+	defaultMaxSessions        = 10000
+)
+
+// Request headers copied into the incoming gRPC metadata of tool calls, along
+// with those given to WithForwardedHeaders.
+var defaultForwardedHeaders = []string{HeaderSessionID, HeaderProtocolVersion, "User-Agent"}
+
+type httpOptions struct {
+	stateless          bool
+	allowedOrigins     []string
+	forwardedHeaders   []string
+	sessionIdleTimeout time.Duration
+	maxSessions        int
+}
+
+// HTTPOption configures the handler returned by Server.HTTPHandler.
+type HTTPOption func(*httpOptions)
+
+// WithStatelessHTTP disables Mcp-Session-Id handling. Every POST is handled
+// independently and GET streams are not offered, which suits deployments
+// where consecutive requests may reach different replicas.
+func WithStatelessHTTP() HTTPOption {
+	return func(o *httpOptions) {
+		o.stateless = true
+	}
+}
+
+// WithAllowedOrigins accepts browser requests from the given origins, such as
+// "https://example.com", or from any origin with "*". Requests carrying any
+// other Origin header are rejected, as the transport specification requires to
+// prevent DNS rebinding attacks; without this option, that is every request
+// with an Origin header.
+func WithAllowedOrigins(origins ...string) HTTPOption {
+	return func(o *httpOptions) {
+		o.allowedOrigins = append(o.allowedOrigins, origins...)
+	}
+}
+
+// WithForwardedHeaders copies the given request headers, such as
+// "Authorization", into the incoming gRPC metadata of tool calls. Only
+// Mcp-Session-Id, Mcp-Protocol-Version and User-Agent are copied otherwise, so
+// credentials reach interceptors only when asked for.
+func WithForwardedHeaders(headers ...string) HTTPOption {
+	return func(o *httpOptions) {
+		o.forwardedHeaders = append(o.forwardedHeaders, headers...)
+	}
+}
+
+// WithSessionIdleTimeout sets how long a session is kept without requests or
+// open streams, 30 minutes by default. Requests for an expired session are
+// answered with 404 Not Found, which tells the client to initialize again.
+func WithSessionIdleTimeout(d time.Duration) HTTPOption {
+	return func(o *httpOptions) {
+		o.sessionIdleTimeout = d
+	}
+}
+
+// WithMaxSessions bounds the number of sessions kept at once, 10000 by
+// default. Once reached, initialize requests are answered with 503 Service
+// Unavailable until sessions are deleted or expire.
+func WithMaxSessions(n int) HTTPOption {
+	return func(o *httpOptions) {
+		o.maxSessions = n
+	}
+}
+
+// HTTPHandler returns an http.Handler serving the registered services over the
+// MCP Streamable HTTP transport.
+//
+// Every tool call receives incoming gRPC metadata built from the HTTP request
+// with MetadataForRequest, and the headers allowed by WithForwardedHeaders, and
+// a peer built with PeerForRequest, so interceptors written for a gRPC server
+// see an equivalent context.
+func (s *Server) HTTPHandler(opts ...HTTPOption) http.Handler {
+	h := &httpHandler{
+		server: s,
+		opts: httpOptions{
+			sessionIdleTimeout: defaultSessionIdleTimeout,
+			maxSessions:        defaultMaxSessions,
+		},
+		sessions:  make(map[string]*httpSession),
+		forwarded: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(&h.opts)
+	}
+	for _, k := range slices.Concat(defaultForwardedHeaders, h.opts.forwardedHeaders) {
+		h.forwarded[strings.ToLower(k)] = true
+	}
+	return h
+}
+
+type httpHandler struct {
+	server    *Server
+	opts      httpOptions
+	forwarded map[string]bool
+
+	mu       sync.Mutex
+	sessions map[string]*httpSession
+}
+
+type httpSession struct {
+	*session
+	id string
+
+	mu         sync.Mutex
+	closed     bool
+	done       chan struct{}
+	streams    map[*sseWriter]struct{}
+	busy       int
+	lastActive time.Time
+}
+
+func newHTTPSession() (*httpSession, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return &httpSession{
+		session:    newSession(),
+		id:         hex.EncodeToString(buf),
+		done:       make(chan struct{}),
+		streams:    make(map[*sseWriter]struct{}),
+		lastActive: time.Now(),
+	}, nil
+}
+
+// acquire marks the session in use by a request until release, so it doesn't
+// expire. It returns false if the session is closed.
+func (hs *httpSession) acquire() bool {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.closed {
+		return false
+	}
+	hs.busy++
+	return true
+}
+
+func (hs *httpSession) release() {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.busy--
+	hs.lastActive = time.Now()
+}
+
+// expired reports whether the session went unused for longer than timeout.
+func (hs *httpSession) expired(now time.Time, timeout time.Duration) bool {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	return hs.busy == 0 && timeout > 0 && now.Sub(hs.lastActive) > timeout
+}
+
+func (hs *httpSession) close() {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.closed {
+		return
+	}
+	hs.closed = true
+	close(hs.done)
+	hs.cancelAll()
+}
+
+func (hs *httpSession) addStream(w *sseWriter) bool {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.closed {
+		return false
+	}
+	hs.streams[w] = struct{}{}
+	return true
+}
+
+func (hs *httpSession) broadcast(msg *jsonrpcMessage) error {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	for sw := range hs.streams {
+		// A stream that fails to write is closing; its GET request cleans it up.
+		_ = sw.send(msg)
+	}
+	return nil
+}
+
+func (hs *httpSession) removeStream(w *sseWriter) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	delete(hs.streams, w)
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.originAllowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	if v := r.Header.Get(HeaderProtocolVersion); v != "" && !slices.Contains(supportedProtocolVersions, v) {
+		http.Error(w, fmt.Sprintf("unsupported %s: %s", HeaderProtocolVersion, v), http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		h.servePost(w, r)
+	case http.MethodGet:
+		h.serveGet(w, r)
+	case http.MethodDelete:
+		h.serveDelete(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// originAllowed reports whether a request may be served. Only browsers send
+// an Origin header, and pages they load may not call the server unless their
+// origin is allowed.
+func (h *httpHandler) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	return slices.Contains(h.opts.allowedOrigins, "*") || slices.Contains(h.opts.allowedOrigins, origin)
+}
+
+// lookupSession resolves the Mcp-Session-Id of a request, writing an error
+// response and returning false if it is missing, unknown or expired. The
+// session is acquired for the request, and must be released.
+func (h *httpHandler) lookupSession(w http.ResponseWriter, r *http.Request) (*httpSession, bool) {
+	id := r.Header.Get(HeaderSessionID)
+	if id == "" {
+		http.Error(w, "missing "+HeaderSessionID+" header", http.StatusBadRequest)
+		return nil, false
+	}
+	h.mu.Lock()
+	sess, ok := h.sessions[id]
+	if ok && sess.expired(time.Now(), h.opts.sessionIdleTimeout) {
+		delete(h.sessions, id)
+		sess.close()
+		ok = false
+	}
+	ok = ok && sess.acquire()
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return nil, false
+	}
+	return sess, true
+}
+
+// addSession keeps a session once it is initialized, after dropping expired
+// ones. It returns false if there are too many sessions.
+func (h *httpHandler) addSession(sess *httpSession) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	for id, other := range h.sessions {
+		if other.expired(now, h.opts.sessionIdleTimeout) {
+			delete(h.sessions, id)
+			other.close()
+		}
+	}
+	if h.opts.maxSessions > 0 && len(h.sessions) >= h.opts.maxSessions {
+		return false
+	}
+	h.sessions[sess.id] = sess
+	return true
+}
+
+func (h *httpHandler) servePost(w http.ResponseWriter, r *http.Request) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mt, _, err := mime.ParseMediaType(ct); err != nil || mt != contentTypeJSON {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPMessageSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxHTTPMessageSize {
+		http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	msgs, batch, err := parseHTTPMessages(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, newErrorResponse(nil, &JSONRPCError{Code: JSONRPCParseError, Message: err.Error()}))
+		return
+	}
+
+	initialize := slices.ContainsFunc(msgs, func(m *jsonrpcMessage) bool { return m.Method == mcpMethodInitialize })
+	var sess *httpSession
+	switch {
+	case h.opts.stateless:
+		sess = &httpSession{session: newSession()}
+	case initialize:
+		if len(msgs) != 1 {
+			http.Error(w, "initialize must not be part of a batch", http.StatusBadRequest)
+			return
+		}
+		sess, err = newHTTPSession()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		var ok bool
+		if sess, ok = h.lookupSession(w, r); !ok {
+			return
+		}
+		defer sess.release()
+	}
+
+	ctx := withCallContext(r.Context(), h.callContext(r))
+	hasRequests := slices.ContainsFunc(msgs, (*jsonrpcMessage).isRequest)
+	if !hasRequests {
+		for _, msg := range msgs {
+			h.server.handleMessage(ctx, sess.session, msg)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if initialize && !h.opts.stateless {
+		h.initialize(ctx, w, sess, msgs[0], batch)
+		return
+	}
+
+	if acceptsEventStream(r) && slices.ContainsFunc(msgs, wantsStream) {
+		h.streamResponses(ctx, w, sess, msgs)
+		return
+	}
+
+	// Without a stream of its own, anything sent while handling the request goes
+	// to the session's GET streams, if any are open.
+	ctx = withNotifier(ctx, sess.broadcast)
+	responses := make([]*jsonrpcMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		if resp := h.handle(ctx, sess, msg); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	if batch {
+		writeJSON(w, http.StatusOK, responses)
+		return
+	}
+	writeJSON(w, http.StatusOK, responses[0])
+}
+
+// initialize answers an initialize request, which starts a session only if
+// it succeeds.
+func (h *httpHandler) initialize(ctx context.Context, w http.ResponseWriter, sess *httpSession, msg *jsonrpcMessage, batch bool) {
+	resp := h.handle(ctx, sess, msg)
+	if resp.Error == nil {
+		if !h.addSession(sess) {
+			sess.close()
+			http.Error(w, "too many sessions", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(HeaderSessionID, sess.id)
+	}
+	if batch {
+		writeJSON(w, http.StatusOK, []*jsonrpcMessage{resp})
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handle processes a message, tracking requests so they can be cancelled by
+// a notifications/cancelled message or by deleting the session.
+func (h *httpHandler) handle(ctx context.Context, sess *httpSession, msg *jsonrpcMessage) *jsonrpcMessage {
+	if !msg.isRequest() {
+		return h.server.handleMessage(ctx, sess.session, msg)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sess.track(msg.ID, cancel)
+	defer sess.untrack(msg.ID)
+	return h.server.handleMessage(ctx, sess.session, msg)
+}
+
+// streamResponses answers a POST with an SSE stream that carries the responses,
+// and any messages sent while the requests are being handled.
+func (h *httpHandler) streamResponses(ctx context.Context, w http.ResponseWriter, sess *httpSession, msgs []*jsonrpcMessage) {
+	sw, ok := newSSEWriter(w)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	ctx = withNotifier(ctx, sw.send)
+	for _, msg := range msgs {
+		if resp := h.handle(ctx, sess, msg); resp != nil {
+			if err := sw.send(resp); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *httpHandler) serveGet(w http.ResponseWriter, r *http.Request) {
+	if h.opts.stateless {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "server does not offer a stream", http.StatusMethodNotAllowed)
+		return
+	}
+	if !acceptsEventStream(r) {
+		http.Error(w, "client must accept "+contentTypeEventStream, http.StatusNotAcceptable)
+		return
+	}
+	sess, ok := h.lookupSession(w, r)
+	if !ok {
+		return
+	}
+	defer sess.release()
+	sw, ok := newSSEWriter(w)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	if !sess.addStream(sw) {
+		return
+	}
+	defer sess.removeStream(sw)
+
+	select {
+	case <-r.Context().Done():
+	case <-sess.done:
+	}
+}
+
+func (h *httpHandler) serveDelete(w http.ResponseWriter, r *http.Request) {
+	if h.opts.stateless {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "server does not use sessions", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := h.lookupSession(w, r)
+	if !ok {
+		return
+	}
+	defer sess.release()
+	h.mu.Lock()
+	delete(h.sessions, sess.id)
+	h.mu.Unlock()
+	sess.close()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseHTTPMessages(body []byte) ([]*jsonrpcMessage, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var msgs []*jsonrpcMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return nil, true, err
+		}
+		if len(msgs) == 0 {
+			return nil, true, fmt.Errorf("empty batch")
+		}
+		return msgs, true, nil
+	}
+	msg := &jsonrpcMessage{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, false, err
+	}
+	return []*jsonrpcMessage{msg}, false, nil
+}
+
+// wantsStream reports whether a request may produce messages before its
+// response, in which case it is worth answering with an SSE stream.
+func wantsStream(msg *jsonrpcMessage) bool {
+	if msg.Method != mcpMethodToolsCall {
+		return false
+	}
+	params := &callToolParams{}
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return false
+	}
+	return params.Meta != nil && len(params.Meta.ProgressToken) != 0
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(v, ",") {
+			if mt, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mt == contentTypeEventStream {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// sseWriter writes JSON-RPC messages as server-sent events.
+type sseWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSEWriter(w http.ResponseWriter) (*sseWriter, bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, false
+	}
+	w.Header().Set("Content-Type", contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &sseWriter{w: w, flusher: flusher}, true
+}
+
+func (sw *sseWriter) send(msg *jsonrpcMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if _, err := fmt.Fprintf(sw.w, "event: message\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+	sw.flusher.Flush()
+	return nil
+}
+
+// httpRequestMetadata adapts an *http.Request to RequestMetadata.
+type httpRequestMetadata struct {
+	r *http.Request
+}
+
+func (m httpRequestMetadata) Host() string {
+	return m.r.Host
+}
+
+func (m httpRequestMetadata) RemoteAddr() string {
+	return m.r.RemoteAddr
+}
+
+// callContext returns a callContextFunc that gives each tool call the
+// incoming metadata and peer of the HTTP request that carried it.
+func (h *httpHandler) callContext(r *http.Request) callContextFunc {
+	reqMD := httpRequestMetadata{r: r}
+	return func(ctx context.Context, md *MethodDesc) context.Context {
+		grpcMD := MetadataForRequest(reqMD, md.Method)
+		for k, vs := range r.Header {
+			key := strings.ToLower(k)
+			if h.forwarded[key] {
+				grpcMD.Append(key, vs...)
+			}
+		}
+		ctx = metadata.NewIncomingContext(ctx, grpcMD)
+		return peer.NewContext(ctx, PeerForRequest(reqMD))
+	}
+}
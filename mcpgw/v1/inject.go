@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldInjector returns the value of a field marked server_populated in the
+// request of a tool call, from its context, such as the incoming metadata or
+// the claims of the authenticated caller. The value must be of the type of fd.
+//
+// An invalid Value leaves the field unset. An error fails the call, and is
+// reported like errors of the handler.
+type FieldInjector func(ctx context.Context, fd protoreflect.FieldDescriptor) (protoreflect.Value, error)
+
+// serverPopulated reports whether a field is marked server_populated, so it
+// isn't taken from tool arguments.
+func serverPopulated(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := proto.GetExtension(fd.Options(), E_Field).(*FieldOptions)
+	return ok && opts.GetServerPopulated()
+}
+
+// serverPopulatedMessages caches holdsServerPopulated, by message full name.
+var serverPopulatedMessages sync.Map
+
+// holdsServerPopulated reports whether messages of type md have server
+// populated fields, themselves or in the messages they hold.
+func holdsServerPopulated(md protoreflect.MessageDescriptor) bool {
+	if rv, ok := serverPopulatedMessages.Load(md.FullName()); ok {
+		return rv.(bool)
+	}
+	rv := findServerPopulated(md, map[protoreflect.FullName]bool{})
+	serverPopulatedMessages.Store(md.FullName(), rv)
+	return rv
+}
+
+func findServerPopulated(md protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) bool {
+	if visited[md.FullName()] {
+		return false
+	}
+	visited[md.FullName()] = true
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if serverPopulated(fd) {
+			return true
+		}
+		if msg := fieldMessage(fd); msg != nil && findServerPopulated(msg, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMessage returns the type of the messages held by a field, or nil.
+func fieldMessage(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd.IsMap() {
+		return fd.MapValue().Message()
+	}
+	return fd.Message()
+}
+
+// injectFields sets the server populated fields of m, and of the messages it
+// holds, that have a FieldInjector.
+func (s *Server) injectFields(ctx context.Context, m protoreflect.Message) error {
+	if len(s.opts.injectors) == 0 || !holdsServerPopulated(m.Descriptor()) {
+		return nil
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if serverPopulated(fd) {
+			inject, ok := s.opts.injectors[fd.FullName()]
+			if !ok {
+				continue
+			}
+			v, err := inject(ctx, fd)
+			if err != nil {
+				return err
+			}
+			if v.IsValid() {
+				m.Set(fd, v)
+			}
+			continue
+		}
+		if msg := fieldMessage(fd); msg == nil || !m.Has(fd) || !holdsServerPopulated(msg) {
+			continue
+		}
+
+		var err error
+		switch {
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for j := 0; j < list.Len() && err == nil; j++ {
+				err = s.injectFields(ctx, list.Get(j).Message())
+			}
+		case fd.IsMap():
+			m.Mutable(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = s.injectFields(ctx, v.Message())
+				return err == nil
+			})
+		default:
+			err = s.injectFields(ctx, m.Mutable(fd).Message())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const jsonrpcVersion = "2.0"
+
+// JSON-RPC 2.0 error codes.
+// See: https://www.jsonrpc.org/specification#error_object
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+)
+
+// jsonrpcMessage is the wire form of a JSON-RPC 2.0 request, notification or response.
+type jsonrpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+func (m *jsonrpcMessage) isNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+func (m *jsonrpcMessage) isRequest() bool {
+	return m.Method != "" && len(m.ID) != 0
+}
+
+// JSONRPCError is a JSON-RPC 2.0 error object.
+type JSONRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+func newResponse(id json.RawMessage, result any) *jsonrpcMessage {
+	return &jsonrpcMessage{
+		JSONRPC: jsonrpcVersion,
+		ID:      id,
+		Result:  result,
+	}
+}
+
+func newNotification(method string, params any) (*jsonrpcMessage, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonrpcMessage{
+		JSONRPC: jsonrpcVersion,
+		Method:  method,
+		Params:  data,
+	}, nil
+}
+
+func newErrorResponse(id json.RawMessage, err *JSONRPCError) *jsonrpcMessage {
+	if len(id) == 0 {
+		// Per the spec, errors that can't be attributed to a request use a null id.
+		id = json.RawMessage("null")
+	}
+	return &jsonrpcMessage{
+		JSONRPC: jsonrpcVersion,
+		ID:      id,
+		Error:   err,
+	}
+}
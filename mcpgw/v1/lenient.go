@@ -0,0 +1,377 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Repair describes a change DecodeLenient made to the arguments of a tool call
+// so they could be decoded.
+type Repair struct {
+	// Path is the JSON Pointer of the repaired value, eg "/book/quotes/0".
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+func (r Repair) String() string {
+	return r.Path + ": " + r.Description
+}
+
+// DecodeLenient is like Decode, but first repairs the arguments that models
+// commonly get wrong, see CoerceArguments. It is used by the Decoder of
+// generated methods with ARGUMENT_DECODING_LENIENT.
+//
+// The repairs are reported by the Server as a warning in the tool result.
+func DecodeLenient(ctx context.Context, input DecoderInput, out proto.Message) error {
+	args := input.Arguments()
+	if raw := input.RawArguments(); len(raw) > 0 {
+		// Keep the precision of 64-bit integers, which float64 would lose
+		var err error
+		if args, err = numberArguments(raw); err != nil {
+			return argumentErrors(err, "")
+		}
+	}
+	args, repairs := CoerceArguments(args, out.ProtoReflect().Descriptor())
+	if err := UnmarshalFromMap(args, out); err != nil {
+		return err
+	}
+	addRepairs(ctx, repairs)
+	return nil
+}
+
+// CoerceArguments returns a copy of args, the JSON form of a message of type
+// md, in which values that strict decoding rejects are converted to the type
+// of their field when that is unambiguous:
+//
+//   - "true" or "false" for a bool,
+//   - numbers with surrounding spaces or a leading "+" for numeric fields,
+//   - numbers and bools for a string,
+//   - enum values by number in a string, or by name in any case and without
+//     the prefix shared by the values of the enum, such as "female" for
+//     GENDER_FEMALE,
+//   - a single value for a repeated field.
+//
+// Unknown fields, and fields marked server_populated, are discarded. Values
+// that can't be repaired are left as is, for decoding to report. Every change
+// is described by a Repair, except for the conversion of valid values to the
+// form the input schema of the message describes, such as 64-bit integers
+// given as numbers to strings, enum values given by number to names, and
+// fields given by their proto name to their JSON name.
+func CoerceArguments(args map[string]any, md protoreflect.MessageDescriptor) (map[string]any, []Repair) {
+	if wellKnownDecoder(md.FullName()) != nil {
+		// Their JSON form is not made of fields
+		return args, nil
+	}
+	c := &coercer{}
+	return c.message(args, md, ""), c.repairs
+}
+
+// schemaArguments returns a copy of args in which valid values are converted
+// to the form the input schema of md describes, like CoerceArguments does,
+// and every other value is left as is.
+func schemaArguments(args map[string]any, md protoreflect.MessageDescriptor) map[string]any {
+	if wellKnownDecoder(md.FullName()) != nil {
+		return args
+	}
+	c := &coercer{keep: true}
+	return c.message(args, md, "")
+}
+
+type coercer struct {
+	// keep leaves the values that need a repair as they are
+	keep    bool
+	repairs []Repair
+}
+
+func (c *coercer) repair(path string, format string, args ...any) {
+	c.repairs = append(c.repairs, Repair{Path: path, Description: fmt.Sprintf(format, args...)})
+}
+
+func (c *coercer) message(args map[string]any, md protoreflect.MessageDescriptor, path string) map[string]any {
+	if args == nil {
+		return nil
+	}
+	rv := make(map[string]any, len(args))
+	for _, key := range slices.Sorted(maps.Keys(args)) {
+		value := args[key]
+		fd, err := messageField(md, key)
+		if err != nil {
+			// Decoding reports it
+			rv[key] = value
+			continue
+		}
+		if c.keep && (fd == nil || serverPopulated(fd)) {
+			rv[key] = value
+			continue
+		}
+		if fd == nil {
+			c.repair(pointerTo(path, key), "discarded the unknown field")
+			continue
+		}
+		if serverPopulated(fd) {
+			c.repair(pointerTo(path, key), "discarded the field set by the server")
+			continue
+		}
+		name := key
+		if _, ok := args[fd.JSONName()]; !ok {
+			// Unless the field is given twice, for decoding to report
+			name = fd.JSONName()
+		}
+		rv[name] = c.field(fd, value, pointerTo(path, key))
+	}
+	return rv
+}
+
+// field repairs the value of a field, which may be a list or a map.
+func (c *coercer) field(fd protoreflect.FieldDescriptor, v any, path string) any {
+	if v == nil {
+		return nil
+	}
+	switch {
+	case fd.IsMap():
+		entries, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		rv := make(map[string]any, len(entries))
+		for _, k := range slices.Sorted(maps.Keys(entries)) {
+			rv[k] = c.singular(fd.MapValue(), entries[k], pointerTo(path, k))
+		}
+		return rv
+
+	case fd.IsList():
+		items, ok := v.([]any)
+		if !ok && c.keep {
+			return v
+		}
+		if !ok {
+			c.repair(path, "wrapped the value in a list")
+			return []any{c.singular(fd, v, pointerTo(path, "0"))}
+		}
+		rv := make([]any, len(items))
+		for i, item := range items {
+			rv[i] = c.singular(fd, item, pointerTo(path, strconv.Itoa(i)))
+		}
+		return rv
+	}
+	return c.singular(fd, v, path)
+}
+
+// singular repairs a single value of a field.
+func (c *coercer) singular(fd protoreflect.FieldDescriptor, v any, path string) any {
+	if v == nil {
+		return nil
+	}
+	if md := fd.Message(); md != nil {
+		name := md.FullName()
+		switch {
+		case wellKnownDecoder(name) == nil:
+			if obj, ok := v.(map[string]any); ok {
+				return c.message(obj, md, path)
+			}
+		case isWrapper(name):
+			// Wrappers are given as the value they wrap
+			return c.singular(md.Fields().ByName("value"), v, path)
+		}
+		return v
+	}
+
+	if pv, ok := scalarValue(fd, v); ok {
+		return schemaForm(fd, v, pv)
+	}
+	if c.keep {
+		return v
+	}
+	rv, ok := coerceScalar(fd, v)
+	if !ok {
+		return v
+	}
+	pv, ok := scalarValue(fd, rv)
+	if !ok {
+		return v
+	}
+	rv = schemaForm(fd, rv, pv)
+	c.repair(path, "converted %s to %s", valueText(v), valueText(rv))
+	return rv
+}
+
+// schemaForm returns v, the JSON value of a field that decodes to pv, in the
+// form the input schema describes where protojson accepts others: integers as
+// numbers, 64-bit ones as decimal strings, and enum values by name.
+func schemaForm(fd protoreflect.FieldDescriptor, v any, pv protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return json.Number(strconv.FormatInt(pv.Int(), 10))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return json.Number(strconv.FormatUint(pv.Uint(), 10))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(pv.Int(), 10)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(pv.Uint(), 10)
+	case protoreflect.EnumKind:
+		if isNullValue(fd) {
+			return v
+		}
+		if ev := fd.Enum().Values().ByNumber(pv.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+	}
+	return v
+}
+
+// coerceScalar converts v to the JSON form of the value of a scalar or enum
+// field, if it unambiguously stands for one.
+func coerceScalar(fd protoreflect.FieldDescriptor, v any) (any, bool) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		s = strings.TrimSpace(s)
+		if strings.EqualFold(s, "true") || strings.EqualFold(s, "false") {
+			return strings.EqualFold(s, "true"), true
+		}
+
+	case protoreflect.StringKind:
+		if b, ok := v.(bool); ok {
+			return fmt.Sprint(b), true
+		}
+		if s, ok := numberText(v); ok {
+			return s, true
+		}
+
+	case protoreflect.EnumKind:
+		if isNullValue(fd) {
+			return nil, false
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		if ev := enumValueByText(fd.Enum(), s); ev != nil {
+			return string(ev.Name()), true
+		}
+
+	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+
+	default:
+		// Numbers
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		return strings.TrimPrefix(strings.TrimSpace(s), "+"), true
+	}
+	return nil, false
+}
+
+func isWrapper(name protoreflect.FullName) bool {
+	if name.Parent() != "google.protobuf" {
+		return false
+	}
+	switch name.Name() {
+	case "BoolValue", "Int32Value", "Int64Value", "UInt32Value", "UInt64Value",
+		"FloatValue", "DoubleValue", "StringValue", "BytesValue":
+		return true
+	}
+	return false
+}
+
+// enumValueByText returns the value of ed a model most likely meant by s: the
+// value of that number, or the only value named s, ignoring case, spaces and
+// the prefix of the value names.
+func enumValueByText(ed protoreflect.EnumDescriptor, s string) protoreflect.EnumValueDescriptor {
+	values := ed.Values()
+	if n, ok := intValue(strings.TrimSpace(s), 32); ok {
+		return values.ByNumber(protoreflect.EnumNumber(n))
+	}
+	name := strings.ToUpper(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_"))
+	if name == "" {
+		return nil
+	}
+	var rv protoreflect.EnumValueDescriptor
+	for i := 0; i < values.Len(); i++ {
+		ev := values.Get(i)
+		upper := strings.ToUpper(string(ev.Name()))
+		if upper != name && !strings.HasSuffix(upper, "_"+name) {
+			continue
+		}
+		if rv != nil && rv.Number() != ev.Number() {
+			// Ambiguous
+			return nil
+		}
+		rv = ev
+	}
+	return rv
+}
+
+// repairScope records the repairs made to the arguments of a tool call. prefix
+// locates the decoded message in the arguments, for client-streaming methods.
+type repairScope struct {
+	log    *repairLog
+	prefix string
+}
+
+type repairLog struct {
+	mu      sync.Mutex
+	repairs []Repair
+}
+
+type repairScopeKey struct{}
+
+// withRepairLog returns a context in which DecodeLenient records its repairs
+// to the returned log.
+func withRepairLog(ctx context.Context) (context.Context, *repairLog) {
+	log := &repairLog{}
+	return context.WithValue(ctx, repairScopeKey{}, &repairScope{log: log}), log
+}
+
+// withRepairPrefix returns a context in which repairs are located under prefix.
+func withRepairPrefix(ctx context.Context, prefix string) context.Context {
+	scope, ok := ctx.Value(repairScopeKey{}).(*repairScope)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, repairScopeKey{}, &repairScope{log: scope.log, prefix: scope.prefix + prefix})
+}
+
+func addRepairs(ctx context.Context, repairs []Repair) {
+	scope, ok := ctx.Value(repairScopeKey{}).(*repairScope)
+	if !ok || len(repairs) == 0 {
+		return
+	}
+	scope.log.mu.Lock()
+	defer scope.log.mu.Unlock()
+	for _, r := range repairs {
+		r.Path = scope.prefix + r.Path
+		scope.log.repairs = append(scope.log.repairs, r)
+	}
+}
+
+// annotate adds the repairs to the result as a text block, so the model
+// learns how to call the tool next time.
+func (l *repairLog) annotate(rv *CallToolResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.repairs) == 0 {
+		return
+	}
+	text := &strings.Builder{}
+	text.WriteString("warning: the arguments were repaired before the call:")
+	for _, r := range l.repairs {
+		fmt.Fprintf(text, "\n%s", r)
+	}
+	rv.Content = append(rv.Content, TextContent(text.String()))
+}
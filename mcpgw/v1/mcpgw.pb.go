@@ -22,6 +22,147 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type StreamResult int32
+
+const (
+	StreamResult_STREAM_RESULT_UNSPECIFIED StreamResult = 0
+	// The result lists every streamed message, in order.
+	StreamResult_STREAM_RESULT_ALL StreamResult = 1
+	// The result is the last streamed message.
+	StreamResult_STREAM_RESULT_LAST StreamResult = 2
+)
+
+// Enum value maps for StreamResult.
+var (
+	StreamResult_name = map[int32]string{
+		0: "STREAM_RESULT_UNSPECIFIED",
+		1: "STREAM_RESULT_ALL",
+		2: "STREAM_RESULT_LAST",
+	}
+	StreamResult_value = map[string]int32{
+		"STREAM_RESULT_UNSPECIFIED": 0,
+		"STREAM_RESULT_ALL":         1,
+		"STREAM_RESULT_LAST":        2,
+	}
+)
+
+func (x StreamResult) Enum() *StreamResult {
+	p := new(StreamResult)
+	*p = x
+	return p
+}
+
+func (x StreamResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcpgw_v1_mcpgw_proto_enumTypes[0].Descriptor()
+}
+
+func (StreamResult) Type() protoreflect.EnumType {
+	return &file_mcpgw_v1_mcpgw_proto_enumTypes[0]
+}
+
+func (x StreamResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type MethodExposure int32
+
+const (
+	MethodExposure_METHOD_EXPOSURE_UNSPECIFIED MethodExposure = 0
+	// Every method is exposed, unless it sets (mcpgw.v1.method).exclude.
+	MethodExposure_METHOD_EXPOSURE_ALL MethodExposure = 1
+	// Only methods with a (mcpgw.v1.method) option are exposed.
+	MethodExposure_METHOD_EXPOSURE_ANNOTATED MethodExposure = 2
+)
+
+// Enum value maps for MethodExposure.
+var (
+	MethodExposure_name = map[int32]string{
+		0: "METHOD_EXPOSURE_UNSPECIFIED",
+		1: "METHOD_EXPOSURE_ALL",
+		2: "METHOD_EXPOSURE_ANNOTATED",
+	}
+	MethodExposure_value = map[string]int32{
+		"METHOD_EXPOSURE_UNSPECIFIED": 0,
+		"METHOD_EXPOSURE_ALL":         1,
+		"METHOD_EXPOSURE_ANNOTATED":   2,
+	}
+)
+
+func (x MethodExposure) Enum() *MethodExposure {
+	p := new(MethodExposure)
+	*p = x
+	return p
+}
+
+func (x MethodExposure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MethodExposure) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcpgw_v1_mcpgw_proto_enumTypes[1].Descriptor()
+}
+
+func (MethodExposure) Type() protoreflect.EnumType {
+	return &file_mcpgw_v1_mcpgw_proto_enumTypes[1]
+}
+
+func (x MethodExposure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type ArgumentDecoding int32
+
+const (
+	ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED ArgumentDecoding = 0
+	// Arguments are decoded like protojson, and rejected if it would fail.
+	ArgumentDecoding_ARGUMENT_DECODING_STRICT ArgumentDecoding = 1
+	// Arguments that models commonly get wrong are repaired before decoding,
+	// such as "true" for a bool or a single value for a repeated field. Unknown
+	// fields are discarded. The repairs are reported as warnings in the tool
+	// result.
+	ArgumentDecoding_ARGUMENT_DECODING_LENIENT ArgumentDecoding = 2
+)
+
+// Enum value maps for ArgumentDecoding.
+var (
+	ArgumentDecoding_name = map[int32]string{
+		0: "ARGUMENT_DECODING_UNSPECIFIED",
+		1: "ARGUMENT_DECODING_STRICT",
+		2: "ARGUMENT_DECODING_LENIENT",
+	}
+	ArgumentDecoding_value = map[string]int32{
+		"ARGUMENT_DECODING_UNSPECIFIED": 0,
+		"ARGUMENT_DECODING_STRICT":      1,
+		"ARGUMENT_DECODING_LENIENT":     2,
+	}
+)
+
+func (x ArgumentDecoding) Enum() *ArgumentDecoding {
+	p := new(ArgumentDecoding)
+	*p = x
+	return p
+}
+
+func (x ArgumentDecoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArgumentDecoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcpgw_v1_mcpgw_proto_enumTypes[2].Descriptor()
+}
+
+func (ArgumentDecoding) Type() protoreflect.EnumType {
+	return &file_mcpgw_v1_mcpgw_proto_enumTypes[2]
+}
+
+func (x ArgumentDecoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type MessageOptions struct {
 	state         protoimpl.MessageState `protogen:"opaque.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -66,12 +207,19 @@ func (b0 MessageOptions_builder) Build() *MessageOptions {
 }
 
 type FieldOptions struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Description *string                `protobuf:"bytes,1,opt,name=description"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Description     *string                `protobuf:"bytes,1,opt,name=description"`
+	xxx_hidden_Examples        []string               `protobuf:"bytes,2,rep,name=examples"`
+	xxx_hidden_Default         *string                `protobuf:"bytes,3,opt,name=default"`
+	xxx_hidden_Format          *string                `protobuf:"bytes,4,opt,name=format"`
+	xxx_hidden_Title           *string                `protobuf:"bytes,5,opt,name=title"`
+	xxx_hidden_ReadOnly        bool                   `protobuf:"varint,6,opt,name=read_only,json=readOnly"`
+	xxx_hidden_WriteOnly       bool                   `protobuf:"varint,7,opt,name=write_only,json=writeOnly"`
+	xxx_hidden_ServerPopulated bool                   `protobuf:"varint,8,opt,name=server_populated,json=serverPopulated"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *FieldOptions) Reset() {
@@ -109,9 +257,101 @@ func (x *FieldOptions) GetDescription() string {
 	return ""
 }
 
+func (x *FieldOptions) GetExamples() []string {
+	if x != nil {
+		return x.xxx_hidden_Examples
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetDefault() string {
+	if x != nil {
+		if x.xxx_hidden_Default != nil {
+			return *x.xxx_hidden_Default
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetFormat() string {
+	if x != nil {
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetReadOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_ReadOnly
+	}
+	return false
+}
+
+func (x *FieldOptions) GetWriteOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_WriteOnly
+	}
+	return false
+}
+
+func (x *FieldOptions) GetServerPopulated() bool {
+	if x != nil {
+		return x.xxx_hidden_ServerPopulated
+	}
+	return false
+}
+
 func (x *FieldOptions) SetDescription(v string) {
 	x.xxx_hidden_Description = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *FieldOptions) SetExamples(v []string) {
+	x.xxx_hidden_Examples = v
+}
+
+func (x *FieldOptions) SetDefault(v string) {
+	x.xxx_hidden_Default = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *FieldOptions) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *FieldOptions) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *FieldOptions) SetReadOnly(v bool) {
+	x.xxx_hidden_ReadOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *FieldOptions) SetWriteOnly(v bool) {
+	x.xxx_hidden_WriteOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *FieldOptions) SetServerPopulated(v bool) {
+	x.xxx_hidden_ServerPopulated = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
 }
 
 func (x *FieldOptions) HasDescription() bool {
@@ -121,21 +361,219 @@ func (x *FieldOptions) HasDescription() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *FieldOptions) HasDefault() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *FieldOptions) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *FieldOptions) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *FieldOptions) HasReadOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *FieldOptions) HasWriteOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *FieldOptions) HasServerPopulated() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
 func (x *FieldOptions) ClearDescription() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Description = nil
 }
 
+func (x *FieldOptions) ClearDefault() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Default = nil
+}
+
+func (x *FieldOptions) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Format = nil
+}
+
+func (x *FieldOptions) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *FieldOptions) ClearReadOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_ReadOnly = false
+}
+
+func (x *FieldOptions) ClearWriteOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_WriteOnly = false
+}
+
+func (x *FieldOptions) ClearServerPopulated() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_ServerPopulated = false
+}
+
 type FieldOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	// Defaults to the leading comment of the field.
 	Description *string
+	// Example values of the field, each written as JSON, such as "\"Dune\""
+	// or "[1, 2]". The generator rejects examples that don't decode as the
+	// field's type.
+	Examples []string
+	// The value the server assumes when the field is not set, written as JSON
+	// and checked like the examples. It is only shown to clients.
+	Default *string
+	// The JSON Schema format of the field, such as "date" or "email".
+	Format *string
+	// A short title of the field.
+	Title *string
+	// Marks a field that is set by the server, as readOnly.
+	ReadOnly *bool
+	// Marks a field that is sent by clients but not returned, as writeOnly.
+	WriteOnly *bool
+	// Marks a field of a request that the server fills in, such as a tenant or
+	// actor id taken from the caller's credentials. It is left out of input
+	// schemas and never taken from tool arguments: strict decoding rejects it
+	// and lenient decoding discards it. The Server sets it with the
+	// FieldInjector registered for it, if any.
+	ServerPopulated *bool
 }
 
 func (b0 FieldOptions_builder) Build() *FieldOptions {
 	m0 := &FieldOptions{}
 	b, x := &b0, m0
 	_, _ = b, x
+	if b.Description != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Description = b.Description
+	}
+	x.xxx_hidden_Examples = b.Examples
+	if b.Default != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_Default = b.Default
+	}
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Format = b.Format
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.ReadOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_ReadOnly = *b.ReadOnly
+	}
+	if b.WriteOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_WriteOnly = *b.WriteOnly
+	}
+	if b.ServerPopulated != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_ServerPopulated = *b.ServerPopulated
+	}
+	return m0
+}
+
+type EnumValueOptions struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Description *string                `protobuf:"bytes,1,opt,name=description"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EnumValueOptions) Reset() {
+	*x = EnumValueOptions{}
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumValueOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumValueOptions) ProtoMessage() {}
+
+func (x *EnumValueOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EnumValueOptions) GetDescription() string {
+	if x != nil {
+		if x.xxx_hidden_Description != nil {
+			return *x.xxx_hidden_Description
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EnumValueOptions) SetDescription(v string) {
+	x.xxx_hidden_Description = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *EnumValueOptions) HasDescription() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *EnumValueOptions) ClearDescription() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Description = nil
+}
+
+type EnumValueOptions_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Defaults to the leading comment of the enum value.
+	Description *string
+}
+
+func (b0 EnumValueOptions_builder) Build() *EnumValueOptions {
+	m0 := &EnumValueOptions{}
+	b, x := &b0, m0
+	_, _ = b, x
 	if b.Description != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
 		x.xxx_hidden_Description = b.Description
@@ -144,22 +582,27 @@ func (b0 FieldOptions_builder) Build() *FieldOptions {
 }
 
 type MethodOptions struct {
-	state                      protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Title           *string                `protobuf:"bytes,1,opt,name=title"`
-	xxx_hidden_Description     *string                `protobuf:"bytes,2,opt,name=description"`
-	xxx_hidden_ReadOnlyHint    bool                   `protobuf:"varint,3,opt,name=read_only_hint,json=readOnlyHint"`
-	xxx_hidden_DestructiveHint bool                   `protobuf:"varint,4,opt,name=destructive_hint,json=destructiveHint"`
-	xxx_hidden_IdempotentHint  bool                   `protobuf:"varint,5,opt,name=idempotent_hint,json=idempotentHint"`
-	xxx_hidden_OpenWorldHint   bool                   `protobuf:"varint,6,opt,name=open_world_hint,json=openWorldHint"`
-	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
-	XXX_presence               [1]uint32
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Title                *string                `protobuf:"bytes,1,opt,name=title"`
+	xxx_hidden_Description          *string                `protobuf:"bytes,2,opt,name=description"`
+	xxx_hidden_ReadOnlyHint         bool                   `protobuf:"varint,3,opt,name=read_only_hint,json=readOnlyHint"`
+	xxx_hidden_DestructiveHint      bool                   `protobuf:"varint,4,opt,name=destructive_hint,json=destructiveHint"`
+	xxx_hidden_IdempotentHint       bool                   `protobuf:"varint,5,opt,name=idempotent_hint,json=idempotentHint"`
+	xxx_hidden_OpenWorldHint        bool                   `protobuf:"varint,6,opt,name=open_world_hint,json=openWorldHint"`
+	xxx_hidden_StreamResult         StreamResult           `protobuf:"varint,7,opt,name=stream_result,json=streamResult,enum=mcpgw.v1.StreamResult"`
+	xxx_hidden_AllowClientStreaming bool                   `protobuf:"varint,8,opt,name=allow_client_streaming,json=allowClientStreaming"`
+	xxx_hidden_Exclude              bool                   `protobuf:"varint,9,opt,name=exclude"`
+	xxx_hidden_Name                 *string                `protobuf:"bytes,10,opt,name=name"`
+	xxx_hidden_ArgumentDecoding     ArgumentDecoding       `protobuf:"varint,11,opt,name=argument_decoding,json=argumentDecoding,enum=mcpgw.v1.ArgumentDecoding"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
 }
 
 func (x *MethodOptions) Reset() {
 	*x = MethodOptions{}
-	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[2]
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -171,7 +614,7 @@ func (x *MethodOptions) String() string {
 func (*MethodOptions) ProtoMessage() {}
 
 func (x *MethodOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[2]
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,34 +673,101 @@ func (x *MethodOptions) GetOpenWorldHint() bool {
 	return false
 }
 
+func (x *MethodOptions) GetStreamResult() StreamResult {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 6) {
+			return x.xxx_hidden_StreamResult
+		}
+	}
+	return StreamResult_STREAM_RESULT_UNSPECIFIED
+}
+
+func (x *MethodOptions) GetAllowClientStreaming() bool {
+	if x != nil {
+		return x.xxx_hidden_AllowClientStreaming
+	}
+	return false
+}
+
+func (x *MethodOptions) GetExclude() bool {
+	if x != nil {
+		return x.xxx_hidden_Exclude
+	}
+	return false
+}
+
+func (x *MethodOptions) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MethodOptions) GetArgumentDecoding() ArgumentDecoding {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 10) {
+			return x.xxx_hidden_ArgumentDecoding
+		}
+	}
+	return ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED
+}
+
 func (x *MethodOptions) SetTitle(v string) {
 	x.xxx_hidden_Title = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 11)
 }
 
 func (x *MethodOptions) SetDescription(v string) {
 	x.xxx_hidden_Description = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 11)
 }
 
 func (x *MethodOptions) SetReadOnlyHint(v bool) {
 	x.xxx_hidden_ReadOnlyHint = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 11)
 }
 
 func (x *MethodOptions) SetDestructiveHint(v bool) {
 	x.xxx_hidden_DestructiveHint = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 11)
 }
 
 func (x *MethodOptions) SetIdempotentHint(v bool) {
 	x.xxx_hidden_IdempotentHint = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 11)
 }
 
 func (x *MethodOptions) SetOpenWorldHint(v bool) {
 	x.xxx_hidden_OpenWorldHint = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 11)
+}
+
+func (x *MethodOptions) SetStreamResult(v StreamResult) {
+	x.xxx_hidden_StreamResult = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 11)
+}
+
+func (x *MethodOptions) SetAllowClientStreaming(v bool) {
+	x.xxx_hidden_AllowClientStreaming = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 11)
+}
+
+func (x *MethodOptions) SetExclude(v bool) {
+	x.xxx_hidden_Exclude = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 11)
+}
+
+func (x *MethodOptions) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 11)
+}
+
+func (x *MethodOptions) SetArgumentDecoding(v ArgumentDecoding) {
+	x.xxx_hidden_ArgumentDecoding = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 11)
 }
 
 func (x *MethodOptions) HasTitle() bool {
@@ -302,6 +812,41 @@ func (x *MethodOptions) HasOpenWorldHint() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
+func (x *MethodOptions) HasStreamResult() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *MethodOptions) HasAllowClientStreaming() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *MethodOptions) HasExclude() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *MethodOptions) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *MethodOptions) HasArgumentDecoding() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
 func (x *MethodOptions) ClearTitle() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Title = nil
@@ -332,15 +877,56 @@ func (x *MethodOptions) ClearOpenWorldHint() {
 	x.xxx_hidden_OpenWorldHint = false
 }
 
+func (x *MethodOptions) ClearStreamResult() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_StreamResult = StreamResult_STREAM_RESULT_UNSPECIFIED
+}
+
+func (x *MethodOptions) ClearAllowClientStreaming() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_AllowClientStreaming = false
+}
+
+func (x *MethodOptions) ClearExclude() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Exclude = false
+}
+
+func (x *MethodOptions) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *MethodOptions) ClearArgumentDecoding() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_ArgumentDecoding = ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED
+}
+
 type MethodOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Title           *string
+	Title *string
+	// Defaults to the leading comment of the method.
 	Description     *string
 	ReadOnlyHint    *bool
 	DestructiveHint *bool
 	IdempotentHint  *bool
 	OpenWorldHint   *bool
+	// How the messages sent by a server-streaming method are combined into
+	// the tool result. Defaults to STREAM_RESULT_ALL.
+	StreamResult *StreamResult
+	// Client-streaming and bidi methods are rejected by the generator unless
+	// this is set. The tool then takes an array of input messages under
+	// "messages", which are sent to the method's stream in order.
+	AllowClientStreaming *bool
+	// Keeps the method off the tool list, whatever the service's method_exposure.
+	Exclude *bool
+	// The MCP tool name, overriding the name derived by the tool_naming plugin
+	// parameter. It is still prefixed with the service's tool_prefix.
+	Name *string
+	// How the tool arguments are decoded, overriding the service's
+	// argument_decoding.
+	ArgumentDecoding *ArgumentDecoding
 }
 
 func (b0 MethodOptions_builder) Build() *MethodOptions {
@@ -348,44 +934,67 @@ func (b0 MethodOptions_builder) Build() *MethodOptions {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Title != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 11)
 		x.xxx_hidden_Title = b.Title
 	}
 	if b.Description != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 11)
 		x.xxx_hidden_Description = b.Description
 	}
 	if b.ReadOnlyHint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 11)
 		x.xxx_hidden_ReadOnlyHint = *b.ReadOnlyHint
 	}
 	if b.DestructiveHint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 11)
 		x.xxx_hidden_DestructiveHint = *b.DestructiveHint
 	}
 	if b.IdempotentHint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 11)
 		x.xxx_hidden_IdempotentHint = *b.IdempotentHint
 	}
 	if b.OpenWorldHint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 11)
 		x.xxx_hidden_OpenWorldHint = *b.OpenWorldHint
 	}
+	if b.StreamResult != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 11)
+		x.xxx_hidden_StreamResult = *b.StreamResult
+	}
+	if b.AllowClientStreaming != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 11)
+		x.xxx_hidden_AllowClientStreaming = *b.AllowClientStreaming
+	}
+	if b.Exclude != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 11)
+		x.xxx_hidden_Exclude = *b.Exclude
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 11)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.ArgumentDecoding != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 11)
+		x.xxx_hidden_ArgumentDecoding = *b.ArgumentDecoding
+	}
 	return m0
 }
 
 type ServiceOptions struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Enabled     bool                   `protobuf:"varint,1,opt,name=enabled"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Enabled          bool                   `protobuf:"varint,1,opt,name=enabled"`
+	xxx_hidden_MethodExposure   MethodExposure         `protobuf:"varint,2,opt,name=method_exposure,json=methodExposure,enum=mcpgw.v1.MethodExposure"`
+	xxx_hidden_ToolPrefix       *string                `protobuf:"bytes,3,opt,name=tool_prefix,json=toolPrefix"`
+	xxx_hidden_ArgumentDecoding ArgumentDecoding       `protobuf:"varint,4,opt,name=argument_decoding,json=argumentDecoding,enum=mcpgw.v1.ArgumentDecoding"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *ServiceOptions) Reset() {
 	*x = ServiceOptions{}
-	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[3]
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +1006,7 @@ func (x *ServiceOptions) String() string {
 func (*ServiceOptions) ProtoMessage() {}
 
 func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[3]
+	mi := &file_mcpgw_v1_mcpgw_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,9 +1024,52 @@ func (x *ServiceOptions) GetEnabled() bool {
 	return false
 }
 
+func (x *ServiceOptions) GetMethodExposure() MethodExposure {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 1) {
+			return x.xxx_hidden_MethodExposure
+		}
+	}
+	return MethodExposure_METHOD_EXPOSURE_UNSPECIFIED
+}
+
+func (x *ServiceOptions) GetToolPrefix() string {
+	if x != nil {
+		if x.xxx_hidden_ToolPrefix != nil {
+			return *x.xxx_hidden_ToolPrefix
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ServiceOptions) GetArgumentDecoding() ArgumentDecoding {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 3) {
+			return x.xxx_hidden_ArgumentDecoding
+		}
+	}
+	return ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED
+}
+
 func (x *ServiceOptions) SetEnabled(v bool) {
 	x.xxx_hidden_Enabled = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ServiceOptions) SetMethodExposure(v MethodExposure) {
+	x.xxx_hidden_MethodExposure = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ServiceOptions) SetToolPrefix(v string) {
+	x.xxx_hidden_ToolPrefix = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ServiceOptions) SetArgumentDecoding(v ArgumentDecoding) {
+	x.xxx_hidden_ArgumentDecoding = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
 func (x *ServiceOptions) HasEnabled() bool {
@@ -427,15 +1079,59 @@ func (x *ServiceOptions) HasEnabled() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *ServiceOptions) HasMethodExposure() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ServiceOptions) HasToolPrefix() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ServiceOptions) HasArgumentDecoding() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
 func (x *ServiceOptions) ClearEnabled() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Enabled = false
 }
 
+func (x *ServiceOptions) ClearMethodExposure() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_MethodExposure = MethodExposure_METHOD_EXPOSURE_UNSPECIFIED
+}
+
+func (x *ServiceOptions) ClearToolPrefix() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ToolPrefix = nil
+}
+
+func (x *ServiceOptions) ClearArgumentDecoding() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ArgumentDecoding = ArgumentDecoding_ARGUMENT_DECODING_UNSPECIFIED
+}
+
 type ServiceOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Enabled *bool
+	// Which methods of an enabled service are exposed as tools.
+	// Defaults to METHOD_EXPOSURE_ALL.
+	MethodExposure *MethodExposure
+	// Prepended to the tool name of every method of the service.
+	ToolPrefix *string
+	// How the tool arguments of the service's methods are decoded.
+	// Defaults to ARGUMENT_DECODING_STRICT.
+	ArgumentDecoding *ArgumentDecoding
 }
 
 func (b0 ServiceOptions_builder) Build() *ServiceOptions {
@@ -443,9 +1139,21 @@ func (b0 ServiceOptions_builder) Build() *ServiceOptions {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Enabled != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
 		x.xxx_hidden_Enabled = *b.Enabled
 	}
+	if b.MethodExposure != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_MethodExposure = *b.MethodExposure
+	}
+	if b.ToolPrefix != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_ToolPrefix = b.ToolPrefix
+	}
+	if b.ArgumentDecoding != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_ArgumentDecoding = *b.ArgumentDecoding
+	}
 	return m0
 }
 
@@ -482,6 +1190,14 @@ var file_mcpgw_v1_mcpgw_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,8653,opt,name=message",
 		Filename:      "mcpgw/v1/mcpgw.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*EnumValueOptions)(nil),
+		Field:         8654,
+		Name:          "mcpgw.v1.enum_value",
+		Tag:           "bytes,8654,opt,name=enum_value",
+		Filename:      "mcpgw/v1/mcpgw.proto",
+	},
 }
 
 // Extension fields to descriptorpb.ServiceOptions.
@@ -508,55 +1224,107 @@ var (
 	E_Message = &file_mcpgw_v1_mcpgw_proto_extTypes[3]
 )
 
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// optional mcpgw.v1.EnumValueOptions enum_value = 8654;
+	E_EnumValue = &file_mcpgw_v1_mcpgw_proto_extTypes[4]
+)
+
 var File_mcpgw_v1_mcpgw_proto protoreflect.FileDescriptor
 
 const file_mcpgw_v1_mcpgw_proto_rawDesc = "" +
 	"\n" +
 	"\x14mcpgw/v1/mcpgw.proto\x12\bmcpgw.v1\x1a google/protobuf/descriptor.proto\x1a!google/protobuf/go_features.proto\"\x10\n" +
-	"\x0eMessageOptions\"0\n" +
+	"\x0eMessageOptions\"\xfb\x01\n" +
 	"\fFieldOptions\x12 \n" +
-	"\vdescription\x18\x01 \x01(\tR\vdescription\"\xe9\x01\n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bexamples\x18\x02 \x03(\tR\bexamples\x12\x18\n" +
+	"\adefault\x18\x03 \x01(\tR\adefault\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x1b\n" +
+	"\tread_only\x18\x06 \x01(\bR\breadOnly\x12\x1d\n" +
+	"\n" +
+	"write_only\x18\a \x01(\bR\twriteOnly\x12)\n" +
+	"\x10server_populated\x18\b \x01(\bR\x0fserverPopulated\"4\n" +
+	"\x10EnumValueOptions\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\"\xd3\x03\n" +
 	"\rMethodOptions\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12$\n" +
 	"\x0eread_only_hint\x18\x03 \x01(\bR\freadOnlyHint\x12)\n" +
 	"\x10destructive_hint\x18\x04 \x01(\bR\x0fdestructiveHint\x12'\n" +
 	"\x0fidempotent_hint\x18\x05 \x01(\bR\x0eidempotentHint\x12&\n" +
-	"\x0fopen_world_hint\x18\x06 \x01(\bR\ropenWorldHint\"*\n" +
+	"\x0fopen_world_hint\x18\x06 \x01(\bR\ropenWorldHint\x12;\n" +
+	"\rstream_result\x18\a \x01(\x0e2\x16.mcpgw.v1.StreamResultR\fstreamResult\x124\n" +
+	"\x16allow_client_streaming\x18\b \x01(\bR\x14allowClientStreaming\x12\x18\n" +
+	"\aexclude\x18\t \x01(\bR\aexclude\x12\x12\n" +
+	"\x04name\x18\n" +
+	" \x01(\tR\x04name\x12G\n" +
+	"\x11argument_decoding\x18\v \x01(\x0e2\x1a.mcpgw.v1.ArgumentDecodingR\x10argumentDecoding\"\xd7\x01\n" +
 	"\x0eServiceOptions\x12\x18\n" +
-	"\aenabled\x18\x01 \x01(\bR\aenabled:T\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12A\n" +
+	"\x0fmethod_exposure\x18\x02 \x01(\x0e2\x18.mcpgw.v1.MethodExposureR\x0emethodExposure\x12\x1f\n" +
+	"\vtool_prefix\x18\x03 \x01(\tR\n" +
+	"toolPrefix\x12G\n" +
+	"\x11argument_decoding\x18\x04 \x01(\x0e2\x1a.mcpgw.v1.ArgumentDecodingR\x10argumentDecoding*\\\n" +
+	"\fStreamResult\x12\x1d\n" +
+	"\x19STREAM_RESULT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11STREAM_RESULT_ALL\x10\x01\x12\x16\n" +
+	"\x12STREAM_RESULT_LAST\x10\x02*i\n" +
+	"\x0eMethodExposure\x12\x1f\n" +
+	"\x1bMETHOD_EXPOSURE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13METHOD_EXPOSURE_ALL\x10\x01\x12\x1d\n" +
+	"\x19METHOD_EXPOSURE_ANNOTATED\x10\x02*r\n" +
+	"\x10ArgumentDecoding\x12!\n" +
+	"\x1dARGUMENT_DECODING_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ARGUMENT_DECODING_STRICT\x10\x01\x12\x1d\n" +
+	"\x19ARGUMENT_DECODING_LENIENT\x10\x02:T\n" +
 	"\aservice\x12\x1f.google.protobuf.ServiceOptions\x18\xcaC \x01(\v2\x18.mcpgw.v1.ServiceOptionsR\aservice:P\n" +
 	"\x06method\x12\x1e.google.protobuf.MethodOptions\x18\xcbC \x01(\v2\x17.mcpgw.v1.MethodOptionsR\x06method:L\n" +
 	"\x05field\x12\x1d.google.protobuf.FieldOptions\x18\xccC \x01(\v2\x16.mcpgw.v1.FieldOptionsR\x05field:T\n" +
-	"\amessage\x12\x1f.google.protobuf.MessageOptions\x18\xcdC \x01(\v2\x18.mcpgw.v1.MessageOptionsR\amessageB\x91\x01\n" +
+	"\amessage\x12\x1f.google.protobuf.MessageOptions\x18\xcdC \x01(\v2\x18.mcpgw.v1.MessageOptionsR\amessage:]\n" +
+	"\n" +
+	"enum_value\x12!.google.protobuf.EnumValueOptions\x18\xceC \x01(\v2\x1a.mcpgw.v1.EnumValueOptionsR\tenumValueB\x91\x01\n" +
 	"\fcom.mcpgw.v1B\n" +
 	"McpgwProtoP\x01Z,github.com/ductone/protoc-gen-mcpgw/mcpgw/v1\xa2\x02\x03MXX\xaa\x02\bMcpgw.V1\xca\x02\bMcpgw\\V1\xe2\x02\x14Mcpgw\\V1\\GPBMetadata\xea\x02\tMcpgw::V1\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
-var file_mcpgw_v1_mcpgw_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_mcpgw_v1_mcpgw_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_mcpgw_v1_mcpgw_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_mcpgw_v1_mcpgw_proto_goTypes = []any{
-	(*MessageOptions)(nil),              // 0: mcpgw.v1.MessageOptions
-	(*FieldOptions)(nil),                // 1: mcpgw.v1.FieldOptions
-	(*MethodOptions)(nil),               // 2: mcpgw.v1.MethodOptions
-	(*ServiceOptions)(nil),              // 3: mcpgw.v1.ServiceOptions
-	(*descriptorpb.ServiceOptions)(nil), // 4: google.protobuf.ServiceOptions
-	(*descriptorpb.MethodOptions)(nil),  // 5: google.protobuf.MethodOptions
-	(*descriptorpb.FieldOptions)(nil),   // 6: google.protobuf.FieldOptions
-	(*descriptorpb.MessageOptions)(nil), // 7: google.protobuf.MessageOptions
+	(StreamResult)(0),                     // 0: mcpgw.v1.StreamResult
+	(MethodExposure)(0),                   // 1: mcpgw.v1.MethodExposure
+	(ArgumentDecoding)(0),                 // 2: mcpgw.v1.ArgumentDecoding
+	(*MessageOptions)(nil),                // 3: mcpgw.v1.MessageOptions
+	(*FieldOptions)(nil),                  // 4: mcpgw.v1.FieldOptions
+	(*EnumValueOptions)(nil),              // 5: mcpgw.v1.EnumValueOptions
+	(*MethodOptions)(nil),                 // 6: mcpgw.v1.MethodOptions
+	(*ServiceOptions)(nil),                // 7: mcpgw.v1.ServiceOptions
+	(*descriptorpb.ServiceOptions)(nil),   // 8: google.protobuf.ServiceOptions
+	(*descriptorpb.MethodOptions)(nil),    // 9: google.protobuf.MethodOptions
+	(*descriptorpb.FieldOptions)(nil),     // 10: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),   // 11: google.protobuf.MessageOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 12: google.protobuf.EnumValueOptions
 }
 var file_mcpgw_v1_mcpgw_proto_depIdxs = []int32{
-	4, // 0: mcpgw.v1.service:extendee -> google.protobuf.ServiceOptions
-	5, // 1: mcpgw.v1.method:extendee -> google.protobuf.MethodOptions
-	6, // 2: mcpgw.v1.field:extendee -> google.protobuf.FieldOptions
-	7, // 3: mcpgw.v1.message:extendee -> google.protobuf.MessageOptions
-	3, // 4: mcpgw.v1.service:type_name -> mcpgw.v1.ServiceOptions
-	2, // 5: mcpgw.v1.method:type_name -> mcpgw.v1.MethodOptions
-	1, // 6: mcpgw.v1.field:type_name -> mcpgw.v1.FieldOptions
-	0, // 7: mcpgw.v1.message:type_name -> mcpgw.v1.MessageOptions
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	4, // [4:8] is the sub-list for extension type_name
-	0, // [0:4] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: mcpgw.v1.MethodOptions.stream_result:type_name -> mcpgw.v1.StreamResult
+	2,  // 1: mcpgw.v1.MethodOptions.argument_decoding:type_name -> mcpgw.v1.ArgumentDecoding
+	1,  // 2: mcpgw.v1.ServiceOptions.method_exposure:type_name -> mcpgw.v1.MethodExposure
+	2,  // 3: mcpgw.v1.ServiceOptions.argument_decoding:type_name -> mcpgw.v1.ArgumentDecoding
+	8,  // 4: mcpgw.v1.service:extendee -> google.protobuf.ServiceOptions
+	9,  // 5: mcpgw.v1.method:extendee -> google.protobuf.MethodOptions
+	10, // 6: mcpgw.v1.field:extendee -> google.protobuf.FieldOptions
+	11, // 7: mcpgw.v1.message:extendee -> google.protobuf.MessageOptions
+	12, // 8: mcpgw.v1.enum_value:extendee -> google.protobuf.EnumValueOptions
+	7,  // 9: mcpgw.v1.service:type_name -> mcpgw.v1.ServiceOptions
+	6,  // 10: mcpgw.v1.method:type_name -> mcpgw.v1.MethodOptions
+	4,  // 11: mcpgw.v1.field:type_name -> mcpgw.v1.FieldOptions
+	3,  // 12: mcpgw.v1.message:type_name -> mcpgw.v1.MessageOptions
+	5,  // 13: mcpgw.v1.enum_value:type_name -> mcpgw.v1.EnumValueOptions
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	9,  // [9:14] is the sub-list for extension type_name
+	4,  // [4:9] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_mcpgw_v1_mcpgw_proto_init() }
@@ -569,13 +1337,14 @@ func file_mcpgw_v1_mcpgw_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mcpgw_v1_mcpgw_proto_rawDesc), len(file_mcpgw_v1_mcpgw_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
-			NumExtensions: 4,
+			NumEnums:      3,
+			NumMessages:   5,
+			NumExtensions: 5,
 			NumServices:   0,
 		},
 		GoTypes:           file_mcpgw_v1_mcpgw_proto_goTypes,
 		DependencyIndexes: file_mcpgw_v1_mcpgw_proto_depIdxs,
+		EnumInfos:         file_mcpgw_v1_mcpgw_proto_enumTypes,
 		MessageInfos:      file_mcpgw_v1_mcpgw_proto_msgTypes,
 		ExtensionInfos:    file_mcpgw_v1_mcpgw_proto_extTypes,
 	}.Build()
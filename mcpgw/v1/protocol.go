@@ -0,0 +1,119 @@
+package v1
+
+import "encoding/json"
+
+// MCP protocol revisions understood by Server, newest first.
+// See: https://modelcontextprotocol.io/specification
+var supportedProtocolVersions = []string{
+	"2025-06-18",
+	"2025-03-26",
+	"2024-11-05",
+}
+
+// MCP method names handled by Server.
+const (
+	mcpMethodInitialize  = "initialize"
+	mcpMethodInitialized = "notifications/initialized"
+	mcpMethodCancelled   = "notifications/cancelled"
+	mcpMethodPing        = "ping"
+	mcpMethodToolsList   = "tools/list"
+	mcpMethodToolsCall   = "tools/call"
+	mcpMethodProgress    = "notifications/progress"
+)
+
+// Implementation describes the name and version of an MCP server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type initializeParams struct {
+	ProtocolVersion string          `json:"protocolVersion"`
+	Capabilities    json.RawMessage `json:"capabilities,omitempty"`
+	ClientInfo      *Implementation `json:"clientInfo,omitempty"`
+}
+
+type initializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    serverCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+type serverCapabilities struct {
+	Tools *toolsCapability `json:"tools,omitempty"`
+}
+
+type toolsCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
+// Tool is the MCP description of a registered method, as returned by tools/list.
+type Tool struct {
+	Name        string         `json:"name"`
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema"`
+	// OutputSchema describes the structuredContent of successful results.
+	OutputSchema map[string]any   `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are the behavioural hints of a tool.
+//
+// The hints are always serialized: MCP clients assume destructive and
+// open world behaviour when the corresponding hint is absent.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    bool   `json:"readOnlyHint"`
+	DestructiveHint bool   `json:"destructiveHint"`
+	IdempotentHint  bool   `json:"idempotentHint"`
+	OpenWorldHint   bool   `json:"openWorldHint"`
+}
+
+type listToolsResult struct {
+	Tools []*Tool `json:"tools"`
+}
+
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Meta      *requestMeta    `json:"_meta,omitempty"`
+}
+
+type requestMeta struct {
+	ProgressToken json.RawMessage `json:"progressToken,omitempty"`
+}
+
+type progressParams struct {
+	ProgressToken json.RawMessage `json:"progressToken"`
+	Progress      int             `json:"progress"`
+	Message       string          `json:"message,omitempty"`
+}
+
+type cancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+	Reason    string          `json:"reason,omitempty"`
+}
+
+// CallToolResult is the result of a tools/call request.
+type CallToolResult struct {
+	Content []*Content `json:"content"`
+	// StructuredContent is the JSON object form of the result, if any.
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// Content is a single MCP content block.
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// TextContent returns a text content block.
+func TextContent(text string) *Content {
+	return &Content{
+		Type: "text",
+		Text: text,
+	}
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ResultEncoder converts the response of a method handler into a CallToolResult.
+type ResultEncoder struct {
+	// MarshalOptions controls the JSON form of the response, eg EmitUnpopulated,
+	// UseProtoNames or UseEnumNumbers.
+	MarshalOptions protojson.MarshalOptions
+}
+
+// Encode marshals resp with protojson and returns a result carrying it both as
+// structuredContent and, for clients that predate structured output, as a text block.
+//
+// structuredContent must be a JSON object, so it is omitted for responses whose
+// JSON form is not, such as google.protobuf.Value or google.protobuf.Timestamp.
+func (e ResultEncoder) Encode(resp proto.Message) (*CallToolResult, error) {
+	data, err := e.MarshalOptions.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	rv := &CallToolResult{
+		Content: []*Content{TextContent(string(data))},
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		rv.StructuredContent = data
+	}
+	return rv, nil
+}
+
+// WithMarshalOptions sets the protojson options used to encode every tool result.
+func WithMarshalOptions(opts protojson.MarshalOptions) ServerOption {
+	return func(o *serverOptions) {
+		o.marshalOptions = opts
+	}
+}
+
+// WithMethodMarshalOptions sets the protojson options used to encode the results
+// of a single method, overriding WithMarshalOptions. The method is identified by
+// its full name as found in MethodDesc.Method, eg "/bookstore.v1.BookstoreService/GetBook".
+func WithMethodMarshalOptions(method string, opts protojson.MarshalOptions) ServerOption {
+	return func(o *serverOptions) {
+		if o.methodMarshalOptions == nil {
+			o.methodMarshalOptions = make(map[string]protojson.MarshalOptions)
+		}
+		o.methodMarshalOptions[method] = opts
+	}
+}
+
+func (s *Server) resultEncoder(md *MethodDesc) ResultEncoder {
+	if opts, ok := s.opts.methodMarshalOptions[md.Method]; ok {
+		return ResultEncoder{MarshalOptions: opts}
+	}
+	return ResultEncoder{MarshalOptions: s.opts.marshalOptions}
+}
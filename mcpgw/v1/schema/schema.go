@@ -9,12 +9,109 @@ import (
 
 var cache = sync.Map{}
 
-func GenerateSchema(md protoreflect.MessageDescriptor) (map[string]any, error) {
-	cacheKey := md.FullName()
+// Option configures GenerateSchema.
+type Option func(*options)
+
+type options struct {
+	defs           bool
+	inlineDepth    int
+	dialect        Dialect
+	enumNumbers    bool
+	input          bool
+	streamMessages bool
+}
+
+// WithDefs puts the schema of each nested message once under "$defs" and
+// refers to it with "$ref", instead of inlining it.
+func WithDefs() Option {
+	return func(o *options) {
+		o.defs = true
+	}
+}
+
+// WithInlineDepth limits how many levels of nested messages are inlined.
+func WithInlineDepth(depth int) Option {
+	return func(o *options) {
+		o.inlineDepth = depth
+	}
+}
+
+// WithEnumNumbers accepts the numbers of enum values as well as their names.
+func WithEnumNumbers() Option {
+	return func(o *options) {
+		o.enumNumbers = true
+	}
+}
+
+// WithoutServerPopulated leaves out the fields marked server_populated, for
+// the input schemas of tools.
+func WithoutServerPopulated() Option {
+	return func(o *options) {
+		o.input = true
+	}
+}
+
+// WithStreamMessages describes a stream of messages, for the tools of
+// streaming methods, under a "messages" property.
+func WithStreamMessages() Option {
+	return func(o *options) {
+		o.streamMessages = true
+	}
+}
+
+// Dialect names the schema dialect of a model provider.
+type Dialect = jsonschema.Dialect
+
+const (
+	DialectDraft202012  = jsonschema.DialectDraft202012
+	DialectDraft07      = jsonschema.DialectDraft07
+	DialectOpenAPI30    = jsonschema.DialectOpenAPI30
+	DialectOpenAIStrict = jsonschema.DialectOpenAIStrict
+	DialectGemini       = jsonschema.DialectGemini
+)
+
+// WithDialect generates schemas in a dialect other than draft 2020-12, for
+// providers that reject some of its keywords.
+func WithDialect(d Dialect) Option {
+	return func(o *options) {
+		o.dialect = d
+	}
+}
+
+type cacheKey struct {
+	name protoreflect.FullName
+	opts options
+}
+
+func GenerateSchema(md protoreflect.MessageDescriptor, opts ...Option) (map[string]any, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cacheKey := cacheKey{name: md.FullName(), opts: o}
 	if cached, ok := cache.Load(cacheKey); ok {
 		return cached.(map[string]any), nil
 	}
-	schema, err := jsonschema.GenerateJSONSchema(md)
+	var schemaOpts []jsonschema.Option
+	if o.defs {
+		schemaOpts = append(schemaOpts, jsonschema.WithDefs())
+	}
+	if o.inlineDepth > 0 {
+		schemaOpts = append(schemaOpts, jsonschema.WithInlineDepth(o.inlineDepth))
+	}
+	if o.dialect != "" {
+		schemaOpts = append(schemaOpts, jsonschema.WithDialect(o.dialect))
+	}
+	if o.enumNumbers {
+		schemaOpts = append(schemaOpts, jsonschema.WithEnumNumbers())
+	}
+	if o.input {
+		schemaOpts = append(schemaOpts, jsonschema.WithoutServerPopulated())
+	}
+	if o.streamMessages {
+		schemaOpts = append(schemaOpts, jsonschema.WithStreamMessages())
+	}
+	schema, err := jsonschema.GenerateJSONSchema(md, schemaOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -22,10 +119,17 @@ func GenerateSchema(md protoreflect.MessageDescriptor) (map[string]any, error) {
 	return schema, nil
 }
 
-func MustGenerateSchema(md protoreflect.MessageDescriptor) map[string]any {
-	schema, err := GenerateSchema(md)
+func MustGenerateSchema(md protoreflect.MessageDescriptor, opts ...Option) map[string]any {
+	schema, err := GenerateSchema(md, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return schema
 }
+
+// RegisterComments records the leading comments of messages and fields, keyed
+// by full name, as their schema descriptions. It is called by generated code,
+// since the descriptors compiled into a binary don't retain source comments.
+func RegisterComments(comments map[string]string) {
+	jsonschema.RegisterComments(comments)
+}
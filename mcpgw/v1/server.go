@@ -0,0 +1,560 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"buf.build/go/protovalidate"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Server is an MCP server that exposes the methods of registered services as MCP tools.
+//
+// Services are registered with the generated RegisterMCP<Service>Server functions,
+// and the server is then run over a transport such as ServeStdio.
+type Server struct {
+	opts serverOptions
+
+	mu       sync.RWMutex
+	services map[string]*serviceInfo
+	tools    map[string]*toolInfo
+	// toolNames preserves registration order for tools/list.
+	toolNames []string
+}
+
+var _ ServiceRegistrar = (*Server)(nil)
+
+type serviceInfo struct {
+	desc *ServiceDesc
+	impl any
+}
+
+type toolInfo struct {
+	name    string
+	service *serviceInfo
+	method  *MethodDesc
+	// inputSchema compiles the input schema of the method on first use, see
+	// WithSchemaValidation.
+	inputSchema func() (*jsonschema.Schema, error)
+}
+
+type serverOptions struct {
+	implementation     Implementation
+	instructions       string
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+
+	marshalOptions       protojson.MarshalOptions
+	methodMarshalOptions map[string]protojson.MarshalOptions
+
+	schemaValidation bool
+	validator        protovalidate.Validator
+	injectors        map[protoreflect.FullName]FieldInjector
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*serverOptions)
+
+// WithImplementation sets the server name and version reported to clients during initialization.
+func WithImplementation(name string, version string) ServerOption {
+	return func(o *serverOptions) {
+		o.implementation = Implementation{Name: name, Version: version}
+	}
+}
+
+// WithInstructions sets the instructions returned to clients during initialization.
+func WithInstructions(instructions string) ServerOption {
+	return func(o *serverOptions) {
+		o.instructions = instructions
+	}
+}
+
+// WithUnaryInterceptors adds interceptors that wrap every tool call, in the same
+// way grpc.ChainUnaryInterceptor does for a gRPC server. The first interceptor is the outermost.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds interceptors that wrap every call of a streaming
+// method, in the same way grpc.ChainStreamInterceptor does for a gRPC server.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithSchemaValidation checks the arguments of every tool call against the
+// input schema of the tool before decoding them. Arguments the schema rejects
+// are reported as ArgumentErrors, like those that can't be decoded.
+//
+// Valid values that protojson accepts in another form than the schema
+// describes, such as 64-bit integers given as numbers, enum values given by
+// number and fields given by their proto name, are checked in the form of the
+// schema, so the check doesn't narrow the arguments a tool accepts.
+//
+// The schema of a tool is compiled on its first call.
+func WithSchemaValidation() ServerOption {
+	return func(o *serverOptions) {
+		o.schemaValidation = true
+	}
+}
+
+// WithProtoValidation validates the request messages of every tool call with
+// protovalidate, after the interceptors and right before the handler, so the
+// changes interceptors make are validated. Violations are reported as
+// ArgumentErrors. A nil validator stands for protovalidate.GlobalValidator.
+func WithProtoValidation(validator protovalidate.Validator) ServerOption {
+	return func(o *serverOptions) {
+		if validator == nil {
+			validator = protovalidate.GlobalValidator
+		}
+		o.validator = validator
+	}
+}
+
+// WithFieldInjector sets a field marked server_populated in the requests of
+// tool calls, wherever the field is found, with the value returned by
+// injector. field is the full name of the field, eg
+// "bookstore.v1.CreateBookRequest.tenant_id".
+//
+// Fields are injected after the interceptors and right before the handler, so
+// injectors see the context the interceptors pass on, such as the claims of an
+// authenticated caller. Requests are validated once injected.
+func WithFieldInjector(field protoreflect.FullName, injector FieldInjector) ServerOption {
+	return func(o *serverOptions) {
+		if o.injectors == nil {
+			o.injectors = make(map[protoreflect.FullName]FieldInjector)
+		}
+		o.injectors[field] = injector
+	}
+}
+
+// NewServer creates an MCP server with no registered services.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		opts: serverOptions{
+			implementation: Implementation{Name: "mcpgw", Version: "0.1.0"},
+		},
+		services: make(map[string]*serviceInfo),
+		tools:    make(map[string]*toolInfo),
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+	return s
+}
+
+// RegisterService registers a service and its implementation with the server.
+// It mirrors grpc.Server.RegisterService and panics on invalid or duplicate registrations.
+func (s *Server) RegisterService(sd *ServiceDesc, ss any) {
+	if ss != nil {
+		ht := reflect.TypeOf(sd.HandlerType).Elem()
+		st := reflect.TypeOf(ss)
+		if !st.Implements(ht) {
+			panic(fmt.Sprintf("mcpgw: Server.RegisterService found the handler of type %v that does not satisfy %v", st, ht))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.services[sd.Name]; ok {
+		panic(fmt.Sprintf("mcpgw: Server.RegisterService found duplicate service registration for %q", sd.Name))
+	}
+	names := make(map[string]*MethodDesc, len(sd.Methods))
+	for _, md := range sd.Methods {
+		name := toolName(md)
+		if t, ok := s.tools[name]; ok {
+			panic(fmt.Sprintf("mcpgw: Server.RegisterService found duplicate tool name %q for %s, already used by %s", name, md.Method, t.method.Method))
+		}
+		if other, ok := names[name]; ok {
+			panic(fmt.Sprintf("mcpgw: Server.RegisterService found duplicate tool name %q for %s, already used by %s", name, md.Method, other.Method))
+		}
+		names[name] = md
+	}
+	info := &serviceInfo{
+		desc: sd,
+		impl: ss,
+	}
+	s.services[sd.Name] = info
+	for _, md := range sd.Methods {
+		name := toolName(md)
+		s.tools[name] = &toolInfo{
+			name:        name,
+			service:     info,
+			method:      md,
+			inputSchema: sync.OnceValues(func() (*jsonschema.Schema, error) { return compileInputSchema(md) }),
+		}
+		s.toolNames = append(s.toolNames, name)
+	}
+}
+
+// toolName returns the MCP tool name of a method. Descriptors without a Name
+// fall back to the full gRPC method name, eg "/bookstore.v1.BookstoreService/GetBook"
+// becomes "bookstore_v1_BookstoreService_GetBook".
+func toolName(md *MethodDesc) string {
+	if md.Name != "" {
+		return md.Name
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimPrefix(md.Method, "/"))
+}
+
+func (s *Server) interceptor() grpc.UnaryServerInterceptor {
+	interceptors := s.opts.unaryInterceptors
+	if s.prepares() {
+		interceptors = append(slices.Clip(interceptors), s.prepareUnary)
+	}
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	default:
+		return ChainUnaryInterceptors(interceptors)
+	}
+}
+
+func (s *Server) streamInterceptor() grpc.StreamServerInterceptor {
+	interceptors := s.opts.streamInterceptors
+	if s.prepares() {
+		interceptors = append(slices.Clip(interceptors), s.prepareStream)
+	}
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	default:
+		return ChainStreamInterceptors(interceptors)
+	}
+}
+
+// session holds the state of a single client connection.
+type session struct {
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+func newSession() *session {
+	return &session{
+		inflight: make(map[string]context.CancelFunc),
+	}
+}
+
+func (s *session) track(id json.RawMessage, cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight[string(id)] = cancel
+}
+
+func (s *session) untrack(id json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inflight, string(id))
+}
+
+func (s *session) cancel(id json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cancel, ok := s.inflight[string(id)]; ok {
+		cancel()
+	}
+}
+
+func (s *session) cancelAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cancel := range s.inflight {
+		cancel()
+	}
+}
+
+// callContextFunc decorates the context of a tool call with transport specific
+// state, such as the incoming gRPC metadata derived from an HTTP request.
+type callContextFunc func(ctx context.Context, md *MethodDesc) context.Context
+
+type callContextKey struct{}
+
+func withCallContext(ctx context.Context, fn callContextFunc) context.Context {
+	return context.WithValue(ctx, callContextKey{}, fn)
+}
+
+// notifyFunc sends a message to the client outside of a response, such as a
+// progress notification. It is provided by the transport through the context.
+type notifyFunc func(msg *jsonrpcMessage) error
+
+type notifyKey struct{}
+
+func withNotifier(ctx context.Context, fn notifyFunc) context.Context {
+	return context.WithValue(ctx, notifyKey{}, fn)
+}
+
+func notifierFromContext(ctx context.Context) notifyFunc {
+	fn, _ := ctx.Value(notifyKey{}).(notifyFunc)
+	return fn
+}
+
+// handleMessage processes a single JSON-RPC message and returns the response to send,
+// or nil if the message does not warrant a response.
+func (s *Server) handleMessage(ctx context.Context, sess *session, msg *jsonrpcMessage) *jsonrpcMessage {
+	if msg.JSONRPC != jsonrpcVersion {
+		return newErrorResponse(msg.ID, &JSONRPCError{Code: JSONRPCInvalidRequest, Message: "invalid jsonrpc version"})
+	}
+	switch {
+	case msg.isNotification():
+		s.handleNotification(sess, msg)
+		return nil
+	case msg.isRequest():
+		result, err := s.handleRequest(ctx, msg)
+		if err != nil {
+			return newErrorResponse(msg.ID, toJSONRPCError(err))
+		}
+		return newResponse(msg.ID, result)
+	case msg.Method == "" && (msg.Result != nil || msg.Error != nil):
+		// Responses to server initiated requests; the server doesn't send any.
+		return nil
+	default:
+		return newErrorResponse(msg.ID, &JSONRPCError{Code: JSONRPCInvalidRequest, Message: "invalid request"})
+	}
+}
+
+func (s *Server) handleNotification(sess *session, msg *jsonrpcMessage) {
+	switch msg.Method {
+	case mcpMethodInitialized:
+		// No per-session state depends on initialization completing.
+	case mcpMethodCancelled:
+		params := &cancelledParams{}
+		if err := json.Unmarshal(msg.Params, params); err == nil {
+			sess.cancel(params.RequestID)
+		}
+	}
+}
+
+func (s *Server) handleRequest(ctx context.Context, msg *jsonrpcMessage) (any, error) {
+	switch msg.Method {
+	case mcpMethodInitialize:
+		params := &initializeParams{}
+		if err := unmarshalParams(msg.Params, params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+	case mcpMethodPing:
+		return struct{}{}, nil
+	case mcpMethodToolsList:
+		return s.listTools(), nil
+	case mcpMethodToolsCall:
+		params := &callToolParams{}
+		if err := unmarshalParams(msg.Params, params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+	default:
+		return nil, &JSONRPCError{Code: JSONRPCMethodNotFound, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+	}
+}
+
+func unmarshalParams(raw json.RawMessage, out any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func toJSONRPCError(err error) *JSONRPCError {
+	rpcErr := &JSONRPCError{}
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	return &JSONRPCError{Code: JSONRPCInternalError, Message: err.Error()}
+}
+
+func (s *Server) initialize(params *initializeParams) *initializeResult {
+	version := supportedProtocolVersions[0]
+	if slices.Contains(supportedProtocolVersions, params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+	return &initializeResult{
+		ProtocolVersion: version,
+		Capabilities: serverCapabilities{
+			Tools: &toolsCapability{},
+		},
+		ServerInfo:   s.opts.implementation,
+		Instructions: s.opts.instructions,
+	}
+}
+
+func (s *Server) listTools() *listToolsResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rv := &listToolsResult{
+		Tools: make([]*Tool, 0, len(s.toolNames)),
+	}
+	for _, name := range s.toolNames {
+		rv.Tools = append(rv.Tools, s.tools[name].tool())
+	}
+	return rv
+}
+
+func (t *toolInfo) tool() *Tool {
+	md := t.method
+	rv := &Tool{
+		Name:        t.name,
+		Title:       md.Title,
+		Description: md.Description,
+		Annotations: &ToolAnnotations{
+			Title:           md.Title,
+			ReadOnlyHint:    md.ReadOnlyHint,
+			DestructiveHint: md.Destructive,
+			IdempotentHint:  md.Idempotent,
+			OpenWorldHint:   md.OpenWorldHint,
+		},
+	}
+	if md.InputSchema != nil {
+		rv.InputSchema = md.InputSchema()
+	} else {
+		rv.InputSchema = map[string]any{"type": "object"}
+	}
+	if md.OutputSchema != nil {
+		// structuredContent is always an object, so other schemas can't describe it.
+		schema := md.OutputSchema()
+		if schema["type"] == "object" {
+			rv.OutputSchema = schema
+		}
+	}
+	return rv
+}
+
+func (s *Server) lookupTool(name string) *toolInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tools[name]
+}
+
+// decodeError marks errors returned by a MethodDesc's Decoder, so they
+// can be told apart from errors returned by the service implementation.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func (s *Server) callTool(ctx context.Context, params *callToolParams) (*CallToolResult, error) {
+	t := s.lookupTool(params.Name)
+	if t == nil {
+		return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+	}
+	input, err := newCallInput(t.method.Method, params.Arguments)
+	if err != nil {
+		return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: err.Error()}
+	}
+
+	if fn, ok := ctx.Value(callContextKey{}).(callContextFunc); ok {
+		ctx = fn(ctx, t.method)
+	}
+	ctx = NewMethodDescContext(ctx, t.method)
+	ctx, repairs := withRepairLog(ctx)
+	var rv *CallToolResult
+	if t.method.StreamHandler != nil {
+		rv, err = s.callStream(ctx, t, input, params.Meta)
+	} else {
+		rv, err = s.callUnary(ctx, t, input)
+	}
+	if rv != nil {
+		repairs.annotate(rv)
+	}
+	return rv, err
+}
+
+func (s *Server) callUnary(ctx context.Context, t *toolInfo, input *callInput) (*CallToolResult, error) {
+	dec := func(m proto.Message) error {
+		return s.decode(ctx, t, input, m)
+	}
+	resp, err := t.method.Handler(t.service.impl, ctx, dec, s.interceptor())
+	if err != nil {
+		return s.callError(t, err)
+	}
+
+	return s.resultEncoder(t.method).Encode(resp)
+}
+
+// callError converts an error returned by a method into the response of a
+// tools/call request: decode failures are invalid params, anything else is a tool error.
+//
+// The data of invalid params holds the ToolError of ArgumentErrors, whose
+// BadRequest details locate each invalid argument.
+func (s *Server) callError(t *toolInfo, err error) (*CallToolResult, error) {
+	dErr := &decodeError{}
+	if !errors.As(err, &dErr) {
+		return ErrorResult(err), nil
+	}
+	rpcErr := &JSONRPCError{Code: JSONRPCInvalidParams, Message: fmt.Sprintf("invalid arguments for tool %s: %s", t.name, dErr.err)}
+	var argErrs ArgumentErrors
+	if errors.As(dErr.err, &argErrs) {
+		toolErr, _ := newToolError(argErrs.GRPCStatus())
+		rpcErr.Data = map[string]*ToolError{"error": toolErr}
+	}
+	return nil, rpcErr
+}
+
+// callInput implements DecoderInput for a tools/call request.
+type callInput struct {
+	method string
+	raw    json.RawMessage
+	args   map[string]any
+}
+
+var _ DecoderInput = (*callInput)(nil)
+
+func newCallInput(method string, raw json.RawMessage) (*callInput, error) {
+	rv := &callInput{
+		method: method,
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		rv.args = map[string]any{}
+		return rv, nil
+	}
+	if err := json.Unmarshal(raw, &rv.args); err != nil {
+		return nil, fmt.Errorf("tool arguments must be a JSON object: %w", err)
+	}
+	rv.raw = raw
+	return rv, nil
+}
+
+func (c *callInput) Method() string {
+	return c.method
+}
+
+func (c *callInput) Arguments() map[string]any {
+	return c.args
+}
+
+func (c *callInput) RawArguments() json.RawMessage {
+	return c.raw
+}
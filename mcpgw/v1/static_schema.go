@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// StaticSchema returns the schema handler of a JSON Schema embedded in
+// generated code. The schema is parsed once, when first used.
+func StaticSchema(data string) func() map[string]any {
+	return sync.OnceValue(func() map[string]any {
+		rv := map[string]any{}
+		if err := json.Unmarshal([]byte(data), &rv); err != nil {
+			panic(fmt.Errorf("mcpgw: StaticSchema: invalid schema: %w", err))
+		}
+		return rv
+	})
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// maxStdioMessageSize bounds the size of a single newline delimited message read by Serve.
+const maxStdioMessageSize = 16 * 1024 * 1024
+
+// ServeStdio serves MCP over the process's standard input and output,
+// returning when standard input is closed.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve serves MCP using the stdio transport: newline delimited JSON-RPC
+// messages are read from r, and responses are written to w.
+//
+// Requests are handled concurrently, so a slow tool call doesn't block pings
+// or cancellations. Serve returns once r is exhausted and all in-flight
+// requests have completed.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := &stdioWriter{w: w}
+	ctx = withNotifier(ctx, out.write)
+	sess := newSession()
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg := &jsonrpcMessage{}
+		if err := json.Unmarshal(line, msg); err != nil {
+			if err := out.write(newErrorResponse(nil, &JSONRPCError{Code: JSONRPCParseError, Message: err.Error()})); err != nil {
+				return err
+			}
+			continue
+		}
+		if !msg.isRequest() {
+			if resp := s.handleMessage(ctx, sess, msg); resp != nil {
+				if err := out.write(resp); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		reqCtx, reqCancel := context.WithCancel(ctx)
+		sess.track(msg.ID, reqCancel)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer reqCancel()
+			defer sess.untrack(msg.ID)
+			if resp := s.handleMessage(reqCtx, sess, msg); resp != nil {
+				// A failed write means the client went away; there is nobody to report it to.
+				_ = out.write(resp)
+			}
+		}()
+	}
+	return scanner.Err()
+}
+
+// stdioWriter serializes messages written by concurrent requests.
+type stdioWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (sw *stdioWriter) write(msg *jsonrpcMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	_, err = sw.w.Write(data)
+	return err
+}
@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// toolServerStream implements grpc.ServerStream over a single tools/call request.
+//
+// The tool arguments are received as the request message, or as a sequence
+// of request messages for client-streaming methods. Every message sent by the
+// method is collected for the result and reported as progress.
+type toolServerStream struct {
+	ctx     context.Context
+	method  *MethodDesc
+	inputs  []DecoderInput
+	decode  func(ctx context.Context, input DecoderInput, m proto.Message) error
+	encoder ResultEncoder
+
+	progressToken json.RawMessage
+	notify        notifyFunc
+
+	mu       sync.Mutex
+	received int
+	sent     []proto.Message
+}
+
+var _ grpc.ServerStream = (*toolServerStream)(nil)
+
+func (ts *toolServerStream) Context() context.Context {
+	return ts.ctx
+}
+
+// Headers and trailers have no equivalent in a tool result, so they are discarded.
+func (ts *toolServerStream) SetHeader(metadata.MD) error  { return nil }
+func (ts *toolServerStream) SendHeader(metadata.MD) error { return nil }
+func (ts *toolServerStream) SetTrailer(metadata.MD)       {}
+
+func (ts *toolServerStream) RecvMsg(m any) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.received == len(ts.inputs) {
+		return io.EOF
+	}
+	idx := ts.received
+	ts.received++
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("mcpgw: RecvMsg called with non-proto message %T", m)
+	}
+	ctx := ts.ctx
+	prefix := ""
+	if ts.method.ClientStreams {
+		prefix = fmt.Sprintf("/messages/%d", idx)
+		ctx = withRepairPrefix(ctx, prefix)
+	}
+	if err := ts.decode(ctx, ts.inputs[idx], msg); err != nil {
+		dErr := &decodeError{}
+		if prefix != "" && errors.As(err, &dErr) {
+			err = &decodeError{err: prefixArgumentErrors(dErr.err, prefix)}
+		}
+		return err
+	}
+	return nil
+}
+
+func (ts *toolServerStream) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("mcpgw: SendMsg called with non-proto message %T", m)
+	}
+	if err := ts.ctx.Err(); err != nil {
+		return err
+	}
+	// Handlers may reuse the message they send, so keep a copy.
+	msg = proto.Clone(msg)
+
+	ts.mu.Lock()
+	ts.sent = append(ts.sent, msg)
+	count := len(ts.sent)
+	ts.mu.Unlock()
+
+	if !ts.method.ServerStreams || len(ts.progressToken) == 0 || ts.notify == nil {
+		return nil
+	}
+	data, err := ts.encoder.MarshalOptions.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	notification, err := newNotification(mcpMethodProgress, &progressParams{
+		ProgressToken: ts.progressToken,
+		Progress:      count,
+		Message:       string(data),
+	})
+	if err != nil {
+		return err
+	}
+	// Progress is best effort: a client that stopped listening still gets the result.
+	_ = ts.notify(notification)
+	return nil
+}
+
+// result aggregates the sent messages according to the method's StreamResult.
+// Methods that are not server-streaming send a single response, which is the result.
+func (ts *toolServerStream) result() (*CallToolResult, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if !ts.method.ServerStreams || ts.method.StreamResult == StreamResult_STREAM_RESULT_LAST {
+		if len(ts.sent) == 0 {
+			return &CallToolResult{
+				Content:           []*Content{TextContent("{}")},
+				StructuredContent: json.RawMessage("{}"),
+			}, nil
+		}
+		return ts.encoder.Encode(ts.sent[len(ts.sent)-1])
+	}
+
+	messages := make([]json.RawMessage, 0, len(ts.sent))
+	for _, msg := range ts.sent {
+		data, err := ts.encoder.MarshalOptions.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, data)
+	}
+	data, err := json.Marshal(&streamMessages{Messages: messages})
+	if err != nil {
+		return nil, err
+	}
+	return &CallToolResult{
+		Content:           []*Content{TextContent(string(data))},
+		StructuredContent: data,
+	}, nil
+}
+
+// streamMessages is the form of a sequence of streamed messages in tool arguments,
+// and in the result of a server-streaming method using STREAM_RESULT_ALL.
+type streamMessages struct {
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// splitStreamInput returns one DecoderInput per message in the "messages"
+// argument of a client-streaming tool.
+func splitStreamInput(input *callInput) ([]DecoderInput, error) {
+	raw := input.RawArguments()
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("missing required argument \"messages\"")
+	}
+	args := &streamMessages{}
+	if err := json.Unmarshal(raw, args); err != nil {
+		return nil, fmt.Errorf("argument \"messages\" must be an array of objects: %w", err)
+	}
+	if args.Messages == nil {
+		return nil, fmt.Errorf("missing required argument \"messages\"")
+	}
+	rv := make([]DecoderInput, 0, len(args.Messages))
+	for i, msg := range args.Messages {
+		item, err := newCallInput(input.Method(), msg)
+		if err != nil {
+			return nil, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		rv = append(rv, item)
+	}
+	return rv, nil
+}
+
+func (s *Server) callStream(ctx context.Context, t *toolInfo, input *callInput, meta *requestMeta) (*CallToolResult, error) {
+	md := t.method
+	inputs := []DecoderInput{input}
+	if md.ClientStreams {
+		var err error
+		if inputs, err = splitStreamInput(input); err != nil {
+			return nil, &JSONRPCError{Code: JSONRPCInvalidParams, Message: fmt.Sprintf("invalid arguments for tool %s: %s", t.name, err)}
+		}
+	}
+	decode := func(ctx context.Context, input DecoderInput, m proto.Message) error {
+		return s.decode(ctx, t, input, m)
+	}
+	stream := &toolServerStream{
+		ctx:     ctx,
+		method:  md,
+		inputs:  inputs,
+		decode:  decode,
+		encoder: s.resultEncoder(md),
+		notify:  notifierFromContext(ctx),
+	}
+	if meta != nil {
+		stream.progressToken = meta.ProgressToken
+	}
+
+	var err error
+	if interceptor := s.streamInterceptor(); interceptor != nil {
+		info := &grpc.StreamServerInfo{
+			FullMethod:     md.Method,
+			IsClientStream: md.ClientStreams,
+			IsServerStream: md.ServerStreams,
+		}
+		err = interceptor(t.service.impl, stream, info, md.StreamHandler)
+	} else {
+		err = md.StreamHandler(t.service.impl, stream)
+	}
+	if err != nil {
+		return s.callError(t, err)
+	}
+	return stream.result()
+}
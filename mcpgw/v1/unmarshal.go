@@ -1,20 +1,611 @@
 package v1
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
+// UnmarshalFromMap unmarshals args, the JSON form of a message decoded into a
+// map, into out. It walks the map with protoreflect instead of encoding it
+// back to JSON, and accepts exactly what protojson.Unmarshal accepts for the
+// JSON encoding of args, with the same result. The exception is fields marked
+// server_populated, which are rejected.
+//
+// Values are expected to be of the types encoding/json decodes into, with
+// numbers as float64 or json.Number. Other values are converted through their
+// JSON encoding.
+//
+// Decoding goes on past invalid arguments, and the error is ArgumentErrors,
+// listing every problem found.
 func UnmarshalFromMap(args map[string]any, out proto.Message) error {
-	// TODO(pquerna): future optimziation: avoid json marshalling
-	//
-	// We use this function from generated code, so we can later
-	// optimize this to avoid json marshalling.
-	jsonArgs, err := json.Marshal(args)
+	proto.Reset(out)
+	// Room for the path of most arguments
+	d := mapDecoder{depth: protowire.DefaultRecursionLimit, path: make([]string, 0, 8)}
+	v, err := plain(args)
 	if err != nil {
-		return err
+		return argumentErrors(err, "")
 	}
-	return protojson.Unmarshal(jsonArgs, out)
+	if err := d.decodeMessage(out.ProtoReflect(), v, false); err != nil {
+		return argumentErrors(err, "").sorted()
+	}
+	if err := proto.CheckInitialized(out); err != nil {
+		return argumentErrors(err, "")
+	}
+	return nil
+}
+
+// mapDecoder decodes the JSON form of messages, following protojson. It is
+// passed by value, so depth is the recursion budget left at each level, and
+// path the reference tokens of the decoded value.
+//
+// Siblings share the backing array of path, which is fine as values are
+// decoded one at a time, and pointers only made for errors.
+type mapDecoder struct {
+	depth int
+	path  []string
+}
+
+// at returns the decoder of a member of the decoded value.
+func (d mapDecoder) at(token string) mapDecoder {
+	d.path = append(d.path, token)
+	return d
+}
+
+// pointer returns the JSON Pointer of the decoded value.
+func (d mapDecoder) pointer() string {
+	var b strings.Builder
+	for _, token := range d.path {
+		b.WriteString(pointerTo("", token))
+	}
+	return b.String()
+}
+
+// invalid reports a value that isn't of the expected type.
+func (d mapDecoder) invalid(expected string, v any) *ArgumentError {
+	return &ArgumentError{
+		Pointer:     d.pointer(),
+		Expected:    expected,
+		Value:       v,
+		Description: fmt.Sprintf("expected %s, got %s", expected, valueText(v)),
+	}
+}
+
+// errorf reports a problem with the decoded value, other than its type.
+func (d mapDecoder) errorf(format string, args ...any) *ArgumentError {
+	return &ArgumentError{
+		Pointer:     d.pointer(),
+		Description: fmt.Sprintf(format, args...),
+	}
+}
+
+// collect adds err, returned by d, to errs.
+func (d mapDecoder) collect(errs ArgumentErrors, err error) ArgumentErrors {
+	if err == nil {
+		return errs
+	}
+	var argErrs ArgumentErrors
+	if !errors.As(err, &argErrs) {
+		argErrs = argumentErrors(err, d.pointer())
+	}
+	return append(errs, argErrs...)
+}
+
+// decodeMessage decodes v, which must be a JSON object unless m is a well
+// known type. With skipTypeURL, the "@type" of an Any is ignored.
+func (d mapDecoder) decodeMessage(m protoreflect.Message, v any, skipTypeURL bool) error {
+	d.depth--
+	if d.depth < 0 {
+		return d.errorf("exceeded max recursion depth")
+	}
+	if decode := wellKnownDecoder(m.Descriptor().FullName()); decode != nil {
+		return decode(d, m, v)
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return d.invalid("object", v)
+	}
+	md := m.Descriptor()
+	if isMessageSet(md) {
+		return d.errorf("no support for proto1 MessageSets")
+	}
+
+	var errs ArgumentErrors
+	var conflicts []protoreflect.OneofDescriptor
+	for key, value := range obj {
+		name := validUTF8(key)
+		if skipTypeURL && name == "@type" {
+			continue
+		}
+		fdec := d.at(name)
+
+		fd, err := messageField(md, name)
+		if err != nil {
+			errs = fdec.collect(errs, err)
+			continue
+		}
+		if fd == nil {
+			errs = append(errs, fdec.errorf("unknown field"))
+			continue
+		}
+		if serverPopulated(fd) {
+			errs = append(errs, fdec.errorf("the field is set by the server"))
+			continue
+		}
+		if other := otherName(fd, name); other != "" {
+			if _, ok := obj[other]; ok {
+				// Reported once, for both names
+				if name < other {
+					errs = append(errs, fdec.errorf("duplicate field, also given as %q", other))
+				}
+				continue
+			}
+		}
+
+		value, err := plain(value)
+		if err != nil {
+			errs = fdec.collect(errs, err)
+			continue
+		}
+		// No need to set values for null unless the field type is
+		// google.protobuf.Value or google.protobuf.NullValue.
+		if value == nil && !isKnownValue(fd) && !isNullValue(fd) {
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			values, ok := value.([]any)
+			if !ok {
+				errs = append(errs, fdec.invalid("array", value))
+				continue
+			}
+			errs = fdec.collect(errs, fdec.decodeList(m.Mutable(fd).List(), fd, values))
+		case fd.IsMap():
+			entries, ok := value.(map[string]any)
+			if !ok {
+				errs = append(errs, fdec.invalid("object", value))
+				continue
+			}
+			errs = fdec.collect(errs, fdec.decodeMap(m.Mutable(fd).Map(), fd, entries))
+		default:
+			if od := fd.ContainingOneof(); od != nil && oneofSetTwice(od, fd, obj) {
+				if !slices.Contains(conflicts, od) {
+					conflicts = append(conflicts, od)
+				}
+				continue
+			}
+			errs = fdec.collect(errs, fdec.decodeSingular(m, fd, value))
+		}
+	}
+
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len() && len(conflicts) > 0; i++ {
+		if od := oneofs.Get(i); slices.Contains(conflicts, od) {
+			errs = append(errs, d.errorf("%s", oneofConflict(od, obj)))
+		}
+	}
+	return errs.orNil()
+}
+
+// messageField looks a key of a JSON object up in the fields of md, by JSON
+// name, then proto name. Extensions are named "[full.name]".
+func messageField(md protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, error) {
+	if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+		extType, err := protoregistry.GlobalTypes.FindExtensionByName(protoreflect.FullName(name[1 : len(name)-1]))
+		if err != nil && !errors.Is(err, protoregistry.NotFound) {
+			return nil, fmt.Errorf("unable to resolve %q: %w", name, err)
+		}
+		if extType == nil {
+			return nil, nil
+		}
+		fd := extType.TypeDescriptor()
+		if !md.ExtensionRanges().Has(fd.Number()) || fd.ContainingMessage().FullName() != md.FullName() {
+			return nil, fmt.Errorf("message %v cannot be extended by %v", md.FullName(), fd.FullName())
+		}
+		return fd, nil
+	}
+	fields := md.Fields()
+	if fd := fields.ByJSONName(name); fd != nil {
+		return fd, nil
+	}
+	return fields.ByTextName(name), nil
+}
+
+// otherName returns the other name a field could be given by in the same
+// object, if it has one.
+func otherName(fd protoreflect.FieldDescriptor, name string) string {
+	if fd.IsExtension() {
+		return ""
+	}
+	fields := fd.ContainingMessage().Fields()
+	switch {
+	case name != fd.JSONName() && fields.ByJSONName(fd.JSONName()) == fd:
+		return fd.JSONName()
+	case name != fd.TextName() && fields.ByJSONName(fd.TextName()) == nil:
+		return fd.TextName()
+	}
+	return ""
+}
+
+// oneofSetTwice reports whether obj also sets another member of od than fd.
+// Like a field that isn't set, a member given null doesn't count, unless null
+// is one of its values.
+func oneofSetTwice(od protoreflect.OneofDescriptor, fd protoreflect.FieldDescriptor, obj map[string]any) bool {
+	fields := od.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		member := fields.Get(i)
+		if member == fd {
+			continue
+		}
+		if oneofMemberSet(member, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func oneofMemberSet(member protoreflect.FieldDescriptor, obj map[string]any) bool {
+	for _, name := range []string{member.JSONName(), member.TextName()} {
+		value, ok := obj[name]
+		if !ok {
+			continue
+		}
+		if value, err := plain(value); err != nil || value != nil || isKnownValue(member) || isNullValue(member) {
+			return true
+		}
+	}
+	return false
+}
+
+// oneofConflict describes obj setting more than one member of od, in the
+// terms of the input schema.
+func oneofConflict(od protoreflect.OneofDescriptor, obj map[string]any) string {
+	var members, set []string
+	fields := od.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		member := fields.Get(i)
+		members = append(members, member.JSONName())
+		if oneofMemberSet(member, obj) {
+			set = append(set, member.JSONName())
+		}
+	}
+	return fmt.Sprintf("only one of the %s oneof members %s may be set, got %s",
+		od.Name(), strings.Join(members, ", "), strings.Join(set, ", "))
+}
+
+func (d mapDecoder) decodeSingular(m protoreflect.Message, fd protoreflect.FieldDescriptor, v any) error {
+	var val protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		val = m.NewField(fd)
+		if err := d.decodeMessage(val.Message(), v, false); err != nil {
+			return err
+		}
+	default:
+		var ok bool
+		if val, ok = scalarValue(fd, v); !ok {
+			return d.invalid(expectedType(fd), v)
+		}
+	}
+	if val.IsValid() {
+		m.Set(fd, val)
+	}
+	return nil
+}
+
+func (d mapDecoder) decodeList(list protoreflect.List, fd protoreflect.FieldDescriptor, values []any) error {
+	var errs ArgumentErrors
+	for i, v := range values {
+		idec := d.at(strconv.Itoa(i))
+		v, err := plain(v)
+		if err != nil {
+			errs = idec.collect(errs, err)
+			continue
+		}
+		switch fd.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			val := list.NewElement()
+			if err := idec.decodeMessage(val.Message(), v, false); err != nil {
+				errs = idec.collect(errs, err)
+				continue
+			}
+			list.Append(val)
+		default:
+			val, ok := scalarValue(fd, v)
+			if !ok {
+				errs = append(errs, idec.invalid(expectedType(fd), v))
+				continue
+			}
+			if val.IsValid() {
+				list.Append(val)
+			}
+		}
+	}
+	return errs.orNil()
+}
+
+func (d mapDecoder) decodeMap(mmap protoreflect.Map, fd protoreflect.FieldDescriptor, entries map[string]any) error {
+	var errs ArgumentErrors
+	for name, v := range entries {
+		name := validUTF8(name)
+		edec := d.at(name)
+		key, ok := mapKey(fd.MapKey(), name)
+		if !ok {
+			errs = append(errs, edec.errorf("invalid %v map key", fd.MapKey().Kind()))
+			continue
+		}
+		if mmap.Has(key) {
+			// Equal keys may be written differently, eg "1" and "01"
+			errs = append(errs, d.errorf("duplicate map key %v", key.Interface()))
+			continue
+		}
+
+		v, err := plain(v)
+		if err != nil {
+			errs = edec.collect(errs, err)
+			continue
+		}
+		var val protoreflect.Value
+		switch fd.MapValue().Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			val = mmap.NewValue()
+			if err := edec.decodeMessage(val.Message(), v, false); err != nil {
+				errs = edec.collect(errs, err)
+				continue
+			}
+		default:
+			if val, ok = scalarValue(fd.MapValue(), v); !ok {
+				errs = append(errs, edec.invalid(expectedType(fd.MapValue()), v))
+				continue
+			}
+		}
+		if val.IsValid() {
+			mmap.Set(key, val)
+		}
+	}
+	return errs.orNil()
+}
+
+// mapKey converts the name of a JSON object member into a map key.
+func mapKey(fd protoreflect.FieldDescriptor, name string) (protoreflect.MapKey, bool) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(name).MapKey(), true
+
+	case protoreflect.BoolKind:
+		switch name {
+		case "true":
+			return protoreflect.ValueOfBool(true).MapKey(), true
+		case "false":
+			return protoreflect.ValueOfBool(false).MapKey(), true
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, err := strconv.ParseInt(name, 10, 32); err == nil {
+			return protoreflect.ValueOfInt32(int32(n)).MapKey(), true
+		}
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, err := strconv.ParseInt(name, 10, 64); err == nil {
+			return protoreflect.ValueOfInt64(n).MapKey(), true
+		}
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, err := strconv.ParseUint(name, 10, 32); err == nil {
+			return protoreflect.ValueOfUint32(uint32(n)).MapKey(), true
+		}
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, err := strconv.ParseUint(name, 10, 64); err == nil {
+			return protoreflect.ValueOfUint64(n).MapKey(), true
+		}
+	}
+	return protoreflect.MapKey{}, false
+}
+
+// scalarValue decodes the value of a scalar or enum field.
+func scalarValue(fd protoreflect.FieldDescriptor, v any) (protoreflect.Value, bool) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if b, ok := v.(bool); ok {
+			return protoreflect.ValueOfBool(b), true
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, ok := intValue(v, 32); ok {
+			return protoreflect.ValueOfInt32(int32(n)), true
+		}
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, ok := intValue(v, 64); ok {
+			return protoreflect.ValueOfInt64(n), true
+		}
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, ok := uintValue(v, 32); ok {
+			return protoreflect.ValueOfUint32(uint32(n)), true
+		}
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, ok := uintValue(v, 64); ok {
+			return protoreflect.ValueOfUint64(n), true
+		}
+
+	case protoreflect.FloatKind:
+		if f, ok := floatValue(v, 32); ok {
+			return protoreflect.ValueOfFloat32(float32(f)), true
+		}
+
+	case protoreflect.DoubleKind:
+		if f, ok := floatValue(v, 64); ok {
+			return protoreflect.ValueOfFloat64(f), true
+		}
+
+	case protoreflect.StringKind:
+		if s, ok := v.(string); ok {
+			return protoreflect.ValueOfString(validUTF8(s)), true
+		}
+
+	case protoreflect.BytesKind:
+		if b, ok := bytesValue(v); ok {
+			return protoreflect.ValueOfBytes(b), true
+		}
+
+	case protoreflect.EnumKind:
+		if n, ok := enumValue(fd, v); ok {
+			return protoreflect.ValueOfEnum(n), true
+		}
+	}
+	return protoreflect.Value{}, false
+}
+
+// expectedType describes the JSON value of a scalar or enum field.
+func expectedType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if isNullValue(fd) {
+			return "null"
+		}
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return "one of " + strings.Join(names, ", ")
+	case protoreflect.BytesKind:
+		return "base64 string"
+	}
+	return fd.Kind().String()
+}
+
+func enumValue(fd protoreflect.FieldDescriptor, v any) (protoreflect.EnumNumber, bool) {
+	switch v := v.(type) {
+	case string:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(v)); ev != nil {
+			return ev.Number(), true
+		}
+	case nil:
+		// This is only valid for google.protobuf.NullValue.
+		return 0, isNullValue(fd)
+	default:
+		if n, ok := intValue(v, 32); ok {
+			return protoreflect.EnumNumber(n), true
+		}
+	}
+	return 0, false
+}
+
+// bytesValue decodes base64, standard or URL safe, with or without padding.
+func bytesValue(v any) ([]byte, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	enc := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.URLEncoding
+	}
+	if len(s)%4 != 0 {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+func isKnownValue(fd protoreflect.FieldDescriptor) bool {
+	md := fd.Message()
+	return md != nil && md.FullName() == "google.protobuf.Value"
+}
+
+func isNullValue(fd protoreflect.FieldDescriptor) bool {
+	ed := fd.Enum()
+	return ed != nil && ed.FullName() == "google.protobuf.NullValue"
+}
+
+func isMessageSet(md protoreflect.MessageDescriptor) bool {
+	xmd, ok := md.(interface{ IsMessageSet() bool })
+	return ok && xmd.IsMessageSet()
+}
+
+// plain returns v as one of the types encoding/json decodes JSON into, with
+// numbers also as any Go number type. Nil maps and slices, which encode as
+// null, become nil. Other values are converted through their JSON encoding.
+func plain(v any) (any, error) {
+	switch x := v.(type) {
+	case nil, bool, string, json.Number,
+		float64, float32, int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, uintptr:
+		return v, nil
+	case map[string]any:
+		if x == nil {
+			return nil, nil
+		}
+		return x, nil
+	case []any:
+		if x == nil {
+			return nil, nil
+		}
+		return x, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var rv any
+	if err := dec.Decode(&rv); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// validUTF8 replaces each invalid byte of s with U+FFFD, as encoding/json does.
+func validUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteRune(utf8.RuneError)
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// valueText shows a value in errors. Objects and arrays are only named.
+func valueText(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
 }
@@ -0,0 +1,329 @@
+// Parts of this file are based on google.golang.org/protobuf, as marked, and
+// are used under its license, see third_party/protobuf-go/LICENSE.
+
+package v1
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Numbers are read like protojson reads the JSON encoding of the argument
+// map: a float64 is first written the way encoding/json writes it, so large
+// values round the same way, and strings may hold a number.
+
+// intValue returns v as a signed integer of bitSize bits.
+func intValue(v any, bitSize int) (int64, bool) {
+	// Integers below 2^53 are written exactly
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		n := int64(f)
+		return n, bitSize == 64 || n == int64(int32(n))
+	}
+	s, ok := intText(v)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(s, 10, bitSize)
+	return n, err == nil
+}
+
+// uintValue returns v as an unsigned integer of bitSize bits.
+func uintValue(v any, bitSize int) (uint64, bool) {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		if f < 0 {
+			return 0, false
+		}
+		n := uint64(f)
+		return n, bitSize == 64 || n == uint64(uint32(n))
+	}
+	s, ok := intText(v)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, 10, bitSize)
+	return n, err == nil
+}
+
+// floatValue returns v as a floating point number of bitSize bits. Strings may
+// also be "NaN", "Infinity" or "-Infinity".
+func floatValue(v any, bitSize int) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		// The shortest form encoding/json writes reads back the same
+		if bitSize == 64 {
+			return x, !math.IsNaN(x) && !math.IsInf(x, 0)
+		}
+	case string:
+		switch x {
+		case "NaN":
+			return math.NaN(), true
+		case "Infinity":
+			return math.Inf(+1), true
+		case "-Infinity":
+			return math.Inf(-1), true
+		}
+	}
+	s, ok := numberToken(v)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, bitSize)
+	return f, err == nil
+}
+
+// intText returns the decimal integer v stands for, if it is an integer.
+func intText(v any) (string, bool) {
+	s, ok := numberToken(v)
+	if !ok {
+		return "", false
+	}
+	return normalizeToIntString(s)
+}
+
+// numberToken returns the JSON number v is encoded as, or that a string v
+// starts with. The string must not have surrounding whitespace, and the number
+// must be followed by a delimiter.
+func numberToken(v any) (string, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return numberText(v)
+	}
+	if len(strings.TrimSpace(s)) != len(s) {
+		return "", false
+	}
+	n, ok := scanNumber(s)
+	if !ok {
+		return "", false
+	}
+	return s[:n], true
+}
+
+// numberText returns the JSON encoding of a number, as encoding/json writes it.
+func numberText(v any) (string, bool) {
+	switch x := v.(type) {
+	case float64:
+		return floatText(x, 64)
+	case float32:
+		return floatText(float64(x), 32)
+	case int:
+		return strconv.FormatInt(int64(x), 10), true
+	case int8:
+		return strconv.FormatInt(int64(x), 10), true
+	case int16:
+		return strconv.FormatInt(int64(x), 10), true
+	case int32:
+		return strconv.FormatInt(int64(x), 10), true
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(x), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(x), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(x), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(x), 10), true
+	case uint64:
+		return strconv.FormatUint(x, 10), true
+	case uintptr:
+		return strconv.FormatUint(uint64(x), 10), true
+	case json.Number:
+		s := x.String()
+		if s == "" {
+			s = "0"
+		}
+		n, ok := scanNumber(s)
+		return s, ok && n == len(s)
+	}
+	return "", false
+}
+
+// floatText formats f like encoding/json, which has no form for NaN and
+// infinities.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/internal/encoding/json/encode.go,
+// Copyright 2018 The Go Authors.
+func floatText(f float64, bits int) (string, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", false
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(nil, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return string(b), true
+}
+
+// scanNumber returns the length of the JSON number s starts with. The number
+// must be followed by a delimiter, or be all of s.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/internal/encoding/json/decode_number.go,
+// Copyright 2018 The Go Authors.
+func scanNumber(s string) (int, bool) {
+	var n int
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	// Optional -
+	if s[n] == '-' {
+		n++
+		if n == len(s) {
+			return 0, false
+		}
+	}
+
+	// Digits
+	switch {
+	case s[n] == '0':
+		n++
+	case '1' <= s[n] && s[n] <= '9':
+		n++
+		for n < len(s) && isDigit(s[n]) {
+			n++
+		}
+	default:
+		return 0, false
+	}
+
+	// . followed by 1 or more digits.
+	if n+1 < len(s) && s[n] == '.' && isDigit(s[n+1]) {
+		n += 2
+		for n < len(s) && isDigit(s[n]) {
+			n++
+		}
+	}
+
+	// e or E followed by an optional - or + and 1 or more digits.
+	if n+1 < len(s) && (s[n] == 'e' || s[n] == 'E') {
+		n++
+		if s[n] == '+' || s[n] == '-' {
+			n++
+			if n == len(s) {
+				return 0, false
+			}
+		}
+		for n < len(s) && isDigit(s[n]) {
+			n++
+		}
+	}
+
+	// Check that next byte is a delimiter or it is at the end.
+	if n < len(s) && isNotDelim(s[n]) {
+		return 0, false
+	}
+	return n, true
+}
+
+// normalizeToIntString returns the JSON number s as a decimal integer, without
+// an exponent. It fails if s is not an integer or its exponent is too large.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/internal/encoding/json/decode_number.go,
+// Copyright 2018 The Go Authors.
+func normalizeToIntString(s string) (string, bool) {
+	var neg bool
+	if s[0] == '-' {
+		neg = true
+		s = s[1:]
+	}
+
+	// Split the number into its integer part, fraction and exponent
+	var intp, frac, exp string
+	end := strings.IndexAny(s, ".eE")
+	if end < 0 {
+		end = len(s)
+	}
+	if s[0] != '0' {
+		intp = s[:end]
+	}
+	s = s[end:]
+	if strings.HasPrefix(s, ".") {
+		end = strings.IndexAny(s, "eE")
+		if end < 0 {
+			end = len(s)
+		}
+		frac = strings.TrimRight(s[1:end], "0")
+		s = s[end:]
+	}
+	if len(s) > 0 {
+		exp = s[1:]
+		// The exponent is checked first, as zero doesn't need it. A sign must
+		// be followed by digits; an e alone is left out, like protojson does.
+		if exp == "+" || exp == "-" {
+			return "", false
+		}
+	}
+
+	if intp == "" && frac == "" {
+		return "0", true
+	}
+
+	var e int
+	if exp != "" {
+		i, err := strconv.ParseInt(exp, 10, 32)
+		if err != nil {
+			return "", false
+		}
+		e = int(i)
+	}
+
+	var num string
+	if e >= 0 {
+		// Shift fraction digits into the integer part, padding with zeroes.
+		if len(frac) > e {
+			return "", false
+		}
+		// Max uint64 value has 20 decimal digits.
+		if len(intp)+e > 20 {
+			return "", false
+		}
+		num = intp + frac + strings.Repeat("0", e-len(frac))
+	} else {
+		// Shift digits of the integer part out, which must all be zeroes.
+		if len(frac) > 0 {
+			return "", false
+		}
+		index := len(intp) + e
+		if index < 0 {
+			return "", false
+		}
+		if strings.Trim(intp[index:], "0") != "" {
+			return "", false
+		}
+		num = intp[:index]
+	}
+
+	if neg {
+		return "-" + num, true
+	}
+	return num, true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isNotDelim reports whether c may continue a literal, so it is not a
+// delimiter.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/internal/encoding/json/decode.go,
+// Copyright 2018 The Go Authors.
+func isNotDelim(c byte) bool {
+	return c == '-' || c == '+' || c == '.' || c == '_' ||
+		('a' <= c && c <= 'z') ||
+		('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9')
+}
@@ -0,0 +1,431 @@
+// Parts of this file are based on google.golang.org/protobuf, as marked, and
+// are used under its license, see third_party/protobuf-go/LICENSE.
+
+package v1
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The well known types have a JSON form of their own, decoded as protojson
+// does.
+
+type wellKnownDecodeFunc func(d mapDecoder, m protoreflect.Message, v any) error
+
+// wellKnownDecoder returns the decoder of a well known type, or nil.
+func wellKnownDecoder(name protoreflect.FullName) wellKnownDecodeFunc {
+	if name.Parent() != "google.protobuf" {
+		return nil
+	}
+	switch name.Name() {
+	case "Any":
+		return mapDecoder.decodeAny
+	case "Timestamp":
+		return mapDecoder.decodeTimestamp
+	case "Duration":
+		return mapDecoder.decodeDuration
+	case "BoolValue", "Int32Value", "Int64Value", "UInt32Value", "UInt64Value",
+		"FloatValue", "DoubleValue", "StringValue", "BytesValue":
+		return mapDecoder.decodeWrapper
+	case "Struct":
+		return mapDecoder.decodeStruct
+	case "ListValue":
+		return mapDecoder.decodeListValue
+	case "Value":
+		return mapDecoder.decodeKnownValue
+	case "FieldMask":
+		return mapDecoder.decodeFieldMask
+	case "Empty":
+		return mapDecoder.decodeEmpty
+	}
+	return nil
+}
+
+// decodeAny decodes an Any from the fields of the message it holds, along
+// with its "@type". Well known types are held under "value" instead.
+func (d mapDecoder) decodeAny(m protoreflect.Message, v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return d.invalid("object", v)
+	}
+	typeValue, ok := obj["@type"]
+	if !ok {
+		// An empty JSON object translates to an empty Any message.
+		if len(obj) == 0 {
+			return nil
+		}
+		return d.errorf(`missing "@type" field`)
+	}
+	tdec := d.at("@type")
+	typeValue, err := plain(typeValue)
+	if err != nil {
+		return tdec.collect(nil, err)
+	}
+	typeURL, ok := typeValue.(string)
+	if !ok {
+		return tdec.invalid("type URL string", typeValue)
+	}
+	typeURL = validUTF8(typeURL)
+	if typeURL == "" {
+		return tdec.invalid("type URL string", typeValue)
+	}
+	emt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return tdec.errorf("unable to resolve %q: %v", typeURL, err)
+	}
+
+	em := emt.New()
+	if decode := wellKnownDecoder(emt.Descriptor().FullName()); decode != nil {
+		if err := d.decodeAnyValue(decode, em, obj); err != nil {
+			return err
+		}
+	} else if err := d.decodeMessage(em, obj, true); err != nil {
+		return err
+	}
+	b, err := proto.MarshalOptions{
+		AllowPartial:  true, // No need to check required fields inside an Any.
+		Deterministic: true,
+	}.Marshal(em.Interface())
+	if err != nil {
+		return d.errorf("error in marshaling Any.value field: %v", err)
+	}
+
+	fields := m.Descriptor().Fields()
+	m.Set(fields.ByName("type_url"), protoreflect.ValueOfString(typeURL))
+	m.Set(fields.ByName("value"), protoreflect.ValueOfBytes(b))
+	return nil
+}
+
+// decodeAnyValue decodes a well known type held by an Any from its "value".
+func (d mapDecoder) decodeAnyValue(decode wellKnownDecodeFunc, m protoreflect.Message, obj map[string]any) error {
+	var errs ArgumentErrors
+	for name := range obj {
+		if name != "@type" && name != "value" {
+			errs = append(errs, d.at(validUTF8(name)).errorf("unknown field"))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	value, ok := obj["value"]
+	if !ok {
+		// An omitted value is tolerated for google.protobuf.Empty.
+		if m.Descriptor().FullName() != "google.protobuf.Empty" {
+			return d.errorf(`missing "value" field`)
+		}
+		return nil
+	}
+	vdec := d.at("value")
+	value, err := plain(value)
+	if err != nil {
+		return vdec.collect(nil, err)
+	}
+	return decode(vdec, m, value)
+}
+
+func (d mapDecoder) decodeWrapper(m protoreflect.Message, v any) error {
+	fd := m.Descriptor().Fields().ByName("value")
+	val, ok := scalarValue(fd, v)
+	if !ok {
+		return d.invalid(expectedType(fd), v)
+	}
+	m.Set(fd, val)
+	return nil
+}
+
+func (d mapDecoder) decodeEmpty(_ protoreflect.Message, v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return d.invalid("object", v)
+	}
+	var errs ArgumentErrors
+	for name := range obj {
+		errs = append(errs, d.at(validUTF8(name)).errorf("unknown field"))
+	}
+	return errs.orNil()
+}
+
+func (d mapDecoder) decodeStruct(m protoreflect.Message, v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return d.invalid("object", v)
+	}
+	fd := m.Descriptor().Fields().ByName("fields")
+	return d.decodeMap(m.Mutable(fd).Map(), fd, obj)
+}
+
+func (d mapDecoder) decodeListValue(m protoreflect.Message, v any) error {
+	values, ok := v.([]any)
+	if !ok {
+		return d.invalid("array", v)
+	}
+	fd := m.Descriptor().Fields().ByName("values")
+	return d.decodeList(m.Mutable(fd).List(), fd, values)
+}
+
+// decodeKnownValue decodes a google.protobuf.Value, which holds any JSON
+// value. Strings always go to string_value, even "NaN".
+func (d mapDecoder) decodeKnownValue(m protoreflect.Message, v any) error {
+	fields := m.Descriptor().Fields()
+	var fd protoreflect.FieldDescriptor
+	var val protoreflect.Value
+	switch x := v.(type) {
+	case nil:
+		fd = fields.ByName("null_value")
+		val = protoreflect.ValueOfEnum(0)
+
+	case bool:
+		fd = fields.ByName("bool_value")
+		val = protoreflect.ValueOfBool(x)
+
+	case string:
+		fd = fields.ByName("string_value")
+		val = protoreflect.ValueOfString(validUTF8(x))
+
+	case map[string]any:
+		fd = fields.ByName("struct_value")
+		val = m.NewField(fd)
+		if err := d.decodeStruct(val.Message(), x); err != nil {
+			return err
+		}
+
+	case []any:
+		fd = fields.ByName("list_value")
+		val = m.NewField(fd)
+		if err := d.decodeListValue(val.Message(), x); err != nil {
+			return err
+		}
+
+	default:
+		if _, ok := numberText(v); !ok {
+			return d.invalid("JSON value", v)
+		}
+		f, ok := floatValue(v, 64)
+		if !ok {
+			return d.invalid("JSON value", v)
+		}
+		fd = fields.ByName("number_value")
+		val = protoreflect.ValueOfFloat64(f)
+	}
+
+	m.Set(fd, val)
+	return nil
+}
+
+// The JSON values of well known types, as told in errors.
+const (
+	durationType  = `duration string such as "1.5s"`
+	timestampType = `RFC 3339 timestamp string such as "2006-01-02T15:04:05Z"`
+	fieldMaskType = `string of comma separated field paths such as "title,shelfId"`
+)
+
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/encoding/protojson/well_known_types.go,
+// Copyright 2019 The Go Authors.
+const (
+	maxSecondsInDuration = 315576000000
+	minTimestampSeconds  = -62135596800
+	maxTimestampSeconds  = 253402300799
+)
+
+func (d mapDecoder) decodeDuration(m protoreflect.Message, v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return d.invalid(durationType, v)
+	}
+	secs, nanos, ok := parseDuration(validUTF8(s))
+	if !ok {
+		return d.invalid(durationType, v)
+	}
+	if secs < -maxSecondsInDuration || secs > maxSecondsInDuration {
+		return d.errorf("duration out of range: %q", s)
+	}
+
+	fields := m.Descriptor().Fields()
+	m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(secs))
+	m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(nanos))
+	return nil
+}
+
+// parseDuration parses a decimal number of seconds with the suffix "s", and
+// up to 9 fractional digits. Example values are 1s, 0.1s, 1.s, .1s, +1s, -1s,
+// -.1s.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/encoding/protojson/well_known_types.go,
+// Copyright 2019 The Go Authors.
+func parseDuration(input string) (int64, int32, bool) {
+	b := []byte(input)
+	size := len(b)
+	if size < 2 {
+		return 0, 0, false
+	}
+	if b[size-1] != 's' {
+		return 0, 0, false
+	}
+	b = b[:size-1]
+
+	// Read optional plus/minus symbol.
+	var neg bool
+	switch b[0] {
+	case '-':
+		neg = true
+		b = b[1:]
+	case '+':
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, 0, false
+	}
+
+	// Read the integer part.
+	var intp []byte
+	switch {
+	case b[0] == '0':
+		b = b[1:]
+
+	case '1' <= b[0] && b[0] <= '9':
+		intp = b[0:]
+		b = b[1:]
+		n := 1
+		for len(b) > 0 && isDigit(b[0]) {
+			n++
+			b = b[1:]
+		}
+		intp = intp[:n]
+
+	case b[0] == '.':
+		// Continue below.
+
+	default:
+		return 0, 0, false
+	}
+
+	hasFrac := false
+	var frac [9]byte
+	if len(b) > 0 {
+		if b[0] != '.' {
+			return 0, 0, false
+		}
+		// Read the fractional part.
+		b = b[1:]
+		n := 0
+		for len(b) > 0 && n < 9 && isDigit(b[0]) {
+			frac[n] = b[0]
+			n++
+			b = b[1:]
+		}
+		// It is not valid if there are more bytes left.
+		if len(b) > 0 {
+			return 0, 0, false
+		}
+		// Pad fractional part with 0s.
+		for i := n; i < 9; i++ {
+			frac[i] = '0'
+		}
+		hasFrac = true
+	}
+
+	var secs int64
+	if len(intp) > 0 {
+		var err error
+		secs, err = strconv.ParseInt(string(intp), 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+
+	var nanos int64
+	if hasFrac {
+		nanob := bytes.TrimLeft(frac[:], "0")
+		if len(nanob) > 0 {
+			var err error
+			nanos, err = strconv.ParseInt(string(nanob), 10, 32)
+			if err != nil {
+				return 0, 0, false
+			}
+		}
+	}
+
+	if neg {
+		if secs > 0 {
+			secs = -secs
+		}
+		if nanos > 0 {
+			nanos = -nanos
+		}
+	}
+	return secs, int32(nanos), true
+}
+
+func (d mapDecoder) decodeTimestamp(m protoreflect.Message, v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return d.invalid(timestampType, v)
+	}
+	s = validUTF8(s)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return d.invalid(timestampType, v)
+	}
+	secs := t.Unix()
+	if secs < minTimestampSeconds || secs > maxTimestampSeconds {
+		return d.errorf("timestamp out of range: %q", s)
+	}
+	// At most 9 fractional digits
+	i := strings.LastIndexByte(s, '.')
+	j := strings.LastIndexAny(s, "Z-+")
+	if i >= 0 && j >= i && j-i > len(".999999999") {
+		return d.invalid(timestampType, v)
+	}
+
+	fields := m.Descriptor().Fields()
+	m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(secs))
+	m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+	return nil
+}
+
+// decodeFieldMask decodes a comma separated list of lowerCamelCase paths.
+func (d mapDecoder) decodeFieldMask(m protoreflect.Message, v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return d.invalid(fieldMaskType, v)
+	}
+	str := strings.TrimSpace(validUTF8(s))
+	if str == "" {
+		return nil
+	}
+
+	fd := m.Descriptor().Fields().ByName("paths")
+	list := m.Mutable(fd).List()
+	for _, s0 := range strings.Split(str, ",") {
+		s := snakeCase(s0)
+		if strings.Contains(s0, "_") || !protoreflect.FullName(s).IsValid() {
+			return d.errorf("invalid field mask path %q", s0)
+		}
+		list.Append(protoreflect.ValueOfString(s))
+	}
+	return nil
+}
+
+// snakeCase converts a lowerCamelCase path to snake_case.
+//
+// Based on https://github.com/protocolbuffers/protobuf-go/blob/v1.36.6/internal/strs/strings.go,
+// Copyright 2019 The Go Authors.
+func snakeCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			b = append(b, '_')
+			c += 'a' - 'A'
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
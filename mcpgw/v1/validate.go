@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// decode decodes the arguments of a tool call into m with the method's
+// Decoder, after checking them against the input schema if the server options
+// ask for it.
+//
+// Invalid arguments are returned as a decodeError; other errors, such as a
+// schema that doesn't compile, are the server's.
+func (s *Server) decode(ctx context.Context, t *toolInfo, input DecoderInput, m proto.Message) error {
+	if s.opts.schemaValidation {
+		if err := t.checkSchema(input, m); err != nil {
+			return err
+		}
+	}
+	if err := t.method.Decoder(ctx, input, m); err != nil {
+		return &decodeError{err: err}
+	}
+	return nil
+}
+
+// prepares reports whether requests are prepared for handlers, by field
+// injectors or protovalidate.
+func (s *Server) prepares() bool {
+	return len(s.opts.injectors) > 0 || s.opts.validator != nil
+}
+
+// prepare fills in the server populated fields of a decoded request and
+// validates it. ctx is the context of the handler, as the interceptors left it.
+func (s *Server) prepare(ctx context.Context, m proto.Message) error {
+	if err := s.injectFields(ctx, m.ProtoReflect()); err != nil {
+		return err
+	}
+	if s.opts.validator != nil {
+		return validateMessage(s.opts.validator, m)
+	}
+	return nil
+}
+
+// prepareUnary is the innermost unary interceptor, which prepares the request
+// right before the handler.
+func (s *Server) prepareUnary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if m, ok := req.(proto.Message); ok {
+		if err := s.prepare(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// prepareStream is the innermost stream interceptor, which prepares every
+// request the handler receives.
+func (s *Server) prepareStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &preparedStream{ServerStream: ss, server: s, clientStreams: info.IsClientStream})
+}
+
+// preparedStream prepares the requests received through the stream the
+// interceptors pass to the handler, with its context.
+type preparedStream struct {
+	grpc.ServerStream
+	server        *Server
+	clientStreams bool
+	received      int
+}
+
+func (ps *preparedStream) RecvMsg(m any) error {
+	if err := ps.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	idx := ps.received
+	ps.received++
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	err := ps.server.prepare(ps.Context(), msg)
+	dErr := &decodeError{}
+	if ps.clientStreams && errors.As(err, &dErr) {
+		err = &decodeError{err: prefixArgumentErrors(dErr.err, fmt.Sprintf("/messages/%d", idx))}
+	}
+	return err
+}
+
+// compileInputSchema compiles the input schema of a method, or returns nil if
+// it has none. Client streams are checked one message at a time, against the
+// schema of the "messages" items.
+func compileInputSchema(md *MethodDesc) (*jsonschema.Schema, error) {
+	if md.InputSchema == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(md.InputSchema())
+	if err != nil {
+		return nil, fmt.Errorf("mcpgw: input schema of %s: %w", md.Method, err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("input.json", bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("mcpgw: input schema of %s: %w", md.Method, err)
+	}
+	url := "input.json"
+	if md.ClientStreams {
+		url += "#/properties/messages/items"
+	}
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("mcpgw: input schema of %s: %w", md.Method, err)
+	}
+	return schema, nil
+}
+
+// checkSchema checks the arguments of a tool call against the input schema
+// of its method, into m.
+func (t *toolInfo) checkSchema(input DecoderInput, m proto.Message) error {
+	schema, err := t.inputSchema()
+	if err != nil || schema == nil {
+		return err
+	}
+	args := input.Arguments()
+	if raw := input.RawArguments(); len(raw) > 0 {
+		// Numbers are checked as given, not as float64
+		if args, err = numberArguments(raw); err != nil {
+			return &decodeError{err: argumentErrors(err, "")}
+		}
+	}
+	// The schema applies to what is decoded, so valid values protojson accepts
+	// in other forms are checked in the form of the schema, after the repairs
+	// of lenient methods
+	md := m.ProtoReflect().Descriptor()
+	if t.method.ArgumentDecoding == ArgumentDecoding_ARGUMENT_DECODING_LENIENT {
+		args, _ = CoerceArguments(args, md)
+	} else {
+		args = schemaArguments(args, md)
+	}
+	var instance any = args
+	if args == nil {
+		instance = map[string]any{}
+	}
+	err = schema.Validate(instance)
+	var valErr *jsonschema.ValidationError
+	if !errors.As(err, &valErr) {
+		return err
+	}
+	return &decodeError{err: schemaErrors(valErr)}
+}
+
+// schemaErrors returns the errors at the leaves of a schema validation error,
+// which are about a single keyword.
+func schemaErrors(err *jsonschema.ValidationError) ArgumentErrors {
+	var rv ArgumentErrors
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		// The errors of each alternative say less than the failure to match one
+		if len(e.Causes) == 0 || strings.HasSuffix(e.KeywordLocation, "/oneOf") || strings.HasSuffix(e.KeywordLocation, "/anyOf") {
+			rv = append(rv, &ArgumentError{Pointer: e.InstanceLocation, Description: e.Message})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(err)
+	return rv.sorted()
+}
+
+// validateMessage validates m with validator. Violations are returned as a
+// decodeError, located in the arguments by the JSON names of their fields.
+func validateMessage(validator protovalidate.Validator, m proto.Message) error {
+	err := validator.Validate(m)
+	var valErr *protovalidate.ValidationError
+	if !errors.As(err, &valErr) {
+		return err
+	}
+	md := m.ProtoReflect().Descriptor()
+	rv := make(ArgumentErrors, 0, len(valErr.Violations))
+	for _, v := range valErr.Violations {
+		rv = append(rv, &ArgumentError{
+			Pointer:     violationPointer(md, v.Proto.GetField()),
+			Description: v.Proto.GetMessage(),
+		})
+	}
+	return &decodeError{err: rv.sorted()}
+}
+
+// violationPointer returns the JSON Pointer of the field at path in a message
+// of type md.
+func violationPointer(md protoreflect.MessageDescriptor, path *validate.FieldPath) string {
+	pointer := ""
+	for _, elem := range path.GetElements() {
+		var fd protoreflect.FieldDescriptor
+		if md != nil {
+			fd = md.Fields().ByNumber(protoreflect.FieldNumber(elem.GetFieldNumber()))
+		}
+		if fd != nil && !fd.IsExtension() {
+			pointer = pointerTo(pointer, fd.JSONName())
+		} else {
+			// Extensions are named as in protojson, eg "[pkg.ext]"
+			pointer = pointerTo(pointer, elem.GetFieldName())
+		}
+
+		switch elem.WhichSubscript() {
+		case validate.FieldPathElement_Index_case:
+			pointer = pointerTo(pointer, strconv.FormatUint(elem.GetIndex(), 10))
+		case validate.FieldPathElement_BoolKey_case:
+			pointer = pointerTo(pointer, strconv.FormatBool(elem.GetBoolKey()))
+		case validate.FieldPathElement_IntKey_case:
+			pointer = pointerTo(pointer, strconv.FormatInt(elem.GetIntKey(), 10))
+		case validate.FieldPathElement_UintKey_case:
+			pointer = pointerTo(pointer, strconv.FormatUint(elem.GetUintKey(), 10))
+		case validate.FieldPathElement_StringKey_case:
+			pointer = pointerTo(pointer, elem.GetStringKey())
+		}
+
+		md = nil
+		if fd != nil {
+			md = fieldMessage(fd)
+		}
+	}
+	return pointer
+}